@@ -13,7 +13,8 @@ var aclsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List user ACLs",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		result, err := apiClient.ACLs().List()
+		ctx := cmd.Context()
+		result, err := apiClient.ACLs().List(ctx)
 		if err != nil {
 			return err
 		}
@@ -25,7 +26,8 @@ var aclsMeCmd = &cobra.Command{
 	Use:   "me",
 	Short: "Get caller details",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		result, err := apiClient.ACLs().Me()
+		ctx := cmd.Context()
+		result, err := apiClient.ACLs().Me(ctx)
 		if err != nil {
 			return err
 		}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -18,6 +19,7 @@ var adRejFindCmd = &cobra.Command{
 	Use:   "find",
 	Short: "Find ad creative rejection reasons",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
 		field, _ := cmd.Flags().GetString("field")
 		op, _ := cmd.Flags().GetString("op")
@@ -49,14 +51,10 @@ var adRejFindCmd = &cobra.Command{
 					sel.Pagination.Offset = offset
 				}
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, apiClient.AdRejections().Find)
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
+			return printAllSelectorPaginated(ctx, sel, pageSize, apiClient.AdRejections().Find)
 		}
 
-		result, _, err := apiClient.AdRejections().Find(sel)
+		result, _, err := apiClient.AdRejections().Find(ctx, sel)
 		if err != nil {
 			return err
 		}
@@ -68,6 +66,7 @@ var adRejGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get an ad creative rejection reason by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		id, _ := cmd.Flags().GetInt64("id")
 		if id == 0 {
 			legacy, _ := cmd.Flags().GetString("product-page-id")
@@ -83,7 +82,7 @@ var adRejGetCmd = &cobra.Command{
 			return fmt.Errorf("--id is required")
 		}
 
-		result, err := apiClient.AdRejections().Get(id)
+		result, err := apiClient.AdRejections().Get(ctx, id)
 		if err != nil {
 			return err
 		}
@@ -95,6 +94,7 @@ var adRejFindAssetsCmd = &cobra.Command{
 	Use:   "find-assets",
 	Short: "Find app assets",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
 		adamID, _ := cmd.Flags().GetInt64("adam-id")
 		limit, _ := cmd.Flags().GetInt("limit")
@@ -149,16 +149,12 @@ var adRejFindAssetsCmd = &cobra.Command{
 			if selectorJSON != "" {
 				pageSize = limit
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, func(s *types.Selector) ([]types.AppAsset, *types.PageDetail, error) {
-				return apiClient.AdRejections().FindAssets(adamID, s)
+			return printAllSelectorPaginated(ctx, sel, pageSize, func(ctx context.Context, s *types.Selector) ([]types.AppAsset, *types.PageDetail, error) {
+				return apiClient.AdRejections().FindAssets(ctx, adamID, s)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.AdRejections().FindAssets(adamID, sel)
+		result, _, err := apiClient.AdRejections().FindAssets(ctx, adamID, sel)
 		if err != nil {
 			return err
 		}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -18,6 +19,7 @@ var adgroupsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create an ad group",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		fromJSON, _ := cmd.Flags().GetString("from-json")
 
@@ -35,7 +37,7 @@ var adgroupsCreateCmd = &cobra.Command{
 			req.Name = name
 			req.AutomatedKeywordsOptIn = searchMatch
 			if bid != "" {
-				m, err := moneyFromAmount(bid)
+				m, err := moneyFromAmount(ctx, bid)
 				if err != nil {
 					return err
 				}
@@ -46,7 +48,7 @@ var adgroupsCreateCmd = &cobra.Command{
 			}
 		}
 
-		result, err := apiClient.AdGroups().Create(campaignID, &req)
+		result, err := apiClient.AdGroups().Create(ctx, campaignID, &req)
 		if err != nil {
 			return err
 		}
@@ -58,9 +60,10 @@ var adgroupsGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get an ad group by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		id, _ := cmd.Flags().GetInt64("id")
-		result, err := apiClient.AdGroups().Get(campaignID, id, fieldsFlag)
+		result, err := apiClient.AdGroups().Get(ctx, campaignID, id, fieldsFlag)
 		if err != nil {
 			return err
 		}
@@ -72,6 +75,7 @@ var adgroupsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List ad groups in a campaign",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		limit, _ := cmd.Flags().GetInt("limit")
 		offset, _ := cmd.Flags().GetInt("offset")
@@ -81,16 +85,12 @@ var adgroupsListCmd = &cobra.Command{
 			if limit == 0 && !cmd.Flags().Changed("limit") {
 				limit = defaultPageSize
 			}
-			result, err := collectAllOffsetPaginated(limit, offset, func(lim, off int) ([]types.AdGroup, *types.PageDetail, error) {
-				return apiClient.AdGroups().List(campaignID, lim, off, fieldsFlag)
+			return printAllOffsetPaginated(ctx, limit, offset, func(ctx context.Context, lim, off int) ([]types.AdGroup, *types.PageDetail, error) {
+				return apiClient.AdGroups().List(ctx, campaignID, lim, off, fieldsFlag)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.AdGroups().List(campaignID, limit, offset, fieldsFlag)
+		result, _, err := apiClient.AdGroups().List(ctx, campaignID, limit, offset, fieldsFlag)
 		if err != nil {
 			return err
 		}
@@ -102,6 +102,7 @@ var adgroupsFindCmd = &cobra.Command{
 	Use:   "find",
 	Short: "Find ad groups in a campaign with selector",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
 		field, _ := cmd.Flags().GetString("field")
@@ -134,16 +135,12 @@ var adgroupsFindCmd = &cobra.Command{
 					sel.Pagination.Offset = offset
 				}
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, func(s *types.Selector) ([]types.AdGroup, *types.PageDetail, error) {
-				return apiClient.AdGroups().Find(campaignID, s)
+			return printAllSelectorPaginated(ctx, sel, pageSize, func(ctx context.Context, s *types.Selector) ([]types.AdGroup, *types.PageDetail, error) {
+				return apiClient.AdGroups().Find(ctx, campaignID, s)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.AdGroups().Find(campaignID, sel)
+		result, _, err := apiClient.AdGroups().Find(ctx, campaignID, sel)
 		if err != nil {
 			return err
 		}
@@ -155,6 +152,7 @@ var adgroupsFindAllCmd = &cobra.Command{
 	Use:   "find-all",
 	Short: "Find ad groups across all campaigns (org-level)",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
 		field, _ := cmd.Flags().GetString("field")
 		op, _ := cmd.Flags().GetString("op")
@@ -186,14 +184,10 @@ var adgroupsFindAllCmd = &cobra.Command{
 					sel.Pagination.Offset = offset
 				}
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, apiClient.AdGroups().FindAll)
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
+			return printAllSelectorPaginated(ctx, sel, pageSize, apiClient.AdGroups().FindAll)
 		}
 
-		result, _, err := apiClient.AdGroups().FindAll(sel)
+		result, _, err := apiClient.AdGroups().FindAll(ctx, sel)
 		if err != nil {
 			return err
 		}
@@ -205,6 +199,7 @@ var adgroupsUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update an ad group",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		id, _ := cmd.Flags().GetInt64("id")
 		name, _ := cmd.Flags().GetString("name")
@@ -217,7 +212,7 @@ var adgroupsUpdateCmd = &cobra.Command{
 			req.Name = name
 		}
 		if bid != "" {
-			m, err := moneyFromAmount(bid)
+			m, err := moneyFromAmount(ctx, bid)
 			if err != nil {
 				return err
 			}
@@ -231,7 +226,7 @@ var adgroupsUpdateCmd = &cobra.Command{
 			req.AutomatedKeywordsOptIn = &v
 		}
 
-		result, err := apiClient.AdGroups().Update(campaignID, id, req)
+		result, err := apiClient.AdGroups().Update(ctx, campaignID, id, req)
 		if err != nil {
 			return err
 		}
@@ -243,9 +238,10 @@ var adgroupsDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete an ad group",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		id, _ := cmd.Flags().GetInt64("id")
-		if err := apiClient.AdGroups().Delete(campaignID, id); err != nil {
+		if err := apiClient.AdGroups().Delete(ctx, campaignID, id); err != nil {
 			return err
 		}
 		fmt.Println("Ad group " + strconv.FormatInt(id, 10) + " deleted")
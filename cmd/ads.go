@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -18,6 +19,7 @@ var adsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create an ad",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		creativeID, _ := cmd.Flags().GetInt64("creative-id")
@@ -30,7 +32,7 @@ var adsCreateCmd = &cobra.Command{
 			Status:     status,
 		}
 
-		result, err := apiClient.Ads().Create(campaignID, adGroupID, req)
+		result, err := apiClient.Ads().Create(ctx, campaignID, adGroupID, req)
 		if err != nil {
 			return err
 		}
@@ -42,10 +44,11 @@ var adsGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get an ad by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		id, _ := cmd.Flags().GetInt64("id")
-		result, err := apiClient.Ads().Get(campaignID, adGroupID, id)
+		result, err := apiClient.Ads().Get(ctx, campaignID, adGroupID, id)
 		if err != nil {
 			return err
 		}
@@ -57,6 +60,7 @@ var adsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List ads in an ad group",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		limit, _ := cmd.Flags().GetInt("limit")
@@ -67,16 +71,12 @@ var adsListCmd = &cobra.Command{
 			if limit == 0 && !cmd.Flags().Changed("limit") {
 				limit = defaultPageSize
 			}
-			result, err := collectAllOffsetPaginated(limit, offset, func(lim, off int) ([]types.Ad, *types.PageDetail, error) {
-				return apiClient.Ads().List(campaignID, adGroupID, lim, off)
+			return printAllOffsetPaginated(ctx, limit, offset, func(ctx context.Context, lim, off int) ([]types.Ad, *types.PageDetail, error) {
+				return apiClient.Ads().List(ctx, campaignID, adGroupID, lim, off)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.Ads().List(campaignID, adGroupID, limit, offset)
+		result, _, err := apiClient.Ads().List(ctx, campaignID, adGroupID, limit, offset)
 		if err != nil {
 			return err
 		}
@@ -88,6 +88,7 @@ var adsFindCmd = &cobra.Command{
 	Use:   "find",
 	Short: "Find ads in an ad group",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
@@ -121,16 +122,12 @@ var adsFindCmd = &cobra.Command{
 					sel.Pagination.Offset = offset
 				}
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, func(s *types.Selector) ([]types.Ad, *types.PageDetail, error) {
-				return apiClient.Ads().Find(campaignID, adGroupID, s)
+			return printAllSelectorPaginated(ctx, sel, pageSize, func(ctx context.Context, s *types.Selector) ([]types.Ad, *types.PageDetail, error) {
+				return apiClient.Ads().Find(ctx, campaignID, adGroupID, s)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.Ads().Find(campaignID, adGroupID, sel)
+		result, _, err := apiClient.Ads().Find(ctx, campaignID, adGroupID, sel)
 		if err != nil {
 			return err
 		}
@@ -142,6 +139,7 @@ var adsFindAllCmd = &cobra.Command{
 	Use:   "find-all",
 	Short: "Find ads across all campaigns (org-level)",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
 		field, _ := cmd.Flags().GetString("field")
 		op, _ := cmd.Flags().GetString("op")
@@ -173,14 +171,10 @@ var adsFindAllCmd = &cobra.Command{
 					sel.Pagination.Offset = offset
 				}
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, apiClient.Ads().FindAll)
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
+			return printAllSelectorPaginated(ctx, sel, pageSize, apiClient.Ads().FindAll)
 		}
 
-		result, _, err := apiClient.Ads().FindAll(sel)
+		result, _, err := apiClient.Ads().FindAll(ctx, sel)
 		if err != nil {
 			return err
 		}
@@ -192,6 +186,7 @@ var adsUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update an ad",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		id, _ := cmd.Flags().GetInt64("id")
@@ -199,7 +194,7 @@ var adsUpdateCmd = &cobra.Command{
 		status, _ := cmd.Flags().GetString("status")
 
 		req := &types.AdUpdate{Name: name, Status: status}
-		result, err := apiClient.Ads().Update(campaignID, adGroupID, id, req)
+		result, err := apiClient.Ads().Update(ctx, campaignID, adGroupID, id, req)
 		if err != nil {
 			return err
 		}
@@ -211,10 +206,11 @@ var adsDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete an ad",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		id, _ := cmd.Flags().GetInt64("id")
-		if err := apiClient.Ads().Delete(campaignID, adGroupID, id); err != nil {
+		if err := apiClient.Ads().Delete(ctx, campaignID, adGroupID, id); err != nil {
 			return err
 		}
 		fmt.Println("Ad " + strconv.FormatInt(id, 10) + " deleted")
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +15,7 @@ var appsSearchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Search for iOS apps",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		query, _ := cmd.Flags().GetString("query")
 		returnOwned, _ := cmd.Flags().GetBool("return-owned-apps")
 		limit, _ := cmd.Flags().GetInt("limit")
@@ -24,16 +26,12 @@ var appsSearchCmd = &cobra.Command{
 			if limit == 0 && !cmd.Flags().Changed("limit") {
 				limit = defaultPageSize
 			}
-			result, err := collectAllOffsetPaginated(limit, offset, func(lim, off int) ([]types.AppInfo, *types.PageDetail, error) {
-				return apiClient.Apps().Search(query, returnOwned, lim, off)
+			return printAllOffsetPaginated(ctx, limit, offset, func(ctx context.Context, lim, off int) ([]types.AppInfo, *types.PageDetail, error) {
+				return apiClient.Apps().Search(ctx, query, returnOwned, lim, off)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.Apps().Search(query, returnOwned, limit, offset)
+		result, _, err := apiClient.Apps().Search(ctx, query, returnOwned, limit, offset)
 		if err != nil {
 			return err
 		}
@@ -45,6 +43,7 @@ var appsEligibilityCmd = &cobra.Command{
 	Use:   "eligibility",
 	Short: "Check app eligibility for Apple Ads",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
 		adamID, _ := cmd.Flags().GetString("adam-id")
 
@@ -65,7 +64,7 @@ var appsEligibilityCmd = &cobra.Command{
 			sel = &types.Selector{}
 		}
 
-		result, err := apiClient.Apps().Eligibility(sel)
+		result, err := apiClient.Apps().Eligibility(ctx, sel)
 		if err != nil {
 			return err
 		}
@@ -77,8 +76,9 @@ var appsDetailsCmd = &cobra.Command{
 	Use:   "details",
 	Short: "Get app details",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		adamID, _ := cmd.Flags().GetInt64("adam-id")
-		result, err := apiClient.Apps().Details(adamID)
+		result, err := apiClient.Apps().Details(ctx, adamID)
 		if err != nil {
 			return err
 		}
@@ -90,8 +90,9 @@ var appsLocalizedCmd = &cobra.Command{
 	Use:   "localized",
 	Short: "Get localized app details",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		adamID, _ := cmd.Flags().GetInt64("adam-id")
-		result, err := apiClient.Apps().LocalizedDetails(adamID)
+		result, err := apiClient.Apps().LocalizedDetails(ctx, adamID)
 		if err != nil {
 			return err
 		}
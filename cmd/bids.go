@@ -0,0 +1,457 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/bidrules"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/report"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var bidsCmd = &cobra.Command{
+	Use:   "bids",
+	Short: "Adjust keyword bids in bulk",
+}
+
+var bidsOptimizeCmd = &cobra.Command{
+	Use:   "optimize",
+	Short: "Adjust keyword bids from rules evaluated against report data",
+	Long: `Evaluate the rules in --rules against each keyword's report totals and
+update the bids they change. Each rule has its own lookback (the last N
+complete days in the report time zone). The first rule whose conditions all
+hold for a keyword decides its new bid.
+
+Paused and deleted keywords are left alone. Keywords without their own bid
+start from their ad group's default bid.
+
+--dry-run prints the planned changes without updating anything. Otherwise
+the changes are shown and applied after confirmation. --change-log appends
+one JSON object per change (with its outcome) to a file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
+		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
+		rulesFile, _ := cmd.Flags().GetString("rules")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		changeLog, _ := cmd.Flags().GetString("change-log")
+
+		rules, err := bidrules.Load(rulesFile)
+		if err != nil {
+			return err
+		}
+		limits, err := bidLimits(cmd)
+		if err != nil {
+			return err
+		}
+		timeZone, err := reportTimeZone(cmd)
+		if err != nil {
+			return err
+		}
+		loc, err := reportLocation(ctx, timeZone)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().In(loc)
+		windows := map[int]report.Window{}
+		totals := map[int]map[int64]*types.SpendRow{}
+		var keywords []keywordRowMeta
+		seen := map[int64]bool{}
+		lookbacks := rules.Lookbacks()
+		reports, err := mapConcurrent(ctx, lookbacks, func(ctx context.Context, days int) ([]keywordReportRow, error) {
+			rows, err := keywordReportRows(ctx, campaignID, adGroupID, report.LastDays(days, now), timeZone)
+			if err != nil {
+				return nil, fmt.Errorf("keywords report (%d days): %w", days, err)
+			}
+			return rows, nil
+		})
+		if err != nil {
+			return err
+		}
+		for i, days := range lookbacks {
+			windows[days] = report.LastDays(days, now)
+			totals[days] = map[int64]*types.SpendRow{}
+			for _, r := range reports[i] {
+				totals[days][r.KeywordID] = r.total
+				if !seen[r.KeywordID] {
+					seen[r.KeywordID] = true
+					keywords = append(keywords, r.keywordRowMeta)
+				}
+			}
+		}
+
+		defaultBids := adGroupBids{campaignID: campaignID}
+		var changes []*bidChange
+		limited, skipped := 0, 0
+		for _, k := range keywords {
+			if !k.active() {
+				continue
+			}
+			for i := range rules.Rules {
+				rule := &rules.Rules[i]
+				row := totals[rule.LookbackDays][k.KeywordID]
+				if !rule.Match(row) {
+					continue
+				}
+				bid := k.BidAmount
+				if bid == nil {
+					if bid, err = defaultBids.get(ctx, k.AdGroupID); err != nil {
+						return err
+					}
+				}
+				next, limit, err := rule.Apply(bid, limits)
+				w := windows[rule.LookbackDays]
+				if err != nil {
+					// Report the keyword and carry on with the others.
+					c := newBidChange(campaignID, k, bid, &types.Money{Currency: bid.Currency}, rule.Name, "")
+					c.Period = w.Start.Format(report.DateLayout) + ".." + w.End.Format(report.DateLayout)
+					c.setMetrics(row)
+					c.Status, c.Error = "skipped", err.Error()
+					changes = append(changes, c)
+					skipped++
+					break
+				}
+				c := newBidChange(campaignID, k, bid, next, rule.Name, limit)
+				c.Period = w.Start.Format(report.DateLayout) + ".." + w.End.Format(report.DateLayout)
+				c.setMetrics(row)
+				if sameAmount(c.NewBid, c.CurrentBid) {
+					limited++
+				} else {
+					changes = append(changes, c)
+				}
+				break
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Bids: %d keywords evaluated against %d rules; %d bids to change, %d held at a floor or ceiling, %d skipped.\n",
+			len(keywords), len(rules.Rules), len(changes)-skipped, limited, skipped)
+
+		return finishBidChanges(ctx, changes, dryRun, yes, changeLog)
+	},
+}
+
+// finishBidChanges prints changes with --dry-run; otherwise it applies the
+// planned ones after confirmation and prints the outcome. Skipped changes are
+// only reported. Either way the changes are appended to changeLog.
+func finishBidChanges(ctx context.Context, changes []*bidChange, dryRun, yes bool, changeLog string) error {
+	if len(changes) == 0 {
+		return printOutput([]*bidChange{})
+	}
+	planned := 0
+	for _, c := range changes {
+		if c.Status == "planned" {
+			planned++
+		}
+	}
+	if dryRun || planned == 0 {
+		if !dryRun {
+			printBidChanges(changes)
+		}
+		if err := writeBidChangeLog(changeLog, changes); err != nil {
+			return err
+		}
+		return printOutput(changes)
+	}
+	if !yes {
+		printBidChanges(changes)
+		ok, err := confirm(fmt.Sprintf("Update %d keyword bids?", planned))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Bid changes cancelled.")
+			return nil
+		}
+	}
+
+	runErr := applyBidChanges(ctx, changes)
+	if err := writeBidChangeLog(changeLog, changes); err != nil {
+		return err
+	}
+	if err := printOutput(changes); err != nil {
+		return err
+	}
+	return runErr
+}
+
+// keywordRowMeta is the part of a keyword report row's metadata that bid
+// changes need.
+type keywordRowMeta struct {
+	KeywordID     int64        `json:"keywordId"`
+	AdGroupID     int64        `json:"adGroupId"`
+	Keyword       string       `json:"keyword"`
+	MatchType     string       `json:"matchType"`
+	KeywordStatus string       `json:"keywordStatus"`
+	BidAmount     *types.Money `json:"bidAmount"`
+	Deleted       bool         `json:"deleted"`
+}
+
+// keywordReportRow is a keyword report row with decoded metadata.
+type keywordReportRow struct {
+	keywordRowMeta
+	total    *types.SpendRow
+	insights *types.Insights
+}
+
+// keywordReportRows fetches the keyword report for w as row totals,
+// including keywords without activity. adGroupID 0 covers the campaign.
+func keywordReportRows(ctx context.Context, campaignID, adGroupID int64, w report.Window, timeZone string) ([]keywordReportRow, error) {
+	var scope *int64
+	if adGroupID > 0 {
+		scope = &adGroupID
+	}
+	req := &types.ReportingRequest{
+		StartTime:                  w.Start.Format(report.DateLayout),
+		EndTime:                    w.End.Format(report.DateLayout),
+		TimeZone:                   timeZone,
+		ReturnRowTotals:            true,
+		ReturnRecordsWithNoMetrics: true,
+		Selector: &types.Selector{
+			OrderBy: []*types.Sorting{{Field: "localSpend", SortOrder: "DESCENDING"}},
+		},
+	}
+	data, err := fetchReport(ctx, req, pagedReport(func(ctx context.Context, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+		return apiClient.Reports().Keywords(ctx, campaignID, scope, req)
+	}))
+	if err != nil {
+		return nil, err
+	}
+	var out []keywordReportRow
+	for _, row := range data.Row {
+		r := keywordReportRow{total: row.Total, insights: row.Insights}
+		b, err := json.Marshal(row.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &r.keywordRowMeta); err != nil {
+			return nil, fmt.Errorf("decode keyword report metadata: %w", err)
+		}
+		if r.KeywordID != 0 {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// active reports whether the keyword's bid may be changed.
+func (k keywordRowMeta) active() bool {
+	return !k.Deleted && (k.KeywordStatus == "" || k.KeywordStatus == "ACTIVE")
+}
+
+// adGroupBids looks up ad group default bids on first use.
+type adGroupBids struct {
+	campaignID int64
+	bids       map[int64]*types.Money
+}
+
+func (a *adGroupBids) get(ctx context.Context, adGroupID int64) (*types.Money, error) {
+	if a.bids == nil {
+		groups, err := collectAllSelectorPaginated(ctx, &types.Selector{}, defaultPageSize, func(ctx context.Context, sel *types.Selector) ([]types.AdGroup, *types.PageDetail, error) {
+			return apiClient.AdGroups().Find(ctx, a.campaignID, sel)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("find ad groups: %w", err)
+		}
+		a.bids = map[int64]*types.Money{}
+		for _, g := range groups {
+			a.bids[g.ID] = g.DefaultBidAmount
+		}
+	}
+	bid := a.bids[adGroupID]
+	if bid == nil {
+		return nil, fmt.Errorf("ad group %d has no default bid", adGroupID)
+	}
+	return bid, nil
+}
+
+// bidChange is one keyword bid update and its outcome.
+type bidChange struct {
+	KeywordID    int64  `json:"keywordId"`
+	CampaignID   int64  `json:"campaignId"`
+	AdGroupID    int64  `json:"adGroupId"`
+	Keyword      string `json:"keyword"`
+	MatchType    string `json:"matchType,omitempty"`
+	Reason       string `json:"reason"`
+	Period       string `json:"period,omitempty"`
+	Taps         int64  `json:"taps"`
+	Installs     int64  `json:"installs"`
+	LocalSpend   string `json:"localSpend,omitempty"`
+	AvgCPA       string `json:"avgCPA,omitempty"`
+	CurrentBid   string `json:"currentBid"`
+	SuggestedBid string `json:"suggestedBid,omitempty"`
+	NewBid       string `json:"newBid"`
+	Currency     string `json:"currency"`
+	Limit        string `json:"limit,omitempty"` // the cap that shaped NewBid
+	Status       string `json:"status"`          // planned, skipped, updated, error
+	Error        string `json:"error,omitempty"`
+}
+
+func newBidChange(campaignID int64, k keywordRowMeta, bid, next *types.Money, reason, limit string) *bidChange {
+	return &bidChange{
+		KeywordID:  k.KeywordID,
+		CampaignID: campaignID,
+		AdGroupID:  k.AdGroupID,
+		Keyword:    k.Keyword,
+		MatchType:  k.MatchType,
+		Reason:     reason,
+		CurrentBid: bid.Amount,
+		NewBid:     next.Amount,
+		Currency:   next.Currency,
+		Limit:      limit,
+		Status:     "planned",
+	}
+}
+
+func (c *bidChange) setMetrics(row *types.SpendRow) {
+	if row == nil {
+		return
+	}
+	c.Taps, c.Installs = row.Taps, row.Installs
+	if row.LocalSpend != nil {
+		c.LocalSpend = row.LocalSpend.Amount
+	}
+	if row.AvgCPA != nil && row.Installs > 0 {
+		c.AvgCPA = row.AvgCPA.Amount
+	}
+}
+
+// sameAmount reports whether two decimal amounts are equal, regardless of
+// their precision.
+func sameAmount(a, b string) bool {
+	x, ok1 := new(big.Rat).SetString(a)
+	y, ok2 := new(big.Rat).SetString(b)
+	if !ok1 || !ok2 {
+		return a == b
+	}
+	return x.Cmp(y) == 0
+}
+
+// bidLimits reads --max-increase and --max-decrease.
+func bidLimits(cmd *cobra.Command) (bidrules.Limits, error) {
+	var lim bidrules.Limits
+	for _, f := range []struct {
+		name string
+		dst  **big.Rat
+	}{{"max-increase", &lim.MaxIncreasePct}, {"max-decrease", &lim.MaxDecreasePct}} {
+		raw, _ := cmd.Flags().GetString(f.name)
+		if raw == "" {
+			continue
+		}
+		v, ok := new(big.Rat).SetString(strings.TrimSuffix(strings.TrimSpace(raw), "%"))
+		if !ok || v.Sign() <= 0 {
+			return lim, fmt.Errorf("invalid --%s %q (want a percentage such as 25%%)", f.name, raw)
+		}
+		*f.dst = v.Quo(v, big.NewRat(100, 1))
+	}
+	return lim, nil
+}
+
+func printBidChanges(changes []*bidChange) {
+	for _, c := range changes {
+		if c.Status == "skipped" {
+			fmt.Fprintf(os.Stderr, "! keyword %d %q: %s %s skipped (%s: %s)\n", c.KeywordID, c.Keyword, c.CurrentBid, c.Currency, c.Reason, c.Error)
+			continue
+		}
+		note := c.Reason
+		if c.SuggestedBid != "" {
+			note += " " + c.SuggestedBid
+		}
+		if c.Limit != "" {
+			note += ", " + c.Limit
+		}
+		fmt.Fprintf(os.Stderr, "~ keyword %d %q: %s -> %s %s (%s)\n", c.KeywordID, c.Keyword, c.CurrentBid, c.NewBid, c.Currency, note)
+	}
+}
+
+// applyBidChanges updates the planned bids per ad group in bulk requests,
+// --concurrency ad groups at a time. It stops at the first failed request.
+func applyBidChanges(ctx context.Context, changes []*bidChange) error {
+	type group struct{ campaignID, adGroupID int64 }
+	byGroup := map[group][]*bidChange{}
+	var order []group
+	for _, c := range changes {
+		if c.Status != "planned" {
+			continue
+		}
+		g := group{c.CampaignID, c.AdGroupID}
+		if _, ok := byGroup[g]; !ok {
+			order = append(order, g)
+		}
+		byGroup[g] = append(byGroup[g], c)
+	}
+
+	return forEachConcurrent(ctx, order, func(ctx context.Context, g group) error {
+		for _, part := range chunk(byGroup[g], maxBulkKeywords) {
+			in := make([]types.Keyword, len(part))
+			for i, c := range part {
+				in[i] = types.Keyword{ID: c.KeywordID, BidAmount: &types.Money{Amount: c.NewBid, Currency: c.Currency}}
+			}
+			_, err := apiClient.Keywords().Update(ctx, g.campaignID, g.adGroupID, in)
+			for _, c := range part {
+				if err != nil {
+					c.Status, c.Error = "error", err.Error()
+				} else {
+					c.Status = "updated"
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("update bids in ad group %d: %w", g.adGroupID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// writeBidChangeLog appends one JSON line per change to path. An empty path
+// disables the log.
+func writeBidChangeLog(path string, changes []*bidChange) error {
+	if path == "" || len(changes) == 0 {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open change log: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	at := time.Now().UTC().Format(time.RFC3339)
+	for _, c := range changes {
+		entry := struct {
+			Time  string `json:"time"`
+			OrgID string `json:"orgId,omitempty"`
+			*bidChange
+		}{at, activeOrgID, c}
+		if err := enc.Encode(entry); err != nil {
+			f.Close()
+			return fmt.Errorf("write change log: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write change log: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(bidsCmd)
+	bidsCmd.AddCommand(bidsOptimizeCmd)
+
+	f := bidsOptimizeCmd.Flags()
+	f.Int64("campaign-id", 0, "Campaign whose keywords are evaluated")
+	bidsOptimizeCmd.MarkFlagRequired("campaign-id")
+	f.Int64("adgroup-id", 0, "Only evaluate keywords in this ad group")
+	f.String("rules", "", "Rules file (YAML or JSON)")
+	bidsOptimizeCmd.MarkFlagRequired("rules")
+	f.String("max-increase", "", "Largest increase of any single bid, as a percentage (e.g. 25%)")
+	f.String("max-decrease", "", "Largest decrease of any single bid, as a percentage (e.g. 20%)")
+	f.String("time-zone", "", "Report time zone: UTC or ORTZ")
+	f.Bool("dry-run", false, "Show the planned changes without updating bids")
+	f.Bool("yes", false, "Update without asking for confirmation")
+	f.String("change-log", "", "Append each change and its outcome as a JSON line to this file")
+}
@@ -14,12 +14,13 @@ var boCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a budget order",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		fromJSON, _ := cmd.Flags().GetString("from-json")
 		var req types.BudgetOrderCreate
 		if err := parseJSONInput(fromJSON, &req); err != nil {
 			return err
 		}
-		result, err := apiClient.BudgetOrders().Create(&req)
+		result, err := apiClient.BudgetOrders().Create(ctx, &req)
 		if err != nil {
 			return err
 		}
@@ -31,8 +32,9 @@ var boGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a budget order by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		id, _ := cmd.Flags().GetInt64("id")
-		result, err := apiClient.BudgetOrders().Get(id)
+		result, err := apiClient.BudgetOrders().Get(ctx, id)
 		if err != nil {
 			return err
 		}
@@ -44,6 +46,7 @@ var boListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List budget orders",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		limit, _ := cmd.Flags().GetInt("limit")
 		offset, _ := cmd.Flags().GetInt("offset")
 		all, _ := cmd.Flags().GetBool("all")
@@ -52,14 +55,10 @@ var boListCmd = &cobra.Command{
 			if limit == 0 && !cmd.Flags().Changed("limit") {
 				limit = defaultPageSize
 			}
-			result, err := collectAllOffsetPaginated(limit, offset, apiClient.BudgetOrders().List)
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
+			return printAllOffsetPaginated(ctx, limit, offset, apiClient.BudgetOrders().List)
 		}
 
-		result, _, err := apiClient.BudgetOrders().List(limit, offset)
+		result, _, err := apiClient.BudgetOrders().List(ctx, limit, offset)
 		if err != nil {
 			return err
 		}
@@ -71,13 +70,14 @@ var boUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update a budget order",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		id, _ := cmd.Flags().GetInt64("id")
 		fromJSON, _ := cmd.Flags().GetString("from-json")
 		var req types.BudgetOrderUpdate
 		if err := parseJSONInput(fromJSON, &req); err != nil {
 			return err
 		}
-		result, err := apiClient.BudgetOrders().Update(id, &req)
+		result, err := apiClient.BudgetOrders().Update(ctx, id, &req)
 		if err != nil {
 			return err
 		}
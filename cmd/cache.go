@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local report cache",
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete all cached report responses",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := api.ReportCacheDir()
+		if err != nil {
+			return err
+		}
+		n, err := api.ClearReportCache(dir)
+		if err != nil {
+			return fmt.Errorf("clear report cache: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Removed %d cached reports from %s\n", n, dir)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -18,6 +19,7 @@ var campaignsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a campaign",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		name, _ := cmd.Flags().GetString("name")
 		adamID, _ := cmd.Flags().GetInt64("adam-id")
 		budget, _ := cmd.Flags().GetString("budget")
@@ -38,14 +40,14 @@ var campaignsCreateCmd = &cobra.Command{
 				req.CountriesOrRegions = strings.Split(countries, ",")
 			}
 			if budget != "" {
-				m, err := moneyFromAmount(budget)
+				m, err := moneyFromAmount(ctx, budget)
 				if err != nil {
 					return err
 				}
 				req.BudgetAmount = m
 			}
 			if dailyBudget != "" {
-				m, err := moneyFromAmount(dailyBudget)
+				m, err := moneyFromAmount(ctx, dailyBudget)
 				if err != nil {
 					return err
 				}
@@ -56,7 +58,7 @@ var campaignsCreateCmd = &cobra.Command{
 			}
 		}
 
-		result, err := apiClient.Campaigns().Create(&req)
+		result, err := apiClient.Campaigns().Create(ctx, &req)
 		if err != nil {
 			return err
 		}
@@ -68,8 +70,9 @@ var campaignsGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a campaign by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		id, _ := cmd.Flags().GetInt64("id")
-		result, err := apiClient.Campaigns().Get(id, fieldsFlag)
+		result, err := apiClient.Campaigns().Get(ctx, id, fieldsFlag)
 		if err != nil {
 			return err
 		}
@@ -81,6 +84,7 @@ var campaignsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all campaigns",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		limit, _ := cmd.Flags().GetInt("limit")
 		offset, _ := cmd.Flags().GetInt("offset")
 		all, _ := cmd.Flags().GetBool("all")
@@ -89,16 +93,12 @@ var campaignsListCmd = &cobra.Command{
 			if limit == 0 && !cmd.Flags().Changed("limit") {
 				limit = defaultPageSize
 			}
-			result, err := collectAllOffsetPaginated(limit, offset, func(lim, off int) ([]types.Campaign, *types.PageDetail, error) {
-				return apiClient.Campaigns().List(lim, off, fieldsFlag)
+			return printAllOffsetPaginated(ctx, limit, offset, func(ctx context.Context, lim, off int) ([]types.Campaign, *types.PageDetail, error) {
+				return apiClient.Campaigns().List(ctx, lim, off, fieldsFlag)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, pagination, err := apiClient.Campaigns().List(limit, offset, fieldsFlag)
+		result, pagination, err := apiClient.Campaigns().List(ctx, limit, offset, fieldsFlag)
 		if err != nil {
 			return err
 		}
@@ -114,6 +114,7 @@ var campaignsFindCmd = &cobra.Command{
 	Use:   "find",
 	Short: "Find campaigns with selector",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
 		field, _ := cmd.Flags().GetString("field")
 		op, _ := cmd.Flags().GetString("op")
@@ -147,14 +148,10 @@ var campaignsFindCmd = &cobra.Command{
 					sel.Pagination.Offset = offset
 				}
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, apiClient.Campaigns().Find)
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
+			return printAllSelectorPaginated(ctx, sel, pageSize, apiClient.Campaigns().Find)
 		}
 
-		result, _, err := apiClient.Campaigns().Find(sel)
+		result, _, err := apiClient.Campaigns().Find(ctx, sel)
 		if err != nil {
 			return err
 		}
@@ -166,6 +163,7 @@ var campaignsUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update a campaign",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		id, _ := cmd.Flags().GetInt64("id")
 		name, _ := cmd.Flags().GetString("name")
 		budget, _ := cmd.Flags().GetString("budget")
@@ -178,14 +176,14 @@ var campaignsUpdateCmd = &cobra.Command{
 			req.Name = name
 		}
 		if budget != "" {
-			m, err := moneyFromAmount(budget)
+			m, err := moneyFromAmount(ctx, budget)
 			if err != nil {
 				return err
 			}
 			req.BudgetAmount = m
 		}
 		if dailyBudget != "" {
-			m, err := moneyFromAmount(dailyBudget)
+			m, err := moneyFromAmount(ctx, dailyBudget)
 			if err != nil {
 				return err
 			}
@@ -198,7 +196,7 @@ var campaignsUpdateCmd = &cobra.Command{
 			req.CountriesOrRegions = strings.Split(countries, ",")
 		}
 
-		result, err := apiClient.Campaigns().Update(id, req)
+		result, err := apiClient.Campaigns().Update(ctx, id, req)
 		if err != nil {
 			return err
 		}
@@ -210,8 +208,9 @@ var campaignsDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a campaign",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		id, _ := cmd.Flags().GetInt64("id")
-		if err := apiClient.Campaigns().Delete(id); err != nil {
+		if err := apiClient.Campaigns().Delete(ctx, id); err != nil {
 			return err
 		}
 		fmt.Println("Campaign " + strconv.FormatInt(id, 10) + " deleted")
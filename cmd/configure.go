@@ -8,8 +8,9 @@ import (
 var configureCmd = &cobra.Command{
 	Use:   "configure",
 	Short: "Interactive setup for Apple Ads API credentials",
+	Long:  "Prompts for Apple Ads API credentials and saves them to ~/.aads/config.yaml. With --profile (or AADS_PROFILE), the credentials are saved as a named profile instead of the top-level default.",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return config.RunInteractiveSetup()
+		return config.RunInteractiveSetup(profileFlag)
 	},
 }
 
@@ -16,6 +16,7 @@ var creativesCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a creative",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		adamID, _ := cmd.Flags().GetInt64("adam-id")
 		productPageID, _ := cmd.Flags().GetString("product-page-id")
 		name, _ := cmd.Flags().GetString("name")
@@ -26,7 +27,7 @@ var creativesCreateCmd = &cobra.Command{
 			Name:          name,
 		}
 
-		result, err := apiClient.Creatives().Create(req)
+		result, err := apiClient.Creatives().Create(ctx, req)
 		if err != nil {
 			return err
 		}
@@ -38,8 +39,9 @@ var creativesGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a creative by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		id, _ := cmd.Flags().GetInt64("id")
-		result, err := apiClient.Creatives().Get(id)
+		result, err := apiClient.Creatives().Get(ctx, id)
 		if err != nil {
 			return err
 		}
@@ -51,6 +53,7 @@ var creativesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all creatives",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		limit, _ := cmd.Flags().GetInt("limit")
 		offset, _ := cmd.Flags().GetInt("offset")
 		all, _ := cmd.Flags().GetBool("all")
@@ -59,14 +62,10 @@ var creativesListCmd = &cobra.Command{
 			if limit == 0 && !cmd.Flags().Changed("limit") {
 				limit = defaultPageSize
 			}
-			result, err := collectAllOffsetPaginated(limit, offset, apiClient.Creatives().List)
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
+			return printAllOffsetPaginated(ctx, limit, offset, apiClient.Creatives().List)
 		}
 
-		result, _, err := apiClient.Creatives().List(limit, offset)
+		result, _, err := apiClient.Creatives().List(ctx, limit, offset)
 		if err != nil {
 			return err
 		}
@@ -78,6 +77,7 @@ var creativesFindCmd = &cobra.Command{
 	Use:   "find",
 	Short: "Find creatives with selector",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
 		field, _ := cmd.Flags().GetString("field")
 		op, _ := cmd.Flags().GetString("op")
@@ -109,14 +109,10 @@ var creativesFindCmd = &cobra.Command{
 					sel.Pagination.Offset = offset
 				}
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, apiClient.Creatives().Find)
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
+			return printAllSelectorPaginated(ctx, sel, pageSize, apiClient.Creatives().Find)
 		}
 
-		result, _, err := apiClient.Creatives().Find(sel)
+		result, _, err := apiClient.Creatives().Find(ctx, sel)
 		if err != nil {
 			return err
 		}
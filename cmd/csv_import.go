@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/keywords"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+// csvTarget is where a --from-csv import creates its rows.
+type csvTarget struct {
+	// perAdGroup is false for campaign-level negatives.
+	perAdGroup bool
+	// fields the CSV may map besides text.
+	fields []string
+	// existing returns the keys (keywords.Key) of what the ad group (0 for
+	// the campaign) already has.
+	existing func(ctx context.Context, adGroupID int64) (map[string]bool, error)
+	// create uploads one chunk and returns the new IDs in request order.
+	create func(ctx context.Context, adGroupID int64, rows []*keywords.Row) ([]int64, error)
+}
+
+// addCSVImportFlags registers the --from-csv flags of the keyword and
+// negative create commands.
+func addCSVImportFlags(c *cobra.Command) {
+	c.Flags().String("from-csv", "", "CSV file with a header row (- for stdin)")
+	c.Flags().StringArray("csv-column", nil, "Map a field to a CSV header, e.g. text=Keyword (fields: text, matchType, bid, adgroup; repeatable)")
+	c.Flags().Bool("dry-run", false, "With --from-csv: validate and check for existing keywords without creating anything")
+}
+
+// checkCSVOnlyFlags rejects the flags that only apply to --from-csv, so
+// that, for example, --dry-run with --text doesn't create the keyword.
+func checkCSVOnlyFlags(cmd *cobra.Command) error {
+	for _, name := range []string{"dry-run", "csv-column"} {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--%s requires --from-csv", name)
+		}
+	}
+	return nil
+}
+
+// runCSVImport validates the --from-csv rows, drops the ones that repeat an
+// earlier row or already exist in the target, and creates the rest in bulk
+// requests per ad group. Nothing is created when a row is invalid. A failed
+// request marks its rows as errors and the import continues; the per-row
+// results are printed either way.
+func runCSVImport(cmd *cobra.Command, adGroupID int64, t csvTarget) error {
+	ctx := cmd.Context()
+	path, _ := cmd.Flags().GetString("from-csv")
+	columnSpecs, _ := cmd.Flags().GetStringArray("csv-column")
+	matchType, _ := cmd.Flags().GetString("match-type")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if cmd.Flags().Changed("from-json") || cmd.Flags().Changed("text") {
+		return fmt.Errorf("--from-csv cannot be combined with --from-json or --text")
+	}
+
+	columns, err := keywords.ParseColumns(columnSpecs)
+	if err != nil {
+		return err
+	}
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("read CSV: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	rows, err := keywords.ParseCSV(in, keywords.CSVOptions{
+		Fields:           t.fields,
+		Columns:          columns,
+		DefaultMatchType: matchType,
+		DefaultAdGroupID: adGroupID,
+	})
+	if err != nil {
+		return err
+	}
+
+	invalid := 0
+	var groups []int64
+	planned := map[int64][]*keywords.Row{}
+	for _, r := range rows {
+		if r.Status == "planned" && t.perAdGroup && r.AdGroupID == 0 {
+			r.Status, r.Error = "invalid", "no ad group; pass --adgroup-id or map an adgroup column"
+		}
+		switch r.Status {
+		case "invalid":
+			invalid++
+		case "planned":
+			if _, ok := planned[r.AdGroupID]; !ok {
+				groups = append(groups, r.AdGroupID)
+			}
+			planned[r.AdGroupID] = append(planned[r.AdGroupID], r)
+		}
+	}
+	if invalid > 0 {
+		if err := printOutput(rows); err != nil {
+			return err
+		}
+		return fmt.Errorf("%d invalid CSV rows; nothing was created", invalid)
+	}
+
+	for _, gid := range groups {
+		have, err := t.existing(ctx, gid)
+		if err != nil {
+			return err
+		}
+		var keep []*keywords.Row
+		for _, r := range planned[gid] {
+			if have[keywords.Key(gid, r.MatchType, r.Text)] {
+				r.Status, r.Error = "exists", ""
+				continue
+			}
+			keep = append(keep, r)
+		}
+		planned[gid] = keep
+	}
+
+	if !dryRun {
+		for _, gid := range groups {
+			for _, part := range chunk(planned[gid], maxBulkKeywords) {
+				ids, err := t.create(ctx, gid, part)
+				for i, r := range part {
+					if err != nil {
+						r.Status, r.Error = "error", err.Error()
+						continue
+					}
+					r.Status = "created"
+					if i < len(ids) {
+						r.ID = ids[i]
+					}
+				}
+			}
+		}
+	}
+
+	counts := map[string]int{}
+	for _, r := range rows {
+		counts[r.Status]++
+	}
+	fmt.Fprintf(os.Stderr, "CSV import: %d rows; %d created, %d planned, %d already exist, %d duplicates, %d failed.\n",
+		len(rows), counts["created"], counts["planned"], counts["exists"], counts["duplicate"], counts["error"])
+	if err := printOutput(rows); err != nil {
+		return err
+	}
+	if counts["error"] > 0 {
+		return fmt.Errorf("%d CSV rows failed", counts["error"])
+	}
+	return nil
+}
+
+// keywordCSVTarget creates targeting keywords. Rows without a bid use --bid,
+// or the ad group's default bid when that is empty too.
+func keywordCSVTarget(cmd *cobra.Command, campaignID int64) csvTarget {
+	defaultBid, _ := cmd.Flags().GetString("bid")
+	return csvTarget{
+		perAdGroup: true,
+		fields:     []string{keywords.FieldMatchType, keywords.FieldBid, keywords.FieldAdGroup},
+		existing: func(ctx context.Context, adGroupID int64) (map[string]bool, error) {
+			list, err := collectAllSelectorPaginated(ctx, &types.Selector{}, defaultPageSize, func(ctx context.Context, sel *types.Selector) ([]types.Keyword, *types.PageDetail, error) {
+				return apiClient.Keywords().Find(ctx, campaignID, adGroupID, sel)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("find keywords in ad group %d: %w", adGroupID, err)
+			}
+			have := map[string]bool{}
+			for _, k := range list {
+				if !k.Deleted {
+					have[keywords.Key(adGroupID, k.MatchType, k.Text)] = true
+				}
+			}
+			return have, nil
+		},
+		create: func(ctx context.Context, adGroupID int64, rows []*keywords.Row) ([]int64, error) {
+			in := make([]types.Keyword, len(rows))
+			for i, r := range rows {
+				in[i] = types.Keyword{Text: r.Text, MatchType: r.MatchType}
+				bid := r.Bid
+				if bid == "" {
+					bid = defaultBid
+				}
+				if bid != "" {
+					m, err := moneyFromAmount(ctx, bid)
+					if err != nil {
+						return nil, err
+					}
+					in[i].BidAmount = m
+				}
+			}
+			out, err := apiClient.Keywords().Create(ctx, campaignID, adGroupID, in)
+			ids := make([]int64, len(out))
+			for i, k := range out {
+				ids[i] = k.ID
+			}
+			return ids, err
+		},
+	}
+}
+
+// negativeCSVTarget creates negatives in the campaign, or in ad groups when
+// perAdGroup is set.
+func negativeCSVTarget(campaignID int64, perAdGroup bool) csvTarget {
+	t := csvTarget{
+		perAdGroup: perAdGroup,
+		fields:     []string{keywords.FieldMatchType},
+	}
+	if perAdGroup {
+		t.fields = append(t.fields, keywords.FieldAdGroup)
+	}
+	t.existing = func(ctx context.Context, adGroupID int64) (map[string]bool, error) {
+		list, err := collectAllSelectorPaginated(ctx, &types.Selector{}, defaultPageSize, func(ctx context.Context, sel *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
+			if perAdGroup {
+				return apiClient.Negatives().AdGroupFind(ctx, campaignID, adGroupID, sel)
+			}
+			return apiClient.Negatives().CampaignFind(ctx, campaignID, sel)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("find negatives: %w", err)
+		}
+		have := map[string]bool{}
+		for _, n := range list {
+			if !n.Deleted {
+				have[keywords.Key(adGroupID, n.MatchType, n.Text)] = true
+			}
+		}
+		return have, nil
+	}
+	t.create = func(ctx context.Context, adGroupID int64, rows []*keywords.Row) ([]int64, error) {
+		in := make([]types.NegativeKeyword, len(rows))
+		for i, r := range rows {
+			in[i] = types.NegativeKeyword{Text: r.Text, MatchType: r.MatchType}
+		}
+		var out []types.NegativeKeyword
+		var err error
+		if perAdGroup {
+			out, err = apiClient.Negatives().AdGroupCreate(ctx, campaignID, adGroupID, in)
+		} else {
+			out, err = apiClient.Negatives().CampaignCreate(ctx, campaignID, in)
+		}
+		ids := make([]int64, len(out))
+		for i, n := range out {
+			ids[i] = n.ID
+		}
+		return ids, err
+	}
+	return t
+}
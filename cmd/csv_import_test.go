@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/keywords"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/mockserver"
+)
+
+func writeCSV(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keywords.csv")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func decodeCSVRows(t *testing.T, out string) []keywords.Row {
+	t.Helper()
+	var rows []keywords.Row
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+	return rows
+}
+
+func TestKeywordsCSVImport(t *testing.T) {
+	writes := useMockAPI(t)
+	outputFormat = "json"
+
+	// 1001 new rows for the Exact ad group take two bulk requests.
+	lines := []string{
+		"Keyword,Match Type,Bid,Ad Group ID",
+		fmt.Sprintf("demo app,EXACT,,%d", mockExactGroup),
+		fmt.Sprintf("csv new,EXACT,1.10,%d", mockExactGroup),
+		fmt.Sprintf("CSV  New,exact,,%d", mockExactGroup),
+		fmt.Sprintf("csv broad,BROAD,,%d", mockDiscovGroup),
+	}
+	for i := range maxBulkKeywords {
+		lines = append(lines, fmt.Sprintf("bulk term %d,,,%d", i, mockExactGroup))
+	}
+	path := writeCSV(t, lines...)
+	args := func(dryRun bool) []string {
+		return []string{"--campaign-id", fmt.Sprint(mockCampaignID), "--adgroup-id", "0",
+			"--match-type", "EXACT", "--bid", "", "--from-csv", path, fmt.Sprintf("--dry-run=%v", dryRun)}
+	}
+	wantStatus := func(rows []keywords.Row, want map[string]string) {
+		t.Helper()
+		for _, r := range rows {
+			if w, ok := want[r.Text]; ok && r.Status != w {
+				t.Errorf("%s (line %d): status %s (%s), want %s", r.Text, r.Line, r.Status, r.Error, w)
+			}
+		}
+	}
+
+	out, errOut := runCommand(t, keywordsCreateCmd, args(true)...)
+	rows := decodeCSVRows(t, out)
+	if len(rows) != len(lines)-1 {
+		t.Fatalf("got %d rows, want %d", len(rows), len(lines)-1)
+	}
+	wantStatus(rows, map[string]string{
+		"demo app": "exists", "csv new": "planned", "csv broad": "planned", "bulk term 0": "planned",
+	})
+	if rows[2].Status != "duplicate" || rows[2].Error != "same keyword as line 3" {
+		t.Errorf("repeated row = %+v, want a duplicate of line 3", rows[2])
+	}
+	if !strings.Contains(errOut, "1004 rows; 0 created, 1002 planned, 1 already exist, 1 duplicates, 0 failed") {
+		t.Errorf("dry-run summary = %q", errOut)
+	}
+	if got := writes.list(); len(got) != 0 {
+		t.Fatalf("dry run wrote %v", got)
+	}
+
+	// A failed request marks its rows as errors; the other ad group's
+	// requests still go through.
+	writes.mock.InjectFault(mockserver.Fault{
+		Status: http.StatusBadRequest,
+		Method: http.MethodPost,
+		Path:   fmt.Sprintf("/adgroups/%d/targetingkeywords/bulk", mockDiscovGroup),
+	})
+	out, errOut, err := runCommandErr(t, keywordsCreateCmd, args(false)...)
+	if err == nil || err.Error() != "1 CSV rows failed" {
+		t.Fatalf("error = %v, want 1 failed row", err)
+	}
+	rows = decodeCSVRows(t, out)
+	wantStatus(rows, map[string]string{
+		"demo app": "exists", "csv new": "created", "csv broad": "error", "bulk term 999": "created",
+	})
+	for _, r := range rows {
+		if r.Status == "created" && r.ID == 0 {
+			t.Errorf("%s: created without an ID", r.Text)
+		}
+		if r.Status == "error" && !strings.Contains(r.Error, "injected 400") {
+			t.Errorf("%s: error %q, want the API error", r.Text, r.Error)
+		}
+	}
+	if !strings.Contains(errOut, "1001 created, 0 planned, 1 already exist, 1 duplicates, 1 failed") {
+		t.Errorf("summary = %q", errOut)
+	}
+	exactBulk := fmt.Sprintf("POST /campaigns/%d/adgroups/%d/targetingkeywords/bulk", mockCampaignID, mockExactGroup)
+	discovBulk := fmt.Sprintf("POST /campaigns/%d/adgroups/%d/targetingkeywords/bulk", mockCampaignID, mockDiscovGroup)
+	if got := writes.list(); len(got) != 3 || got[0] != exactBulk || got[1] != exactBulk || got[2] != discovBulk {
+		t.Fatalf("writes = %v, want two chunks for ad group %d and one for %d", got, mockExactGroup, mockDiscovGroup)
+	}
+
+	// Rerunning creates only what failed.
+	out, _ = runCommand(t, keywordsCreateCmd, args(false)...)
+	wantStatus(decodeCSVRows(t, out), map[string]string{
+		"demo app": "exists", "csv new": "exists", "csv broad": "created", "bulk term 999": "exists",
+	})
+	if got := writes.list()[3:]; len(got) != 1 || got[0] != discovBulk {
+		t.Fatalf("rerun writes = %v, want only ad group %d", got, mockDiscovGroup)
+	}
+}
+
+func TestKeywordsCSVImportInvalidRows(t *testing.T) {
+	writes := useMockAPI(t)
+	outputFormat = "json"
+
+	path := writeCSV(t, "Keyword,Match Type,Bid", "good one,EXACT,1.00", "bad bid,EXACT,-1", ",EXACT,")
+	out, _, err := runCommandErr(t, keywordsCreateCmd, "--campaign-id", fmt.Sprint(mockCampaignID),
+		"--adgroup-id", fmt.Sprint(mockExactGroup), "--match-type", "EXACT", "--bid", "", "--from-csv", path, "--dry-run=false")
+	if err == nil || err.Error() != "2 invalid CSV rows; nothing was created" {
+		t.Fatalf("error = %v", err)
+	}
+	rows := decodeCSVRows(t, out)
+	if len(rows) != 3 || rows[0].Status != "planned" || rows[1].Status != "invalid" || rows[2].Status != "invalid" {
+		t.Errorf("rows = %+v", rows)
+	}
+	if got := writes.list(); len(got) != 0 {
+		t.Fatalf("invalid CSV wrote %v", got)
+	}
+}
+
+func TestCampaignNegativesCSVRejectsAdGroupColumn(t *testing.T) {
+	writes := useMockAPI(t)
+	outputFormat = "json"
+
+	path := writeCSV(t, "Negative Keyword,Match Type,Ad Group ID", fmt.Sprintf("cheap,EXACT,%d", mockExactGroup))
+	_, _, err := runCommandErr(t, negCampaignCreateCmd, "--campaign-id", fmt.Sprint(mockCampaignID),
+		"--match-type", "EXACT", "--from-csv", path, "--dry-run=false")
+	if err == nil || !strings.Contains(err.Error(), `"Ad Group ID" sets an ad group`) {
+		t.Fatalf("error = %v, want the ad group column rejected", err)
+	}
+	if got := writes.list(); len(got) != 0 {
+		t.Fatalf("writes = %v, want none", got)
+	}
+
+	// Without the column the rows are campaign-wide, as intended.
+	path = writeCSV(t, "Negative Keyword,Match Type", "cheap,EXACT")
+	out, _ := runCommand(t, negCampaignCreateCmd, "--campaign-id", fmt.Sprint(mockCampaignID),
+		"--match-type", "EXACT", "--from-csv", path, "--dry-run=false")
+	rows := decodeCSVRows(t, out)
+	if len(rows) != 1 || rows[0].Status != "created" || rows[0].AdGroupID != 0 {
+		t.Errorf("rows = %+v", rows)
+	}
+	want := fmt.Sprintf("POST /campaigns/%d/negativekeywords/bulk", mockCampaignID)
+	if got := writes.list(); len(got) != 1 || got[0] != want {
+		t.Fatalf("writes = %v, want %s", got, want)
+	}
+}
@@ -15,12 +15,13 @@ var geoSearchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Search for geolocations",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		query, _ := cmd.Flags().GetString("query")
 		countryCode, _ := cmd.Flags().GetString("country-code")
 		entity, _ := cmd.Flags().GetString("entity")
 		limit, _ := cmd.Flags().GetInt("limit")
 
-		result, err := apiClient.Geo().Search(query, countryCode, entity, limit)
+		result, err := apiClient.Geo().Search(ctx, query, countryCode, entity, limit)
 		if err != nil {
 			return err
 		}
@@ -32,8 +33,9 @@ var geoGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get geo location by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		geoID, _ := cmd.Flags().GetString("geo-id")
-		result, err := apiClient.Geo().Get(geoID)
+		result, err := apiClient.Geo().Get(ctx, geoID)
 		if err != nil {
 			return err
 		}
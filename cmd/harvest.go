@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/harvest"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/keywords"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var harvestCmd = &cobra.Command{
+	Use:   "harvest",
+	Short: "Promote converting search terms to exact keywords and negatives",
+	Long: `Read the search terms report for a campaign (or one of its ad groups) and
+pick the terms that meet the thresholds. Each picked term is added as an
+EXACT keyword to the target ad group and as an EXACT negative to every
+--negative-adgroup-id, so discovery ad groups stop competing for it. The
+target ad group can't be one of them.
+
+Terms are matched case-insensitively. A keyword is skipped when the target
+ad group already has the term as an EXACT keyword, and a negative is skipped
+when the ad group or its campaign already has it as an EXACT negative.
+
+--preview prints what would be created without changing anything.
+Otherwise the changes are shown and applied after confirmation. Creation
+stops at the first failed request, and the per-term results are printed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
+		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
+		targetCampaignID, _ := cmd.Flags().GetInt64("target-campaign-id")
+		targetAdGroupID, _ := cmd.Flags().GetInt64("target-adgroup-id")
+		negativeAdGroupIDs, _ := cmd.Flags().GetInt64Slice("negative-adgroup-id")
+		minInstalls, _ := cmd.Flags().GetInt64("min-installs")
+		minTaps, _ := cmd.Flags().GetInt64("min-taps")
+		maxCPA, _ := cmd.Flags().GetString("max-cpa")
+		bid, _ := cmd.Flags().GetString("bid")
+		preview, _ := cmd.Flags().GetBool("preview")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		if targetCampaignID == 0 {
+			targetCampaignID = campaignID
+		}
+		if err := checkHarvestTargets(campaignID, targetCampaignID, targetAdGroupID, negativeAdGroupIDs); err != nil {
+			return err
+		}
+		th := harvest.Thresholds{MinInstalls: minInstalls, MinTaps: minTaps}
+		if maxCPA != "" {
+			v, ok := new(big.Rat).SetString(maxCPA)
+			if !ok || v.Sign() <= 0 {
+				return fmt.Errorf("invalid --max-cpa %q (want a positive amount)", maxCPA)
+			}
+			th.MaxCPA = v
+		}
+		var bidAmount *types.Money
+		if bid != "" {
+			m, err := moneyFromAmount(ctx, bid)
+			if err != nil {
+				return err
+			}
+			bidAmount = m
+		}
+
+		timeZone, err := reportTimeZone(cmd)
+		if err != nil {
+			return err
+		}
+		startTime, endTime, err := reportDates(cmd, timeZone)
+		if err != nil {
+			return err
+		}
+		req := &types.ReportingRequest{
+			StartTime:       startTime,
+			EndTime:         endTime,
+			TimeZone:        timeZone,
+			ReturnRowTotals: true,
+			Selector: &types.Selector{
+				OrderBy: []*types.Sorting{{Field: "installs", SortOrder: "DESCENDING"}},
+			},
+		}
+		var scope *int64
+		if adGroupID > 0 {
+			scope = &adGroupID
+		}
+		data, err := fetchReport(ctx, req, pagedReport(func(ctx context.Context, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+			return apiClient.Reports().SearchTerms(ctx, campaignID, scope, req)
+		}))
+		if err != nil {
+			return fmt.Errorf("search terms report: %w", err)
+		}
+		terms := harvest.Candidates(data, th)
+
+		results, err := planHarvest(ctx, terms, campaignID, targetCampaignID, targetAdGroupID, negativeAdGroupIDs)
+		if err != nil {
+			return err
+		}
+		keywords, negatives, present := countHarvest(results)
+		fmt.Fprintf(os.Stderr, "Harvest: %d of %d search terms qualify; %d keywords and %d negatives to create, %d already present.\n",
+			len(terms), len(data.Row), keywords, negatives, present)
+
+		if preview || keywords+negatives == 0 {
+			return printOutput(results)
+		}
+		if !yes {
+			for _, r := range results {
+				if r.Status == "planned" {
+					fmt.Fprintf(os.Stderr, "+ %s %q in ad group %d\n", r.Action, r.SearchTerm, r.AdGroupID)
+				}
+			}
+			ok, err := confirm(fmt.Sprintf("Create %d keywords and %d negatives?", keywords, negatives))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(os.Stderr, "Harvest cancelled.")
+				return nil
+			}
+		}
+
+		runErr := runHarvest(ctx, results, bidAmount)
+		if err := printOutput(results); err != nil {
+			return err
+		}
+		return runErr
+	},
+}
+
+// harvestResult is one keyword or negative harvest creates (or skips) for a
+// search term.
+type harvestResult struct {
+	SearchTerm string `json:"searchTerm"`
+	Action     string `json:"action"` // keyword or negative
+	CampaignID int64  `json:"campaignId"`
+	AdGroupID  int64  `json:"adGroupId"`
+	Installs   int64  `json:"installs"`
+	Taps       int64  `json:"taps"`
+	AvgCPA     string `json:"avgCPA,omitempty"`
+	ID         int64  `json:"id,omitempty"`
+	Status     string `json:"status"` // planned, exists, created, error
+	Error      string `json:"error,omitempty"`
+}
+
+// checkHarvestTargets rejects a target ad group that would also get the
+// negatives, since its new EXACT keywords would be blocked right away.
+func checkHarvestTargets(campaignID, targetCampaignID, targetAdGroupID int64, negativeAdGroupIDs []int64) error {
+	if targetCampaignID == campaignID && slices.Contains(negativeAdGroupIDs, targetAdGroupID) {
+		return fmt.Errorf("--negative-adgroup-id %d is the target ad group; its negatives would block the harvested keywords", targetAdGroupID)
+	}
+	return nil
+}
+
+// planHarvest lists the keywords and negatives to create for terms, marking
+// the ones that already exist.
+func planHarvest(ctx context.Context, terms []harvest.Term, campaignID, targetCampaignID, targetAdGroupID int64, negativeAdGroupIDs []int64) ([]*harvestResult, error) {
+	existing, err := collectAllSelectorPaginated(ctx, &types.Selector{}, defaultPageSize, func(ctx context.Context, sel *types.Selector) ([]types.Keyword, *types.PageDetail, error) {
+		return apiClient.Keywords().Find(ctx, targetCampaignID, targetAdGroupID, sel)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find keywords in ad group %d: %w", targetAdGroupID, err)
+	}
+	haveKeyword := map[string]bool{}
+	for _, k := range existing {
+		if !k.Deleted && strings.EqualFold(k.MatchType, "EXACT") {
+			haveKeyword[keywords.Normalize(k.Text)] = true
+		}
+	}
+
+	haveNegative := map[int64]map[string]bool{}
+	if len(negativeAdGroupIDs) > 0 {
+		campaignNegatives, err := collectAllSelectorPaginated(ctx, &types.Selector{}, defaultPageSize, func(ctx context.Context, sel *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
+			return apiClient.Negatives().CampaignFind(ctx, campaignID, sel)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("find campaign negatives: %w", err)
+		}
+		groupNegatives, err := mapConcurrent(ctx, negativeAdGroupIDs, func(ctx context.Context, gid int64) ([]types.NegativeKeyword, error) {
+			list, err := collectAllSelectorPaginated(ctx, &types.Selector{}, defaultPageSize, func(ctx context.Context, sel *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
+				return apiClient.Negatives().AdGroupFind(ctx, campaignID, gid, sel)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("find negatives in ad group %d: %w", gid, err)
+			}
+			return list, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i, gid := range negativeAdGroupIDs {
+			set := map[string]bool{}
+			for _, list := range [][]types.NegativeKeyword{campaignNegatives, groupNegatives[i]} {
+				for _, n := range list {
+					if !n.Deleted && strings.EqualFold(n.MatchType, "EXACT") {
+						set[keywords.Normalize(n.Text)] = true
+					}
+				}
+			}
+			haveNegative[gid] = set
+		}
+	}
+
+	var results []*harvestResult
+	for _, t := range terms {
+		key := keywords.Normalize(t.Text)
+		base := harvestResult{SearchTerm: t.Text, Installs: t.Installs, Taps: t.Taps, Status: "planned"}
+		if t.AvgCPA != nil {
+			base.AvgCPA = t.AvgCPA.Amount
+		}
+
+		kw := base
+		kw.Action, kw.CampaignID, kw.AdGroupID = "keyword", targetCampaignID, targetAdGroupID
+		if haveKeyword[key] {
+			kw.Status = "exists"
+		}
+		results = append(results, &kw)
+
+		for _, gid := range negativeAdGroupIDs {
+			neg := base
+			neg.Action, neg.CampaignID, neg.AdGroupID = "negative", campaignID, gid
+			if haveNegative[gid][key] {
+				neg.Status = "exists"
+			}
+			results = append(results, &neg)
+		}
+	}
+	return results, nil
+}
+
+func countHarvest(results []*harvestResult) (keywords, negatives, present int) {
+	for _, r := range results {
+		switch {
+		case r.Status == "exists":
+			present++
+		case r.Action == "keyword":
+			keywords++
+		default:
+			negatives++
+		}
+	}
+	return keywords, negatives, present
+}
+
+// runHarvest creates the planned keywords, then the planned negatives per ad
+// group, in bulk requests; negatives go to --concurrency ad groups at a time.
+// It stops at the first failed request.
+func runHarvest(ctx context.Context, results []*harvestResult, bid *types.Money) error {
+	var keywords []*harvestResult
+	negatives := map[int64][]*harvestResult{}
+	var negativeGroups []int64
+	for _, r := range results {
+		if r.Status != "planned" {
+			continue
+		}
+		if r.Action == "keyword" {
+			keywords = append(keywords, r)
+			continue
+		}
+		if _, ok := negatives[r.AdGroupID]; !ok {
+			negativeGroups = append(negativeGroups, r.AdGroupID)
+		}
+		negatives[r.AdGroupID] = append(negatives[r.AdGroupID], r)
+	}
+
+	for _, part := range chunk(keywords, maxBulkKeywords) {
+		in := make([]types.Keyword, len(part))
+		for i, r := range part {
+			in[i] = types.Keyword{Text: r.SearchTerm, MatchType: "EXACT", BidAmount: bid}
+		}
+		out, err := apiClient.Keywords().Create(ctx, part[0].CampaignID, part[0].AdGroupID, in)
+		if err := recordHarvest(part, len(out), func(i int) int64 { return out[i].ID }, err); err != nil {
+			return fmt.Errorf("create keywords in ad group %d: %w", part[0].AdGroupID, err)
+		}
+	}
+	return forEachConcurrent(ctx, negativeGroups, func(ctx context.Context, gid int64) error {
+		for _, part := range chunk(negatives[gid], maxBulkKeywords) {
+			in := make([]types.NegativeKeyword, len(part))
+			for i, r := range part {
+				in[i] = types.NegativeKeyword{Text: r.SearchTerm, MatchType: "EXACT"}
+			}
+			out, err := apiClient.Negatives().AdGroupCreate(ctx, part[0].CampaignID, gid, in)
+			if err := recordHarvest(part, len(out), func(i int) int64 { return out[i].ID }, err); err != nil {
+				return fmt.Errorf("create negatives in ad group %d: %w", gid, err)
+			}
+		}
+		return nil
+	})
+}
+
+// recordHarvest sets the outcome of one bulk request on its results. The API
+// returns created entities in request order.
+func recordHarvest(part []*harvestResult, n int, id func(int) int64, err error) error {
+	for i, r := range part {
+		if err != nil {
+			r.Status, r.Error = "error", err.Error()
+			continue
+		}
+		r.Status = "created"
+		if i < n {
+			r.ID = id(i)
+		}
+	}
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(harvestCmd)
+
+	f := harvestCmd.Flags()
+	f.Int64("campaign-id", 0, "Campaign whose search terms are read; negatives go to its ad groups")
+	harvestCmd.MarkFlagRequired("campaign-id")
+	f.Int64("adgroup-id", 0, "Only read search terms from this ad group")
+	f.Int64("target-campaign-id", 0, "Campaign of the target ad group (default --campaign-id)")
+	f.Int64("target-adgroup-id", 0, "Ad group that receives the EXACT keywords")
+	harvestCmd.MarkFlagRequired("target-adgroup-id")
+	f.Int64Slice("negative-adgroup-id", nil, "Ad groups in --campaign-id that receive EXACT negatives (repeatable or comma-separated)")
+	f.Int64("min-installs", 1, "Minimum installs for a term to qualify")
+	f.Int64("min-taps", 0, "Minimum taps for a term to qualify")
+	f.String("max-cpa", "", "Maximum average CPA for a term to qualify (e.g. 2.50)")
+	f.String("bid", "", "Bid for new keywords (default: the ad group's default bid)")
+	f.String("start-time", "", "Start time (YYYY-MM-DD)")
+	f.String("end-time", "", "End time (YYYY-MM-DD)")
+	f.String("range", "", "Relative date range instead of --start-time/--end-time (e.g. last-7d, last-30d)")
+	f.String("time-zone", "", "Report time zone: UTC or ORTZ")
+	f.Bool("preview", false, "Show what would be created without changing anything")
+	f.Bool("yes", false, "Create without asking for confirmation")
+}
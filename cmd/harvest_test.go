@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/harvest"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// Seeded mock IDs: campaign 1002 with the "Exact" ad group 1003 (EXACT
+// keywords "demo app", "demo", "best demo app"), the "Discovery" ad group
+// 1008 and the campaign EXACT negative "free".
+const (
+	mockCampaignID  = 1002
+	mockExactGroup  = 1003
+	mockDiscovGroup = 1008
+)
+
+func TestHarvestSkipsExistingAndBatchesNegatives(t *testing.T) {
+	writes := useMockAPI(t)
+	ctx := context.Background()
+	g, err := apiClient.AdGroups().Create(ctx, mockCampaignID, &types.AdGroupCreate{Name: "Broad", DefaultBidAmount: &types.Money{Amount: "1.00", Currency: "USD"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	broad := g.ID
+	setup := len(writes.list())
+
+	terms := []harvest.Term{{Text: "Demo App", Installs: 5}, {Text: "free"}, {Text: "photo editor", Installs: 3}}
+	results, err := planHarvest(ctx, terms, mockCampaignID, mockCampaignID, mockExactGroup, []int64{mockDiscovGroup, broad})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := writes.list()[setup:]; len(w) != 0 {
+		t.Fatalf("planning wrote %v", w)
+	}
+	want := []struct {
+		term, action string
+		adGroupID    int64
+		status       string
+	}{
+		{"Demo App", "keyword", mockExactGroup, "exists"},
+		{"Demo App", "negative", mockDiscovGroup, "planned"},
+		{"Demo App", "negative", broad, "planned"},
+		{"free", "keyword", mockExactGroup, "planned"},
+		{"free", "negative", mockDiscovGroup, "exists"}, // campaign negative
+		{"free", "negative", broad, "exists"},
+		{"photo editor", "keyword", mockExactGroup, "planned"},
+		{"photo editor", "negative", mockDiscovGroup, "planned"},
+		{"photo editor", "negative", broad, "planned"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.SearchTerm != w.term || r.Action != w.action || r.AdGroupID != w.adGroupID || r.Status != w.status {
+			t.Errorf("result %d = %+v, want %+v", i, *r, w)
+		}
+	}
+
+	if err := runHarvest(ctx, results, nil); err != nil {
+		t.Fatal(err)
+	}
+	wantWrites := []string{
+		"POST /campaigns/1002/adgroups/1003/targetingkeywords/bulk",
+		"POST /campaigns/1002/adgroups/1008/negativekeywords/bulk",
+		fmt.Sprintf("POST /campaigns/1002/adgroups/%d/negativekeywords/bulk", broad),
+	}
+	// Keywords go first; negatives go to several ad groups at once.
+	got := writes.list()[setup:]
+	if len(got) > 1 {
+		slices.Sort(got[1:])
+	}
+	if strings.Join(got, "\n") != strings.Join(wantWrites, "\n") {
+		t.Errorf("writes = %v, want %v", got, wantWrites)
+	}
+	for i, r := range results {
+		switch {
+		case want[i].status == "exists" && r.Status != "exists":
+			t.Errorf("result %d changed to %q", i, r.Status)
+		case want[i].status == "planned" && (r.Status != "created" || r.ID == 0):
+			t.Errorf("result %d = %+v, want created with an ID", i, *r)
+		}
+	}
+}
+
+func TestHarvestRejectsTargetAsNegativeGroup(t *testing.T) {
+	tests := []struct {
+		name             string
+		targetCampaignID int64
+		negatives        []int64
+		wantErr          bool
+	}{
+		{"target among negatives", mockCampaignID, []int64{mockDiscovGroup, mockExactGroup}, true},
+		{"other negative groups", mockCampaignID, []int64{mockDiscovGroup}, false},
+		{"same ID in another campaign", 2002, []int64{mockExactGroup}, false},
+	}
+	for _, tt := range tests {
+		err := checkHarvestTargets(mockCampaignID, tt.targetCampaignID, mockExactGroup, tt.negatives)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestHarvestPreviewMakesNoWrites(t *testing.T) {
+	writes := useMockAPI(t)
+	out, _ := runCommand(t, harvestCmd,
+		"--campaign-id", "1002", "--target-adgroup-id", "1003", "--negative-adgroup-id", "1008",
+		"--start-time", "2026-01-01", "--end-time", "2026-01-31", "--min-installs", "0", "--preview")
+	if w := writes.list(); len(w) != 0 {
+		t.Fatalf("preview wrote %v", w)
+	}
+
+	var results []harvestResult
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	status := map[string]string{}
+	for _, r := range results {
+		status[r.Action+" "+r.SearchTerm] = r.Status
+	}
+	for key, want := range map[string]string{
+		"keyword demo app":      "exists",
+		"keyword task manager":  "planned",
+		"negative demo app":     "planned",
+		"negative task manager": "planned",
+	} {
+		if status[key] != want {
+			t.Errorf("%s: status %q, want %q (results %+v)", key, status[key], want, results)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -102,7 +104,7 @@ func normalizeCurrencyCode(v string) string {
 
 // resolveMoneyCurrency resolves the currency to use for Money fields when the CLI builds requests from flags.
 // Priority: --currency > config default_currency / env vars > inferred from GET /acls (matched by org id).
-func resolveMoneyCurrency() (string, error) {
+func resolveMoneyCurrency(ctx context.Context) (string, error) {
 	if currencyResolved {
 		return resolvedCurrency, currencyResolveErr
 	}
@@ -121,7 +123,7 @@ func resolveMoneyCurrency() (string, error) {
 		return "", currencyResolveErr
 	}
 
-	acls, err := apiClient.ACLs().List()
+	acls, err := apiClient.ACLs().List(ctx)
 	if err != nil {
 		currencyResolveErr = fmt.Errorf("infer currency from ACLs: %w", err)
 		return "", currencyResolveErr
@@ -157,10 +159,42 @@ func resolveMoneyCurrency() (string, error) {
 	return "", currencyResolveErr
 }
 
-func moneyFromAmount(amount string) (*types.Money, error) {
-	cur, err := resolveMoneyCurrency()
+func moneyFromAmount(ctx context.Context, amount string) (*types.Money, error) {
+	cur, err := resolveMoneyCurrency(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return &types.Money{Amount: amount, Currency: cur}, nil
 }
+
+// chunk splits items into consecutive slices of at most size elements.
+func chunk[T any](items []T, size int) [][]T {
+	if size <= 0 {
+		size = len(items)
+	}
+	var out [][]T
+	for len(items) > size {
+		out = append(out, items[:size])
+		items = items[size:]
+	}
+	if len(items) > 0 {
+		out = append(out, items)
+	}
+	return out
+}
+
+// confirm asks a yes/no question on stderr. It refuses when stdin isn't a
+// terminal so scripted runs must opt in explicitly (e.g. with --yes).
+func confirm(question string) (bool, error) {
+	if fi, err := os.Stdin.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return false, fmt.Errorf("stdin is not a terminal; pass --yes to confirm")
+	}
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -1,6 +1,14 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/api"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/report"
 	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +22,7 @@ var isCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create an impression share report",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		fromJSON, _ := cmd.Flags().GetString("from-json")
 
 		var req types.CustomReportRequest
@@ -25,18 +34,46 @@ var isCreateCmd = &cobra.Command{
 			startTime, _ := cmd.Flags().GetString("start-time")
 			endTime, _ := cmd.Flags().GetString("end-time")
 			granularity, _ := cmd.Flags().GetString("granularity")
+			name, _ := cmd.Flags().GetString("name")
+			groupBy, _ := cmd.Flags().GetStringSlice("group-by")
+			filters, _ := cmd.Flags().GetStringArray("filter")
 
 			req = types.CustomReportRequest{
+				Name:        name,
 				StartTime:   startTime,
 				EndTime:     endTime,
-				Granularity: granularity,
+				Granularity: strings.ToUpper(granularity),
+			}
+			for _, dim := range groupBy {
+				canonical, ok := lookupFold(impressionShareGroupBy, strings.TrimSpace(dim))
+				if !ok {
+					return fmt.Errorf("invalid --group-by %q (want one of: %s)", dim, strings.Join(impressionShareGroupBy, ", "))
+				}
+				req.GroupBy = append(req.GroupBy, canonical)
+			}
+			for _, f := range filters {
+				cond, err := parseSovFilter(f)
+				if err != nil {
+					return err
+				}
+				if req.Selector == nil {
+					req.Selector = &types.SovSelector{}
+				}
+				req.Selector.Conditions = append(req.Selector.Conditions, cond)
+			}
+			// --from-json input is sent as-is and left for Apple to validate.
+			if err := validateCustomReportRequest(&req); err != nil {
+				return err
 			}
 		}
 
-		result, err := apiClient.ImpressionShare().Create(&req)
+		result, err := apiClient.ImpressionShare().Create(ctx, &req)
 		if err != nil {
 			return err
 		}
+		if wait, _ := cmd.Flags().GetBool("wait"); wait {
+			return printWaitedReport(cmd, result)
+		}
 		return printOutput(result)
 	},
 }
@@ -45,19 +82,203 @@ var isGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get an impression share report by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		id, _ := cmd.Flags().GetInt64("id")
-		result, err := apiClient.ImpressionShare().Get(id)
+		result, err := apiClient.ImpressionShare().Get(ctx, id)
 		if err != nil {
 			return err
 		}
+		if wait, _ := cmd.Flags().GetBool("wait"); wait {
+			return printWaitedReport(cmd, result)
+		}
 		return printOutput(result)
 	},
 }
 
+var isDownloadCmd = &cobra.Command{
+	Use:   "download",
+	Short: "Download a completed impression share report as typed rows",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		id, _ := cmd.Flags().GetInt64("id")
+		wait, _ := cmd.Flags().GetBool("wait")
+
+		rep, err := apiClient.ImpressionShare().Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if wait {
+			if rep, err = waitForCustomReport(cmd, rep); err != nil {
+				return err
+			}
+		}
+		switch rep.State {
+		case "COMPLETED":
+		case "FAILED":
+			return fmt.Errorf("report %d failed", rep.ID)
+		default:
+			return fmt.Errorf("report %d is %s; retry later or pass --wait", rep.ID, rep.State)
+		}
+
+		rows, err := apiClient.ImpressionShare().Download(ctx, rep.DownloadURI)
+		if err != nil {
+			return fmt.Errorf("download report %d: %w", rep.ID, err)
+		}
+		return printOutput(rows)
+	},
+}
+
+// impressionShareGroupBy lists the dimensions an impression share report can
+// be grouped by.
+var impressionShareGroupBy = []string{"countryOrRegion"}
+
+// parseSovFilter parses a --filter value for impression share reports. Only
+// adamId and countryOrRegion with EQUALS or IN are accepted.
+func parseSovFilter(v string) (*types.SovCondition, error) {
+	cond, err := parseFilterFlag(v)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := lookupFold([]string{"adamId", "countryOrRegion"}, cond.Field)
+	if !ok {
+		return nil, fmt.Errorf("invalid --filter %q: field must be adamId or countryOrRegion", v)
+	}
+	if cond.Operator != "EQUALS" && cond.Operator != "IN" {
+		return nil, fmt.Errorf("invalid --filter %q: operator must be EQUALS or IN", v)
+	}
+	if cond.Operator == "EQUALS" {
+		// Accept a single value or a list, like IN.
+		cond.Values = strings.Split(cond.Values[0], ",")
+	}
+	values := make([]string, 0, len(cond.Values))
+	for _, val := range cond.Values {
+		val = strings.TrimSpace(val)
+		if val == "" {
+			return nil, fmt.Errorf("invalid --filter %q: empty value", v)
+		}
+		if field == "adamId" {
+			if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid --filter %q: adamId %q is not a number", v, val)
+			}
+		} else {
+			val = strings.ToUpper(val)
+		}
+		values = append(values, val)
+	}
+	op := cond.Operator
+	if len(values) > 1 {
+		op = "IN"
+	}
+	return &types.SovCondition{Field: field, Operator: op, Values: values}, nil
+}
+
+// validateCustomReportRequest checks what Apple would reject anyway, so a bad
+// request built from flags fails before anything is submitted.
+func validateCustomReportRequest(req *types.CustomReportRequest) error {
+	if req.StartTime == "" || req.EndTime == "" {
+		return fmt.Errorf("start and end time are required (--start-time and --end-time)")
+	}
+	start, err := time.Parse(report.DateLayout, req.StartTime)
+	if err != nil {
+		return fmt.Errorf("invalid start time %q (want YYYY-MM-DD)", req.StartTime)
+	}
+	end, err := time.Parse(report.DateLayout, req.EndTime)
+	if err != nil {
+		return fmt.Errorf("invalid end time %q (want YYYY-MM-DD)", req.EndTime)
+	}
+	if end.Before(start) {
+		return fmt.Errorf("end time %s is before start time %s", req.EndTime, req.StartTime)
+	}
+
+	granularity := req.Granularity
+	if granularity == "" {
+		granularity = "DAILY"
+	}
+	limit := report.ImpressionShareMaxDays(granularity)
+	if limit == 0 {
+		return fmt.Errorf("invalid granularity %q (impression share reports support DAILY or WEEKLY)", req.Granularity)
+	}
+	if days := (report.Window{Start: start, End: end}).Days(); days > limit {
+		return fmt.Errorf("%s impression share reports cover at most %d days; %s..%s is %d days", strings.ToUpper(granularity), limit, req.StartTime, req.EndTime, days)
+	}
+	return nil
+}
+
+// printWaitedReport waits for rep to finish and prints it. A FAILED report is
+// printed and reported as an error.
+func printWaitedReport(cmd *cobra.Command, rep *types.CustomReportResponse) error {
+	rep, err := waitForCustomReport(cmd, rep)
+	if err != nil {
+		return err
+	}
+	if err := printOutput(rep); err != nil {
+		return err
+	}
+	if rep.State == "FAILED" {
+		return fmt.Errorf("report %d failed", rep.ID)
+	}
+	return nil
+}
+
+// waitForCustomReport polls until rep is COMPLETED or FAILED. The interval
+// starts at --poll-interval and grows by half each poll up to
+// maxCustomReportPoll. Progress goes to stderr: a single updating line on a
+// terminal, otherwise one line per state change.
+func waitForCustomReport(cmd *cobra.Command, rep *types.CustomReportResponse) (*types.CustomReportResponse, error) {
+	ctx := cmd.Context()
+	interval, _ := cmd.Flags().GetDuration("poll-interval")
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	tty := isTerminal(os.Stderr)
+	start := time.Now()
+	lastState := ""
+	for {
+		if rep.State == "COMPLETED" || rep.State == "FAILED" {
+			if tty && lastState != "" {
+				fmt.Fprintln(os.Stderr)
+			}
+			return rep, nil
+		}
+
+		elapsed := time.Since(start).Round(time.Second)
+		switch {
+		case tty:
+			fmt.Fprintf(os.Stderr, "\rReport %d: %-9s %s elapsed", rep.ID, rep.State, elapsed)
+		case rep.State != lastState:
+			fmt.Fprintf(os.Stderr, "Report %d: %s\n", rep.ID, rep.State)
+		}
+		lastState = rep.State
+
+		if err := api.SleepContext(ctx, interval); err != nil {
+			if tty {
+				fmt.Fprintln(os.Stderr)
+			}
+			return nil, fmt.Errorf("waiting for report %d: %w", rep.ID, err)
+		}
+		interval = min(interval*3/2, maxCustomReportPoll)
+
+		next, err := apiClient.ImpressionShare().Get(ctx, rep.ID)
+		if err != nil {
+			return nil, err
+		}
+		rep = next
+	}
+}
+
+const maxCustomReportPoll = 30 * time.Second
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
 var isListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List impression share reports",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		limit, _ := cmd.Flags().GetInt("limit")
 		offset, _ := cmd.Flags().GetInt("offset")
 		all, _ := cmd.Flags().GetBool("all")
@@ -66,14 +287,10 @@ var isListCmd = &cobra.Command{
 			if limit == 0 && !cmd.Flags().Changed("limit") {
 				limit = defaultPageSize
 			}
-			result, err := collectAllOffsetPaginated(limit, offset, apiClient.ImpressionShare().List)
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
+			return printAllOffsetPaginated(ctx, limit, offset, apiClient.ImpressionShare().List)
 		}
 
-		result, _, err := apiClient.ImpressionShare().List(limit, offset)
+		result, _, err := apiClient.ImpressionShare().List(ctx, limit, offset)
 		if err != nil {
 			return err
 		}
@@ -85,20 +302,36 @@ func init() {
 	rootCmd.AddCommand(impressionShareCmd)
 
 	// create
-	isCreateCmd.Flags().String("from-json", "", "JSON input (inline, @file, or @- for stdin)")
+	isCreateCmd.Flags().String("from-json", "", "JSON input (inline, @file, or @- for stdin), sent without local validation")
 	isCreateCmd.Flags().String("start-time", "", "Start time (YYYY-MM-DD)")
 	isCreateCmd.Flags().String("end-time", "", "End time (YYYY-MM-DD)")
-	isCreateCmd.Flags().String("granularity", "DAILY", "DAILY, WEEKLY, or MONTHLY")
+	isCreateCmd.Flags().String("granularity", "DAILY", "DAILY (up to 30 days) or WEEKLY (up to 84 days)")
+	isCreateCmd.Flags().String("name", "", "Report name")
+	isCreateCmd.Flags().StringSlice("group-by", nil, "Group by dimensions: "+strings.Join(impressionShareGroupBy, ", "))
+	isCreateCmd.Flags().StringArray("filter", nil, "Filter as field=OPERATOR:value with adamId or countryOrRegion and EQUALS or IN, repeatable (e.g. countryOrRegion=IN:US,GB)")
+	addWaitFlags(isCreateCmd)
 	impressionShareCmd.AddCommand(isCreateCmd)
 
 	// get
 	isGetCmd.Flags().Int64("id", 0, "Report ID")
 	isGetCmd.MarkFlagRequired("id")
+	addWaitFlags(isGetCmd)
 	impressionShareCmd.AddCommand(isGetCmd)
 
+	// download
+	isDownloadCmd.Flags().Int64("id", 0, "Report ID")
+	isDownloadCmd.MarkFlagRequired("id")
+	addWaitFlags(isDownloadCmd)
+	impressionShareCmd.AddCommand(isDownloadCmd)
+
 	// list
 	isListCmd.Flags().Int("limit", 0, "Max results")
 	isListCmd.Flags().Int("offset", 0, "Start offset")
 	isListCmd.Flags().Bool("all", false, "Fetch all pages")
 	impressionShareCmd.AddCommand(isListCmd)
 }
+
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("wait", false, "Poll until the report is COMPLETED or FAILED")
+	cmd.Flags().Duration("poll-interval", 2*time.Second, "Initial delay between polls with --wait (backs off to 30s)")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/api"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func TestParseSovFilter(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *types.SovCondition
+		wantErr string
+	}{
+		{
+			in:   "countryOrRegion=IN:us, gb",
+			want: &types.SovCondition{Field: "countryOrRegion", Operator: "IN", Values: []string{"US", "GB"}},
+		},
+		{
+			in:   "COUNTRYORREGION=equals:us",
+			want: &types.SovCondition{Field: "countryOrRegion", Operator: "EQUALS", Values: []string{"US"}},
+		},
+		{
+			// EQUALS with a list is sent as IN.
+			in:   "adamId=EQUALS:123,456",
+			want: &types.SovCondition{Field: "adamId", Operator: "IN", Values: []string{"123", "456"}},
+		},
+		{
+			in:   "adamId=IN:123",
+			want: &types.SovCondition{Field: "adamId", Operator: "IN", Values: []string{"123"}},
+		},
+		{in: "countryOrRegion=US", wantErr: "want field=OPERATOR:value"},
+		{in: "localSpend=EQUALS:1", wantErr: "field must be adamId or countryOrRegion"},
+		{in: "countryOrRegion=NOT_IN:US", wantErr: "operator must be EQUALS or IN"},
+		{in: "adamId=EQUALS:abc", wantErr: `adamId "abc" is not a number`},
+		{in: "countryOrRegion=IN:US,", wantErr: "empty value"},
+		{in: "countryOrRegion=IN:US,,GB", wantErr: "empty value"},
+		{in: "countryOrRegion=EQUALS:", wantErr: "empty value"},
+		{in: "adamId=IN: ", wantErr: "empty value"},
+	}
+	for _, tt := range tests {
+		got, err := parseSovFilter(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseSovFilter(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSovFilter(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseSovFilter(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidateCustomReportRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		start, end  string
+		granularity string
+		wantErr     string
+	}{
+		{name: "daily default", start: "2025-01-01", end: "2025-01-30"},
+		{name: "single day", start: "2025-01-01", end: "2025-01-01", granularity: "DAILY"},
+		{name: "weekly limit", start: "2025-01-01", end: "2025-03-25", granularity: "WEEKLY"},
+		{name: "daily too long", start: "2025-01-01", end: "2025-01-31", wantErr: "at most 30 days"},
+		{name: "weekly too long", start: "2025-01-01", end: "2025-03-26", granularity: "WEEKLY", wantErr: "at most 84 days"},
+		{name: "monthly", start: "2025-01-01", end: "2025-01-02", granularity: "MONTHLY", wantErr: "invalid granularity"},
+		{name: "missing end", start: "2025-01-01", wantErr: "start and end time are required"},
+		{name: "bad start", start: "01/01/2025", end: "2025-01-02", wantErr: "invalid start time"},
+		{name: "bad end", start: "2025-01-01", end: "2025-02-30", wantErr: "invalid end time"},
+		{name: "end before start", start: "2025-01-02", end: "2025-01-01", wantErr: "before start time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomReportRequest(&types.CustomReportRequest{
+				StartTime: tt.start, EndTime: tt.end, Granularity: tt.granularity,
+			})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImpressionShareCreateValidatesOnlyFlags(t *testing.T) {
+	writes := useMockAPI(t)
+
+	run := func(args ...string) error {
+		t.Helper()
+		_, _, err := runCommandErr(t, isCreateCmd, args...)
+		return err
+	}
+
+	// 31 DAILY days is rejected locally when built from flags...
+	err := run("--from-json", "", "--start-time", "2025-01-01", "--end-time", "2025-01-31", "--granularity", "DAILY")
+	if err == nil || !strings.Contains(err.Error(), "at most 30 days") {
+		t.Fatalf("flag-built request error = %v", err)
+	}
+	if got := writes.list(); len(got) != 0 {
+		t.Fatalf("flag-built request was submitted: %v", got)
+	}
+
+	// ...but --from-json input goes to the API unchanged.
+	err = run("--from-json", `{"startTime":"2025-01-01","endTime":"2025-01-31","granularity":"DAILY"}`)
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("--from-json request error = %v, want the API's error", err)
+	}
+	if got := writes.list(); len(got) != 1 || got[0] != "POST /custom-reports" {
+		t.Fatalf("writes = %v, want the --from-json request submitted", got)
+	}
+}
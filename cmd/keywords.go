@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/SaadBelfqih/apple-ads-cli/internal/bidrules"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/report"
 	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
 	"github.com/spf13/cobra"
 )
@@ -18,13 +23,24 @@ var keywordsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create targeting keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		fromJSON, _ := cmd.Flags().GetString("from-json")
 		text, _ := cmd.Flags().GetString("text")
 		matchType, _ := cmd.Flags().GetString("match-type")
 		bid, _ := cmd.Flags().GetString("bid")
+		fromCSV, _ := cmd.Flags().GetString("from-csv")
 
+		if fromCSV != "" {
+			return runCSVImport(cmd, adGroupID, keywordCSVTarget(cmd, campaignID))
+		}
+		if err := checkCSVOnlyFlags(cmd); err != nil {
+			return err
+		}
+		if adGroupID == 0 {
+			return fmt.Errorf("--adgroup-id is required")
+		}
 		var keywords []types.Keyword
 		if fromJSON != "" {
 			if err := parseJSONInput(fromJSON, &keywords); err != nil {
@@ -33,7 +49,7 @@ var keywordsCreateCmd = &cobra.Command{
 		} else {
 			kw := types.Keyword{Text: text, MatchType: matchType}
 			if bid != "" {
-				m, err := moneyFromAmount(bid)
+				m, err := moneyFromAmount(ctx, bid)
 				if err != nil {
 					return err
 				}
@@ -42,7 +58,7 @@ var keywordsCreateCmd = &cobra.Command{
 			keywords = []types.Keyword{kw}
 		}
 
-		result, err := apiClient.Keywords().Create(campaignID, adGroupID, keywords)
+		result, err := apiClient.Keywords().Create(ctx, campaignID, adGroupID, keywords)
 		if err != nil {
 			return err
 		}
@@ -54,10 +70,11 @@ var keywordsGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a targeting keyword",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		id, _ := cmd.Flags().GetInt64("id")
-		result, err := apiClient.Keywords().Get(campaignID, adGroupID, id)
+		result, err := apiClient.Keywords().Get(ctx, campaignID, adGroupID, id)
 		if err != nil {
 			return err
 		}
@@ -69,6 +86,7 @@ var keywordsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List targeting keywords in an ad group",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		limit, _ := cmd.Flags().GetInt("limit")
@@ -79,16 +97,12 @@ var keywordsListCmd = &cobra.Command{
 			if limit == 0 && !cmd.Flags().Changed("limit") {
 				limit = defaultPageSize
 			}
-			result, err := collectAllOffsetPaginated(limit, offset, func(lim, off int) ([]types.Keyword, *types.PageDetail, error) {
-				return apiClient.Keywords().List(campaignID, adGroupID, lim, off)
+			return printAllOffsetPaginated(ctx, limit, offset, func(ctx context.Context, lim, off int) ([]types.Keyword, *types.PageDetail, error) {
+				return apiClient.Keywords().List(ctx, campaignID, adGroupID, lim, off)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.Keywords().List(campaignID, adGroupID, limit, offset)
+		result, _, err := apiClient.Keywords().List(ctx, campaignID, adGroupID, limit, offset)
 		if err != nil {
 			return err
 		}
@@ -100,6 +114,7 @@ var keywordsFindCmd = &cobra.Command{
 	Use:   "find",
 	Short: "Find targeting keywords in an ad group",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
@@ -133,16 +148,12 @@ var keywordsFindCmd = &cobra.Command{
 					sel.Pagination.Offset = offset
 				}
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, func(s *types.Selector) ([]types.Keyword, *types.PageDetail, error) {
-				return apiClient.Keywords().Find(campaignID, adGroupID, s)
+			return printAllSelectorPaginated(ctx, sel, pageSize, func(ctx context.Context, s *types.Selector) ([]types.Keyword, *types.PageDetail, error) {
+				return apiClient.Keywords().Find(ctx, campaignID, adGroupID, s)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.Keywords().Find(campaignID, adGroupID, sel)
+		result, _, err := apiClient.Keywords().Find(ctx, campaignID, adGroupID, sel)
 		if err != nil {
 			return err
 		}
@@ -154,6 +165,7 @@ var keywordsFindCampaignCmd = &cobra.Command{
 	Use:   "find-campaign",
 	Short: "Find targeting keywords across all ad groups in a campaign",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
 		field, _ := cmd.Flags().GetString("field")
@@ -187,16 +199,12 @@ var keywordsFindCampaignCmd = &cobra.Command{
 					sel.Pagination.Offset = offset
 				}
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, func(s *types.Selector) ([]types.Keyword, *types.PageDetail, error) {
-				return apiClient.Keywords().FindCampaign(campaignID, s)
+			return printAllSelectorPaginated(ctx, sel, pageSize, func(ctx context.Context, s *types.Selector) ([]types.Keyword, *types.PageDetail, error) {
+				return apiClient.Keywords().FindCampaign(ctx, campaignID, s)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.Keywords().FindCampaign(campaignID, sel)
+		result, _, err := apiClient.Keywords().FindCampaign(ctx, campaignID, sel)
 		if err != nil {
 			return err
 		}
@@ -208,6 +216,7 @@ var keywordsUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update targeting keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		fromJSON, _ := cmd.Flags().GetString("from-json")
@@ -217,7 +226,7 @@ var keywordsUpdateCmd = &cobra.Command{
 			return err
 		}
 
-		result, err := apiClient.Keywords().Update(campaignID, adGroupID, keywords)
+		result, err := apiClient.Keywords().Update(ctx, campaignID, adGroupID, keywords)
 		if err != nil {
 			return err
 		}
@@ -229,6 +238,7 @@ var keywordsDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Bulk delete targeting keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		idsStr, _ := cmd.Flags().GetString("ids")
@@ -242,7 +252,7 @@ var keywordsDeleteCmd = &cobra.Command{
 			ids = append(ids, id)
 		}
 
-		if err := apiClient.Keywords().Delete(campaignID, adGroupID, ids); err != nil {
+		if err := apiClient.Keywords().Delete(ctx, campaignID, adGroupID, ids); err != nil {
 			return err
 		}
 		fmt.Println("Keywords deleted")
@@ -254,10 +264,11 @@ var keywordsDeleteOneCmd = &cobra.Command{
 	Use:   "delete-one",
 	Short: "Delete a single targeting keyword",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		id, _ := cmd.Flags().GetInt64("id")
-		if err := apiClient.Keywords().DeleteOne(campaignID, adGroupID, id); err != nil {
+		if err := apiClient.Keywords().DeleteOne(ctx, campaignID, adGroupID, id); err != nil {
 			return err
 		}
 		fmt.Println("Keyword " + strconv.FormatInt(id, 10) + " deleted")
@@ -265,18 +276,107 @@ var keywordsDeleteOneCmd = &cobra.Command{
 	},
 }
 
+var keywordsApplySuggestedBidsCmd = &cobra.Command{
+	Use:   "apply-suggested-bids",
+	Short: "Move keyword bids toward Apple's suggested bids",
+	Long: `Read the suggested bid for each keyword from the keyword report's insights
+and update bids that differ from it. --max-increase and --max-decrease cap
+how far a single bid moves; a capped bid moves part of the way.
+
+The bids to change are shown as a diff and applied after confirmation.
+--dry-run only prints them. Paused and deleted keywords, and keywords
+without a suggestion, are left alone. A keyword whose suggestion can't be
+used, such as one that rounds to a zero bid, is listed as skipped.
+Keywords without their own bid are compared using their ad group's default
+bid.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
+		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
+		rangeName, _ := cmd.Flags().GetString("range")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		changeLog, _ := cmd.Flags().GetString("change-log")
+
+		limits, err := bidLimits(cmd)
+		if err != nil {
+			return err
+		}
+		timeZone, err := reportTimeZone(cmd)
+		if err != nil {
+			return err
+		}
+		loc, err := reportLocation(ctx, timeZone)
+		if err != nil {
+			return err
+		}
+		w, err := report.Resolve(rangeName, time.Now().In(loc))
+		if err != nil {
+			return err
+		}
+		rows, err := keywordReportRows(ctx, campaignID, adGroupID, w, timeZone)
+		if err != nil {
+			return fmt.Errorf("keywords report: %w", err)
+		}
+
+		defaultBids := adGroupBids{campaignID: campaignID}
+		var changes []*bidChange
+		var noSuggestion, unchanged, skipped int
+		for _, r := range rows {
+			if !r.active() {
+				continue
+			}
+			var suggested *types.Money
+			if r.insights != nil && r.insights.BidRecommendation != nil {
+				suggested = r.insights.BidRecommendation.SuggestedBidAmount
+			}
+			if suggested == nil || suggested.Amount == "" {
+				noSuggestion++
+				continue
+			}
+			bid := r.BidAmount
+			if bid == nil {
+				if bid, err = defaultBids.get(ctx, r.AdGroupID); err != nil {
+					return err
+				}
+			}
+			next, limit, err := bidrules.Toward(bid, suggested, limits)
+			if err != nil {
+				// Report the keyword and carry on with the others.
+				next = &types.Money{Currency: bid.Currency}
+			} else if sameAmount(next.Amount, bid.Amount) {
+				unchanged++
+				continue
+			}
+			c := newBidChange(campaignID, r.keywordRowMeta, bid, next, "suggested bid", limit)
+			c.SuggestedBid = suggested.Amount
+			c.Period = w.Start.Format(report.DateLayout) + ".." + w.End.Format(report.DateLayout)
+			c.setMetrics(r.total)
+			if err != nil {
+				c.Status, c.Error = "skipped", err.Error()
+				skipped++
+			}
+			changes = append(changes, c)
+		}
+		fmt.Fprintf(os.Stderr, "Suggested bids: %d keywords; %d bids to change, %d already at the suggestion, %d without a suggestion, %d skipped.\n",
+			len(rows), len(changes)-skipped, unchanged, noSuggestion, skipped)
+
+		return finishBidChanges(ctx, changes, dryRun, yes, changeLog)
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(keywordsCmd)
 
 	// create
 	keywordsCreateCmd.Flags().Int64("campaign-id", 0, "Campaign ID")
 	keywordsCreateCmd.MarkFlagRequired("campaign-id")
-	keywordsCreateCmd.Flags().Int64("adgroup-id", 0, "Ad group ID")
-	keywordsCreateCmd.MarkFlagRequired("adgroup-id")
+	keywordsCreateCmd.Flags().Int64("adgroup-id", 0, "Ad group ID (with --from-csv, the default for rows without an adgroup column)")
 	keywordsCreateCmd.Flags().String("text", "", "Keyword text")
 	keywordsCreateCmd.Flags().String("match-type", "BROAD", "BROAD or EXACT")
 	keywordsCreateCmd.Flags().String("bid", "", "Bid amount")
 	keywordsCreateCmd.Flags().String("from-json", "", "JSON input (inline, @file, or @- for stdin)")
+	addCSVImportFlags(keywordsCreateCmd)
 	keywordsCmd.AddCommand(keywordsCreateCmd)
 
 	// get
@@ -333,6 +433,19 @@ func init() {
 	keywordsUpdateCmd.MarkFlagRequired("from-json")
 	keywordsCmd.AddCommand(keywordsUpdateCmd)
 
+	// apply-suggested-bids
+	keywordsApplySuggestedBidsCmd.Flags().Int64("campaign-id", 0, "Campaign ID")
+	keywordsApplySuggestedBidsCmd.MarkFlagRequired("campaign-id")
+	keywordsApplySuggestedBidsCmd.Flags().Int64("adgroup-id", 0, "Ad group ID (default: every ad group in the campaign)")
+	keywordsApplySuggestedBidsCmd.Flags().String("range", "last-7d", "Report range the metrics and suggestions come from")
+	keywordsApplySuggestedBidsCmd.Flags().String("time-zone", "", "Report time zone: UTC or ORTZ")
+	keywordsApplySuggestedBidsCmd.Flags().String("max-increase", "", "Largest increase of any single bid, as a percentage (e.g. 25%)")
+	keywordsApplySuggestedBidsCmd.Flags().String("max-decrease", "", "Largest decrease of any single bid, as a percentage (e.g. 20%)")
+	keywordsApplySuggestedBidsCmd.Flags().Bool("dry-run", false, "Show the bid diff without updating bids")
+	keywordsApplySuggestedBidsCmd.Flags().Bool("yes", false, "Update without asking for confirmation")
+	keywordsApplySuggestedBidsCmd.Flags().String("change-log", "", "Append each change and its outcome as a JSON line to this file")
+	keywordsCmd.AddCommand(keywordsApplySuggestedBidsCmd)
+
 	// delete (bulk)
 	keywordsDeleteCmd.Flags().Int64("campaign-id", 0, "Campaign ID")
 	keywordsDeleteCmd.MarkFlagRequired("campaign-id")
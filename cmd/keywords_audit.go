@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/keywords"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var keywordsAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Find duplicate, blocked, overlapping and unnormalized keywords",
+	Long: `Load the targeting keywords and negatives of a campaign, or of every
+campaign without --campaign-id, and report:
+
+  duplicate  active keywords with the same match type and text as an older
+             one in any ad group (fix: pause the newer keyword)
+  blocked    active keywords excluded by their own campaign or ad group
+             negatives (fix: delete the negative when its text is the same)
+  overlap    BROAD keywords with the same text as an EXACT keyword and no
+             EXACT negative isolating them (fix: add that EXACT negative to
+             the BROAD keyword's ad group)
+  normalize  keyword and negative text with upper case or extra spaces
+             (report only; keyword text can't be edited)
+
+Texts are compared ignoring case and extra spaces. --fix applies the fixes
+of the listed kinds after confirmation; findings without a fix are left alone.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
+		fixFlag, _ := cmd.Flags().GetString("fix")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		fixKinds, err := parseAuditFixKinds(fixFlag)
+		if err != nil {
+			return err
+		}
+
+		st, err := fetchAccountState(ctx, func(c types.Campaign) bool {
+			return campaignID == 0 || c.ID == campaignID
+		})
+		if err != nil {
+			return err
+		}
+		if campaignID != 0 && len(st.Campaigns) == 0 {
+			return fmt.Errorf("campaign %d not found", campaignID)
+		}
+
+		findings := []*keywords.Finding{}
+		var nKeywords, nNegatives int
+		for _, cs := range st.Campaigns {
+			nNegatives += len(cs.Negatives)
+			for _, g := range cs.AdGroups {
+				nKeywords += len(g.Keywords)
+				nNegatives += len(g.Negatives)
+			}
+			for _, f := range keywords.Audit(cs) {
+				findings = append(findings, &f)
+			}
+		}
+		counts := map[string]int{}
+		for _, f := range findings {
+			counts[f.Kind]++
+		}
+		fmt.Fprintf(os.Stderr, "Keyword audit: %d campaigns, %d keywords, %d negatives; %d duplicates, %d blocked, %d overlaps, %d to normalize.\n",
+			len(st.Campaigns), nKeywords, nNegatives, counts[keywords.KindDuplicate], counts[keywords.KindBlocked], counts[keywords.KindOverlap], counts[keywords.KindNormalize])
+
+		fixes := planAuditFixes(findings, fixKinds)
+		if len(fixes) == 0 || dryRun {
+			if len(fixes) > 0 {
+				printAuditFixes(fixes)
+			}
+			return printOutput(findings)
+		}
+		if !yes {
+			printAuditFixes(fixes)
+			ok, err := confirm(fmt.Sprintf("Apply %d fixes?", len(fixes)))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(os.Stderr, "Fixes cancelled.")
+				return nil
+			}
+		}
+
+		failed := applyAuditFixes(ctx, fixes)
+		if err := printOutput(findings); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d fixes failed", failed, len(fixes))
+		}
+		return nil
+	},
+}
+
+// auditFixKinds are the finding kinds --fix accepts.
+var auditFixKinds = []string{keywords.KindDuplicate, keywords.KindBlocked, keywords.KindOverlap}
+
+func parseAuditFixKinds(s string) (map[string]bool, error) {
+	kinds := map[string]bool{}
+	for _, k := range strings.Split(s, ",") {
+		k = strings.ToLower(strings.TrimSpace(k))
+		switch {
+		case k == "":
+		case k == "all":
+			for _, a := range auditFixKinds {
+				kinds[a] = true
+			}
+		case slices.Contains(auditFixKinds, k):
+			kinds[k] = true
+		default:
+			return nil, fmt.Errorf("invalid --fix %q (want all or a list of: %s)", k, strings.Join(auditFixKinds, ", "))
+		}
+	}
+	return kinds, nil
+}
+
+// auditFix is one change to make, shared by every finding it resolves (for
+// example one negative blocking several keywords).
+type auditFix struct {
+	campaignID int64
+	fix        keywords.Fix
+	findings   []*keywords.Finding
+}
+
+// planAuditFixes collects the distinct fixes of the findings whose kind is
+// in kinds, in report order.
+func planAuditFixes(findings []*keywords.Finding, kinds map[string]bool) []*auditFix {
+	var out []*auditFix
+	byKey := map[string]*auditFix{}
+	for _, f := range findings {
+		if f.Fix == nil || !kinds[f.Kind] {
+			continue
+		}
+		key := fmt.Sprintf("%d|%s|%d|%d|%s", f.CampaignID, f.Fix.Action, f.Fix.AdGroupID, f.Fix.ID, keywords.Key(0, f.Fix.MatchType, f.Fix.Text))
+		fx := byKey[key]
+		if fx == nil {
+			fx = &auditFix{campaignID: f.CampaignID, fix: *f.Fix}
+			byKey[key] = fx
+			out = append(out, fx)
+		}
+		fx.findings = append(fx.findings, f)
+	}
+	return out
+}
+
+func printAuditFixes(fixes []*auditFix) {
+	for _, fx := range fixes {
+		f := fx.fix
+		switch f.Action {
+		case keywords.FixPauseKeyword:
+			fmt.Fprintf(os.Stderr, "~ campaign %d: pause keyword %d %q in ad group %d\n", fx.campaignID, f.ID, fx.findings[0].Text, f.AdGroupID)
+		case keywords.FixDeleteNegative:
+			where := "the campaign"
+			if f.AdGroupID != 0 {
+				where = fmt.Sprintf("ad group %d", f.AdGroupID)
+			}
+			fmt.Fprintf(os.Stderr, "- campaign %d: delete negative %d in %s\n", fx.campaignID, f.ID, where)
+		case keywords.FixAddNegative:
+			fmt.Fprintf(os.Stderr, "+ campaign %d: add %s negative %q to ad group %d\n", fx.campaignID, f.MatchType, f.Text, f.AdGroupID)
+		}
+	}
+}
+
+// applyAuditFixes sends the fixes in bulk requests per campaign, action and
+// ad group, and marks their findings fixed or error. A failed request
+// doesn't stop the others. It returns the number of failed fixes.
+func applyAuditFixes(ctx context.Context, fixes []*auditFix) int {
+	type batchKey struct {
+		campaignID int64
+		action     string
+		adGroupID  int64
+	}
+	batches := map[batchKey][]*auditFix{}
+	var order []batchKey
+	for _, fx := range fixes {
+		k := batchKey{fx.campaignID, fx.fix.Action, fx.fix.AdGroupID}
+		if _, ok := batches[k]; !ok {
+			order = append(order, k)
+		}
+		batches[k] = append(batches[k], fx)
+	}
+
+	failed := 0
+	for _, k := range order {
+		for _, part := range chunk(batches[k], maxBulkKeywords) {
+			err := sendAuditFixes(ctx, k.campaignID, k.action, k.adGroupID, part)
+			for _, fx := range part {
+				if err != nil {
+					failed++
+				}
+				for _, f := range fx.findings {
+					if err != nil {
+						f.Status, f.Error = "error", err.Error()
+					} else {
+						f.Status = "fixed"
+					}
+				}
+			}
+		}
+	}
+	return failed
+}
+
+func sendAuditFixes(ctx context.Context, campaignID int64, action string, adGroupID int64, fixes []*auditFix) error {
+	switch action {
+	case keywords.FixPauseKeyword:
+		in := make([]types.Keyword, len(fixes))
+		for i, fx := range fixes {
+			in[i] = types.Keyword{ID: fx.fix.ID, Status: "PAUSED"}
+		}
+		_, err := apiClient.Keywords().Update(ctx, campaignID, adGroupID, in)
+		return err
+	case keywords.FixDeleteNegative:
+		ids := make([]int64, len(fixes))
+		for i, fx := range fixes {
+			ids[i] = fx.fix.ID
+		}
+		if adGroupID == 0 {
+			return apiClient.Negatives().CampaignDelete(ctx, campaignID, ids)
+		}
+		return apiClient.Negatives().AdGroupDelete(ctx, campaignID, adGroupID, ids)
+	case keywords.FixAddNegative:
+		in := make([]types.NegativeKeyword, len(fixes))
+		for i, fx := range fixes {
+			in[i] = types.NegativeKeyword{Text: fx.fix.Text, MatchType: fx.fix.MatchType}
+		}
+		_, err := apiClient.Negatives().AdGroupCreate(ctx, campaignID, adGroupID, in)
+		return err
+	}
+	return fmt.Errorf("unknown fix action %q", action)
+}
+
+func init() {
+	f := keywordsAuditCmd.Flags()
+	f.Int64("campaign-id", 0, "Campaign to audit (default: every campaign)")
+	f.String("fix", "", "Apply fixes for these kinds: all, or a comma-separated list of duplicate, blocked, overlap")
+	f.Bool("dry-run", false, "With --fix, list the fixes without applying them")
+	f.Bool("yes", false, "Apply fixes without asking for confirmation")
+	keywordsCmd.AddCommand(keywordsAuditCmd)
+}
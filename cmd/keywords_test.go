@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func TestApplySuggestedBids(t *testing.T) {
+	writes := useMockAPI(t)
+	ctx := context.Background()
+
+	// A bid in another currency than its suggestion can't be moved toward it.
+	if _, err := apiClient.Keywords().Update(ctx, mockCampaignID, mockExactGroup, []types.Keyword{
+		{ID: 1004, BidAmount: &types.Money{Amount: "0.50", Currency: "EUR"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	setup := len(writes.list())
+
+	run := func(args ...string) ([]bidChange, string) {
+		t.Helper()
+		args = append([]string{"--campaign-id", "1002", "--range", "last-7d", "--time-zone", "UTC"}, args...)
+		out, stderr := runCommand(t, keywordsApplySuggestedBidsCmd, args...)
+		var changes []bidChange
+		if err := json.Unmarshal([]byte(out), &changes); err != nil {
+			t.Fatalf("decode output: %v\n%s", err, out)
+		}
+		return changes, stderr
+	}
+	summary := regexp.MustCompile(`(\d+) keywords; (\d+) bids to change, (\d+) already at the suggestion, (\d+) without a suggestion, (\d+) skipped`)
+	counts := func(stderr string) []int {
+		t.Helper()
+		m := summary.FindStringSubmatch(stderr)
+		if m == nil {
+			t.Fatalf("no summary in %q", stderr)
+		}
+		n := make([]int, 5)
+		for i := range n {
+			n[i], _ = strconv.Atoi(m[i+1])
+		}
+		return n
+	}
+
+	// Uncapped: every bid moves to its suggestion.
+	changes, stderr := run("--max-increase", "", "--max-decrease", "", "--dry-run", "--yes=false")
+	if w := writes.list()[setup:]; len(w) != 0 {
+		t.Fatalf("dry run wrote %v", w)
+	}
+	planned := 0
+	for _, c := range changes {
+		switch {
+		case c.KeywordID == 1004:
+			if c.Status != "skipped" || !strings.Contains(c.Error, "EUR") {
+				t.Errorf("keyword 1004 = %+v, want skipped for its currency", c)
+			}
+		case c.Status != "planned" || c.NewBid != c.SuggestedBid || c.Limit != "":
+			t.Errorf("change %+v, want planned at the suggestion", c)
+		default:
+			planned++
+		}
+	}
+	n := counts(stderr)
+	if n[0] != 5 || n[1] != planned || n[4] != 1 || n[1]+n[2]+n[3]+n[4] != 5 {
+		t.Errorf("summary counts %v with %d planned changes: %q", n, planned, stderr)
+	}
+
+	// Capped at 10% either way.
+	changes, _ = run("--max-increase", "10%", "--max-decrease", "10%", "--dry-run", "--yes=false")
+	limited := 0
+	for _, c := range changes {
+		if c.Status != "planned" {
+			continue
+		}
+		cur, _ := new(big.Rat).SetString(c.CurrentBid)
+		suggested, _ := new(big.Rat).SetString(c.SuggestedBid)
+		want := suggested
+		switch c.Limit {
+		case "maxIncrease":
+			want = new(big.Rat).Mul(cur, big.NewRat(110, 100))
+		case "maxDecrease":
+			want = new(big.Rat).Mul(cur, big.NewRat(90, 100))
+		}
+		if c.Limit != "" {
+			limited++
+		}
+		if c.NewBid != want.FloatString(2) {
+			t.Errorf("change %+v, want new bid %s", c, want.FloatString(2))
+		}
+	}
+	if limited == 0 {
+		t.Error("no bid was held back by the 10% caps")
+	}
+
+	// Applying updates the planned bids, one request per ad group.
+	changes, _ = run("--max-increase", "10%", "--max-decrease", "10%", "--dry-run=false", "--yes")
+	groups := map[int64]bool{}
+	for _, c := range changes {
+		if c.KeywordID == 1004 {
+			if c.Status != "skipped" {
+				t.Errorf("keyword 1004 = %+v, want skipped", c)
+			}
+			continue
+		}
+		if c.Status != "updated" {
+			t.Errorf("change %+v, want updated", c)
+		}
+		groups[c.AdGroupID] = true
+		k, err := apiClient.Keywords().Get(ctx, mockCampaignID, c.AdGroupID, c.KeywordID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k.BidAmount == nil || !sameAmount(k.BidAmount.Amount, c.NewBid) {
+			t.Errorf("keyword %d bid = %+v, want %s", c.KeywordID, k.BidAmount, c.NewBid)
+		}
+	}
+	if w := writes.list()[setup:]; len(w) != len(groups) {
+		t.Errorf("writes = %v, want one per ad group %v", w, groups)
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/mockserver"
+	"github.com/spf13/cobra"
+)
+
+var mockServerCmd = &cobra.Command{
+	Use:   "mock-server",
+	Short: "Run a local in-memory Apple Ads API for development",
+	Long: `Serve an in-memory emulation of the Apple Ads v5 endpoints this CLI uses
+(campaigns, ad groups, keywords, negatives, ads, creatives, budget orders,
+reports, custom reports and ACLs), so automation can be exercised end to end
+without an Apple account. State lives in memory and is lost on exit.
+
+--org-id and --currency set the mock org. On startup it prints the environment variables that point
+the CLI at it, including a throwaway signing key. Faults can be injected at random
+with --fault-rate, or queued at runtime:
+
+  curl -X POST localhost:8080/__mock/faults -d '{"status":429,"count":3,"path":"/reports"}'
+  curl -X POST localhost:8080/__mock/reset`,
+	Example: `  aads mock-server --seed
+  aads mock-server --addr 127.0.0.1:9090 --fault-rate 0.1 --retry-after 2s`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		addr, _ := cmd.Flags().GetString("addr")
+		timeZone, _ := cmd.Flags().GetString("time-zone")
+		seed, _ := cmd.Flags().GetBool("seed")
+		faultRate, _ := cmd.Flags().GetFloat64("fault-rate")
+		faultStatuses, _ := cmd.Flags().GetIntSlice("fault-status")
+		retryAfter, _ := cmd.Flags().GetDuration("retry-after")
+
+		if faultRate < 0 || faultRate > 1 {
+			return fmt.Errorf("--fault-rate must be between 0 and 1")
+		}
+		for _, s := range faultStatuses {
+			if s != http.StatusTooManyRequests && (s < 500 || s > 599) {
+				return fmt.Errorf("--fault-status %d: want 429 or 5xx", s)
+			}
+		}
+		var orgID int64
+		if orgIDFlag != "" {
+			id, err := strconv.ParseInt(orgIDFlag, 10, 64)
+			if err != nil {
+				return fmt.Errorf("--org-id: %w", err)
+			}
+			orgID = id
+		}
+
+		handler := mockserver.New(mockserver.Options{
+			OrgID:         orgID,
+			Currency:      currencyFlag,
+			TimeZone:      timeZone,
+			FaultRate:     faultRate,
+			FaultStatuses: faultStatuses,
+			RetryAfter:    retryAfter,
+			Seed:          seed,
+		})
+
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+		srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+		// The CLI signs a JWT before every token exchange, so hand out a
+		// throwaway key. The mock accepts any signature.
+		keyPath, err := writeMockKey()
+		if err != nil {
+			return err
+		}
+		defer os.Remove(keyPath)
+
+		base := "http://" + ln.Addr().String()
+		fmt.Fprintf(os.Stderr, "Mock Apple Ads API listening on %s%s (org %d)\n", base, mockserver.APIPrefix, handler.OrgID())
+		fmt.Fprintln(os.Stderr, "Point the CLI at it with:")
+		fmt.Fprintf(os.Stderr, "  export AADS_API_BASE_URL=%s%s\n", base, mockserver.APIPrefix)
+		fmt.Fprintf(os.Stderr, "  export AADS_TOKEN_URL=%s%s\n", base, mockserver.TokenPath)
+		fmt.Fprintln(os.Stderr, "  export AADS_CLIENT_ID=SEARCHADS.mock AADS_TEAM_ID=SEARCHADS.mock AADS_KEY_ID=mock")
+		fmt.Fprintf(os.Stderr, "  export AADS_ORG_ID=%d AADS_PRIVATE_KEY_PATH=%s\n", handler.OrgID(), keyPath)
+		fmt.Fprintln(os.Stderr, "Press Ctrl-C to stop.")
+
+		errc := make(chan error, 1)
+		go func() { errc <- srv.Serve(ln) }()
+
+		select {
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	mockServerCmd.Flags().String("addr", "127.0.0.1:8080", "Listen address")
+	mockServerCmd.Flags().String("time-zone", "", "Org time zone reported by /acls (default America/Los_Angeles)")
+	mockServerCmd.Flags().Bool("seed", false, "Preload a demo campaign with ad groups, keywords and a creative")
+	mockServerCmd.Flags().Float64("fault-rate", 0, "Probability (0-1) that a request fails with an injected error")
+	mockServerCmd.Flags().IntSlice("fault-status", nil, "Statuses for injected errors (default 429,500,503)")
+	mockServerCmd.Flags().Duration("retry-after", 0, "Retry-After sent with injected 429s (0 = omit)")
+
+	rootCmd.AddCommand(mockServerCmd)
+}
+
+// writeMockKey writes a fresh P-256 key to a temp file for use with the mock
+// server's token endpoint.
+func writeMockKey() (string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("generate key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("encode key: %w", err)
+	}
+	f, err := os.CreateTemp("", "aads-mock-*.pem")
+	if err != nil {
+		return "", fmt.Errorf("write key: %w", err)
+	}
+	defer f.Close()
+	if err := pem.Encode(f, &pem.Block{Type: "PRIVATE KEY", Bytes: der}); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("write key: %w", err)
+	}
+	return f.Name(), nil
+}
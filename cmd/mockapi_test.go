@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/api"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/config"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/mockserver"
+	"github.com/spf13/cobra"
+)
+
+// writeLog records the requests that change mock server state. mock is the
+// server itself, for injecting faults.
+type writeLog struct {
+	mock *mockserver.Server
+
+	mu   sync.Mutex
+	reqs []string
+}
+
+func (l *writeLog) list() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.reqs...)
+}
+
+// useMockAPI points apiClient at a seeded mock server for the rest of the
+// test and returns the log of its write requests. Finds and reports are
+// reads even though they are POSTs.
+func useMockAPI(t *testing.T) *writeLog {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir()) // token cache
+
+	mock := mockserver.New(mockserver.Options{OrgID: 42, Seed: true})
+	log := &writeLog{mock: mock}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := strings.TrimPrefix(r.URL.Path, mockserver.APIPrefix)
+		read := r.Method == http.MethodGet || r.URL.Path == mockserver.TokenPath ||
+			strings.HasSuffix(p, "/find") || strings.HasPrefix(p, "/reports/")
+		if !read {
+			log.mu.Lock()
+			log.reqs = append(log.reqs, r.Method+" "+p)
+			log.mu.Unlock()
+		}
+		mock.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	key, err := writeMockKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Remove(key) })
+	c, err := api.NewClient(&config.Config{Credentials: config.Credentials{
+		ClientID:       "SEARCHADS.mock",
+		TeamID:         "SEARCHADS.mock",
+		KeyID:          "mock",
+		OrgID:          "42",
+		PrivateKeyPath: key,
+		APIBaseURL:     srv.URL + mockserver.APIPrefix,
+		TokenURL:       srv.URL + mockserver.TokenPath,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := apiClient
+	apiClient = c
+	t.Cleanup(func() { apiClient = old })
+	return log
+}
+
+// captureOutput runs fn with os.Stdout and os.Stderr redirected and returns
+// what it wrote to each.
+func captureOutput(t *testing.T, fn func() error) (stdout, stderr string) {
+	t.Helper()
+	dir := t.TempDir()
+	files := make([]*os.File, 2)
+	for i := range files {
+		f, err := os.CreateTemp(dir, "out")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		files[i] = f
+	}
+	oldOut, oldErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = files[0], files[1]
+	err := fn()
+	os.Stdout, os.Stderr = oldOut, oldErr
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make([]string, 2)
+	for i, f := range files {
+		b, err := os.ReadFile(f.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[i] = string(b)
+	}
+	return out[0], out[1]
+}
+
+// runCommand parses args and runs c, returning its stdout and stderr. Flags
+// keep their values from earlier runs of c, so pass every flag that matters.
+func runCommand(t *testing.T, c *cobra.Command, args ...string) (stdout, stderr string) {
+	t.Helper()
+	stdout, stderr, err := runCommandErr(t, c, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stdout, stderr
+}
+
+// runCommandErr is runCommand for runs that may fail.
+func runCommandErr(t *testing.T, c *cobra.Command, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	if err := c.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	c.SetContext(context.Background())
+	stdout, stderr = captureOutput(t, func() error {
+		err = c.RunE(c, nil)
+		return nil
+	})
+	return stdout, stderr, err
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -20,11 +21,19 @@ var negCampaignCreateCmd = &cobra.Command{
 	Use:   "campaign-create",
 	Short: "Create campaign-level negative keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		fromJSON, _ := cmd.Flags().GetString("from-json")
 		text, _ := cmd.Flags().GetString("text")
 		matchType, _ := cmd.Flags().GetString("match-type")
+		fromCSV, _ := cmd.Flags().GetString("from-csv")
 
+		if fromCSV != "" {
+			return runCSVImport(cmd, 0, negativeCSVTarget(campaignID, false))
+		}
+		if err := checkCSVOnlyFlags(cmd); err != nil {
+			return err
+		}
 		var keywords []types.NegativeKeyword
 		if fromJSON != "" {
 			if err := parseJSONInput(fromJSON, &keywords); err != nil {
@@ -34,7 +43,7 @@ var negCampaignCreateCmd = &cobra.Command{
 			keywords = []types.NegativeKeyword{{Text: text, MatchType: matchType}}
 		}
 
-		result, err := apiClient.Negatives().CampaignCreate(campaignID, keywords)
+		result, err := apiClient.Negatives().CampaignCreate(ctx, campaignID, keywords)
 		if err != nil {
 			return err
 		}
@@ -46,9 +55,10 @@ var negCampaignGetCmd = &cobra.Command{
 	Use:   "campaign-get",
 	Short: "Get a campaign-level negative keyword",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		id, _ := cmd.Flags().GetInt64("id")
-		result, err := apiClient.Negatives().CampaignGet(campaignID, id)
+		result, err := apiClient.Negatives().CampaignGet(ctx, campaignID, id)
 		if err != nil {
 			return err
 		}
@@ -60,6 +70,7 @@ var negCampaignListCmd = &cobra.Command{
 	Use:   "campaign-list",
 	Short: "List campaign-level negative keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		limit, _ := cmd.Flags().GetInt("limit")
 		offset, _ := cmd.Flags().GetInt("offset")
@@ -69,16 +80,12 @@ var negCampaignListCmd = &cobra.Command{
 			if limit == 0 && !cmd.Flags().Changed("limit") {
 				limit = defaultPageSize
 			}
-			result, err := collectAllOffsetPaginated(limit, offset, func(lim, off int) ([]types.NegativeKeyword, *types.PageDetail, error) {
-				return apiClient.Negatives().CampaignList(campaignID, lim, off)
+			return printAllOffsetPaginated(ctx, limit, offset, func(ctx context.Context, lim, off int) ([]types.NegativeKeyword, *types.PageDetail, error) {
+				return apiClient.Negatives().CampaignList(ctx, campaignID, lim, off)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.Negatives().CampaignList(campaignID, limit, offset)
+		result, _, err := apiClient.Negatives().CampaignList(ctx, campaignID, limit, offset)
 		if err != nil {
 			return err
 		}
@@ -90,6 +97,7 @@ var negCampaignFindCmd = &cobra.Command{
 	Use:   "campaign-find",
 	Short: "Find campaign-level negative keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
 		limit, _ := cmd.Flags().GetInt("limit")
@@ -126,16 +134,12 @@ var negCampaignFindCmd = &cobra.Command{
 			if selectorJSON != "" {
 				pageSize = limit
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, func(s *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
-				return apiClient.Negatives().CampaignFind(campaignID, s)
+			return printAllSelectorPaginated(ctx, sel, pageSize, func(ctx context.Context, s *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
+				return apiClient.Negatives().CampaignFind(ctx, campaignID, s)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.Negatives().CampaignFind(campaignID, sel)
+		result, _, err := apiClient.Negatives().CampaignFind(ctx, campaignID, sel)
 		if err != nil {
 			return err
 		}
@@ -147,6 +151,7 @@ var negCampaignUpdateCmd = &cobra.Command{
 	Use:   "campaign-update",
 	Short: "Update campaign-level negative keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		fromJSON, _ := cmd.Flags().GetString("from-json")
 
@@ -155,7 +160,7 @@ var negCampaignUpdateCmd = &cobra.Command{
 			return err
 		}
 
-		result, err := apiClient.Negatives().CampaignUpdate(campaignID, keywords)
+		result, err := apiClient.Negatives().CampaignUpdate(ctx, campaignID, keywords)
 		if err != nil {
 			return err
 		}
@@ -167,13 +172,14 @@ var negCampaignDeleteCmd = &cobra.Command{
 	Use:   "campaign-delete",
 	Short: "Delete campaign-level negative keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		idsStr, _ := cmd.Flags().GetString("ids")
 		ids, err := parseIDList(idsStr)
 		if err != nil {
 			return err
 		}
-		if err := apiClient.Negatives().CampaignDelete(campaignID, ids); err != nil {
+		if err := apiClient.Negatives().CampaignDelete(ctx, campaignID, ids); err != nil {
 			return err
 		}
 		fmt.Println("Campaign negative keywords deleted")
@@ -187,12 +193,23 @@ var negAdGroupCreateCmd = &cobra.Command{
 	Use:   "adgroup-create",
 	Short: "Create ad group-level negative keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		fromJSON, _ := cmd.Flags().GetString("from-json")
 		text, _ := cmd.Flags().GetString("text")
 		matchType, _ := cmd.Flags().GetString("match-type")
+		fromCSV, _ := cmd.Flags().GetString("from-csv")
 
+		if fromCSV != "" {
+			return runCSVImport(cmd, adGroupID, negativeCSVTarget(campaignID, true))
+		}
+		if err := checkCSVOnlyFlags(cmd); err != nil {
+			return err
+		}
+		if adGroupID == 0 {
+			return fmt.Errorf("--adgroup-id is required")
+		}
 		var keywords []types.NegativeKeyword
 		if fromJSON != "" {
 			if err := parseJSONInput(fromJSON, &keywords); err != nil {
@@ -202,7 +219,7 @@ var negAdGroupCreateCmd = &cobra.Command{
 			keywords = []types.NegativeKeyword{{Text: text, MatchType: matchType}}
 		}
 
-		result, err := apiClient.Negatives().AdGroupCreate(campaignID, adGroupID, keywords)
+		result, err := apiClient.Negatives().AdGroupCreate(ctx, campaignID, adGroupID, keywords)
 		if err != nil {
 			return err
 		}
@@ -214,10 +231,11 @@ var negAdGroupGetCmd = &cobra.Command{
 	Use:   "adgroup-get",
 	Short: "Get an ad group-level negative keyword",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		id, _ := cmd.Flags().GetInt64("id")
-		result, err := apiClient.Negatives().AdGroupGet(campaignID, adGroupID, id)
+		result, err := apiClient.Negatives().AdGroupGet(ctx, campaignID, adGroupID, id)
 		if err != nil {
 			return err
 		}
@@ -229,6 +247,7 @@ var negAdGroupListCmd = &cobra.Command{
 	Use:   "adgroup-list",
 	Short: "List ad group-level negative keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		limit, _ := cmd.Flags().GetInt("limit")
@@ -239,16 +258,12 @@ var negAdGroupListCmd = &cobra.Command{
 			if limit == 0 && !cmd.Flags().Changed("limit") {
 				limit = defaultPageSize
 			}
-			result, err := collectAllOffsetPaginated(limit, offset, func(lim, off int) ([]types.NegativeKeyword, *types.PageDetail, error) {
-				return apiClient.Negatives().AdGroupList(campaignID, adGroupID, lim, off)
+			return printAllOffsetPaginated(ctx, limit, offset, func(ctx context.Context, lim, off int) ([]types.NegativeKeyword, *types.PageDetail, error) {
+				return apiClient.Negatives().AdGroupList(ctx, campaignID, adGroupID, lim, off)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.Negatives().AdGroupList(campaignID, adGroupID, limit, offset)
+		result, _, err := apiClient.Negatives().AdGroupList(ctx, campaignID, adGroupID, limit, offset)
 		if err != nil {
 			return err
 		}
@@ -260,6 +275,7 @@ var negAdGroupFindCmd = &cobra.Command{
 	Use:   "adgroup-find",
 	Short: "Find ad group-level negative keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		selectorJSON, _ := cmd.Flags().GetString("selector-json")
@@ -297,16 +313,12 @@ var negAdGroupFindCmd = &cobra.Command{
 			if selectorJSON != "" {
 				pageSize = limit
 			}
-			result, err := collectAllSelectorPaginated(sel, pageSize, func(s *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
-				return apiClient.Negatives().AdGroupFind(campaignID, adGroupID, s)
+			return printAllSelectorPaginated(ctx, sel, pageSize, func(ctx context.Context, s *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
+				return apiClient.Negatives().AdGroupFind(ctx, campaignID, adGroupID, s)
 			})
-			if err != nil {
-				return err
-			}
-			return printOutput(result)
 		}
 
-		result, _, err := apiClient.Negatives().AdGroupFind(campaignID, adGroupID, sel)
+		result, _, err := apiClient.Negatives().AdGroupFind(ctx, campaignID, adGroupID, sel)
 		if err != nil {
 			return err
 		}
@@ -318,6 +330,7 @@ var negAdGroupUpdateCmd = &cobra.Command{
 	Use:   "adgroup-update",
 	Short: "Update ad group-level negative keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		fromJSON, _ := cmd.Flags().GetString("from-json")
@@ -327,7 +340,7 @@ var negAdGroupUpdateCmd = &cobra.Command{
 			return err
 		}
 
-		result, err := apiClient.Negatives().AdGroupUpdate(campaignID, adGroupID, keywords)
+		result, err := apiClient.Negatives().AdGroupUpdate(ctx, campaignID, adGroupID, keywords)
 		if err != nil {
 			return err
 		}
@@ -339,6 +352,7 @@ var negAdGroupDeleteCmd = &cobra.Command{
 	Use:   "adgroup-delete",
 	Short: "Delete ad group-level negative keywords",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		campaignID, _ := cmd.Flags().GetInt64("campaign-id")
 		adGroupID, _ := cmd.Flags().GetInt64("adgroup-id")
 		idsStr, _ := cmd.Flags().GetString("ids")
@@ -346,7 +360,7 @@ var negAdGroupDeleteCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if err := apiClient.Negatives().AdGroupDelete(campaignID, adGroupID, ids); err != nil {
+		if err := apiClient.Negatives().AdGroupDelete(ctx, campaignID, adGroupID, ids); err != nil {
 			return err
 		}
 		fmt.Println("Ad group negative keywords deleted")
@@ -379,6 +393,7 @@ func init() {
 	negCampaignCreateCmd.Flags().String("text", "", "Negative keyword text")
 	negCampaignCreateCmd.Flags().String("match-type", "EXACT", "BROAD or EXACT")
 	negCampaignCreateCmd.Flags().String("from-json", "", "JSON input")
+	addCSVImportFlags(negCampaignCreateCmd)
 
 	negCampaignGetCmd.Flags().Int64("id", 0, "Keyword ID")
 	negCampaignGetCmd.MarkFlagRequired("id")
@@ -402,14 +417,20 @@ func init() {
 	for _, c := range []*cobra.Command{negAdGroupCreateCmd, negAdGroupGetCmd, negAdGroupListCmd, negAdGroupFindCmd, negAdGroupUpdateCmd, negAdGroupDeleteCmd} {
 		c.Flags().Int64("campaign-id", 0, "Campaign ID")
 		c.MarkFlagRequired("campaign-id")
-		c.Flags().Int64("adgroup-id", 0, "Ad group ID")
-		c.MarkFlagRequired("adgroup-id")
+		if c == negAdGroupCreateCmd {
+			// --from-csv can take the ad group from a column instead.
+			c.Flags().Int64("adgroup-id", 0, "Ad group ID (with --from-csv, the default for rows without an adgroup column)")
+		} else {
+			c.Flags().Int64("adgroup-id", 0, "Ad group ID")
+			c.MarkFlagRequired("adgroup-id")
+		}
 		negativesCmd.AddCommand(c)
 	}
 
 	negAdGroupCreateCmd.Flags().String("text", "", "Negative keyword text")
 	negAdGroupCreateCmd.Flags().String("match-type", "EXACT", "BROAD or EXACT")
 	negAdGroupCreateCmd.Flags().String("from-json", "", "JSON input")
+	addCSVImportFlags(negAdGroupCreateCmd)
 
 	negAdGroupGetCmd.Flags().Int64("id", 0, "Keyword ID")
 	negAdGroupGetCmd.MarkFlagRequired("id")
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/SaadBelfqih/apple-ads-cli/internal/output"
 	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
 )
 
@@ -15,17 +17,74 @@ func effectivePageSize(pageSize int) int {
 	return defaultPageSize
 }
 
-func collectAllOffsetPaginated[T any](pageSize, startOffset int, fetch func(limit, offset int) ([]T, *types.PageDetail, error)) ([]T, error) {
+func collectAllOffsetPaginated[T any](ctx context.Context, pageSize, startOffset int, fetch func(ctx context.Context, limit, offset int) ([]T, *types.PageDetail, error)) ([]T, error) {
+	var out []T
+	err := walkOffsetPaginated(ctx, pageSize, startOffset, fetch, func(page []T) error {
+		out = append(out, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func collectAllSelectorPaginated[T any](ctx context.Context, sel *types.Selector, pageSize int, fetch func(context.Context, *types.Selector) ([]T, *types.PageDetail, error)) ([]T, error) {
+	var out []T
+	err := walkSelectorPaginated(ctx, sel, pageSize, fetch, func(page []T) error {
+		out = append(out, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// printAllOffsetPaginated fetches every page and prints the combined result.
+// With -o ndjson each page is written as soon as it arrives, so memory stays
+// flat and downstream consumers can start before the last page is fetched.
+func printAllOffsetPaginated[T any](ctx context.Context, pageSize, startOffset int, fetch func(ctx context.Context, limit, offset int) ([]T, *types.PageDetail, error)) error {
+	if getOutputFormat() == output.FormatNDJSON {
+		return walkOffsetPaginated(ctx, pageSize, startOffset, fetch, printPage[T])
+	}
+	result, err := collectAllOffsetPaginated(ctx, pageSize, startOffset, fetch)
+	if err != nil {
+		return err
+	}
+	return printOutput(result)
+}
+
+// printAllSelectorPaginated is the selector-based counterpart of printAllOffsetPaginated.
+func printAllSelectorPaginated[T any](ctx context.Context, sel *types.Selector, pageSize int, fetch func(context.Context, *types.Selector) ([]T, *types.PageDetail, error)) error {
+	if getOutputFormat() == output.FormatNDJSON {
+		return walkSelectorPaginated(ctx, sel, pageSize, fetch, printPage[T])
+	}
+	result, err := collectAllSelectorPaginated(ctx, sel, pageSize, fetch)
+	if err != nil {
+		return err
+	}
+	return printOutput(result)
+}
+
+func printPage[T any](page []T) error {
+	return printOutput(page)
+}
+
+// walkOffsetPaginated calls visit with each page returned by fetch until the
+// last page has been seen.
+func walkOffsetPaginated[T any](ctx context.Context, pageSize, startOffset int, fetch func(ctx context.Context, limit, offset int) ([]T, *types.PageDetail, error), visit func([]T) error) error {
 	limit := effectivePageSize(pageSize)
 	offset := startOffset
 
-	var out []T
 	for {
-		page, pag, err := fetch(limit, offset)
+		page, pag, err := fetch(ctx, limit, offset)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := visit(page); err != nil {
+			return err
 		}
-		out = append(out, page...)
 
 		if len(page) == 0 {
 			break
@@ -47,10 +106,12 @@ func collectAllOffsetPaginated[T any](pageSize, startOffset int, fetch func(limi
 
 		offset += len(page)
 	}
-	return out, nil
+	return nil
 }
 
-func collectAllSelectorPaginated[T any](sel *types.Selector, pageSize int, fetch func(*types.Selector) ([]T, *types.PageDetail, error)) ([]T, error) {
+// walkSelectorPaginated calls visit with each page returned by fetch, advancing
+// the selector's pagination offset between requests.
+func walkSelectorPaginated[T any](ctx context.Context, sel *types.Selector, pageSize int, fetch func(context.Context, *types.Selector) ([]T, *types.PageDetail, error), visit func([]T) error) error {
 	if sel == nil {
 		sel = &types.Selector{}
 	}
@@ -64,19 +125,20 @@ func collectAllSelectorPaginated[T any](sel *types.Selector, pageSize int, fetch
 		}
 	}
 	if limit <= 0 {
-		return nil, fmt.Errorf("invalid page size")
+		return fmt.Errorf("invalid page size")
 	}
 
-	var out []T
 	for {
 		reqSel := *sel // shallow copy so we don't mutate caller's selector
 		reqSel.Pagination = &types.Pagination{Limit: limit, Offset: offset}
 
-		page, pag, err := fetch(&reqSel)
+		page, pag, err := fetch(ctx, &reqSel)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := visit(page); err != nil {
+			return err
 		}
-		out = append(out, page...)
 
 		if len(page) == 0 {
 			break
@@ -97,5 +159,5 @@ func collectAllSelectorPaginated[T any](sel *types.Selector, pageSize int, fetch
 		offset += len(page)
 	}
 
-	return out, nil
+	return nil
 }
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+type pageItem struct {
+	ID int `json:"id"`
+}
+
+// pagedItems serves n items in pages and checks that every earlier item was
+// already written to stdout when the next page is requested.
+func pagedItems(t *testing.T, n int) func(limit, offset int) ([]pageItem, *types.PageDetail) {
+	return func(limit, offset int) ([]pageItem, *types.PageDetail) {
+		out, err := os.ReadFile(os.Stdout.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lines := strings.Count(string(out), "\n"); lines != offset {
+			t.Errorf("at offset %d, %d lines were written", offset, lines)
+		}
+		var page []pageItem
+		for i := offset; i < min(offset+limit, n); i++ {
+			page = append(page, pageItem{ID: i})
+		}
+		return page, &types.PageDetail{TotalResults: n, StartIndex: offset, ItemsPerPage: limit}
+	}
+}
+
+func checkNDJSONItems(t *testing.T, out string, n int) {
+	t.Helper()
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != n {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), n, out)
+	}
+	for i, line := range lines {
+		var v pageItem
+		if err := json.Unmarshal([]byte(line), &v); err != nil || v.ID != i {
+			t.Errorf("line %d = %q (%v), want object with id %d", i, line, err, i)
+		}
+	}
+}
+
+func TestPrintAllPaginatedStreamsNDJSON(t *testing.T) {
+	old := outputFormat
+	outputFormat = "ndjson"
+	t.Cleanup(func() { outputFormat = old })
+	ctx := context.Background()
+
+	fetch := pagedItems(t, 5)
+	out, _ := captureOutput(t, func() error {
+		return printAllOffsetPaginated(ctx, 2, 0, func(ctx context.Context, limit, offset int) ([]pageItem, *types.PageDetail, error) {
+			page, pag := fetch(limit, offset)
+			return page, pag, nil
+		})
+	})
+	checkNDJSONItems(t, out, 5)
+
+	out, _ = captureOutput(t, func() error {
+		return printAllSelectorPaginated(ctx, nil, 2, func(ctx context.Context, sel *types.Selector) ([]pageItem, *types.PageDetail, error) {
+			page, pag := fetch(sel.Pagination.Limit, sel.Pagination.Offset)
+			return page, pag, nil
+		})
+	})
+	checkNDJSONItems(t, out, 5)
+}
@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/account"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+// maxBulkKeywords is the most keywords or negatives the API accepts in one
+// bulk create, update or delete request.
+const maxBulkKeywords = 1000
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the changes needed to make the account match a manifest",
+	Long: `Compare a YAML manifest of campaigns, ad groups, keywords, negatives and ads
+against the live account and print the create/update/delete actions that
+'aads apply' would perform. Nothing is changed.
+
+Campaigns are matched by name, ad groups by name within their campaign,
+keywords and negatives by text and match type, and ads by creative ID.
+Only campaigns named in the manifest are read or changed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		file, _ := cmd.Flags().GetString("file")
+		prune, _ := cmd.Flags().GetBool("prune")
+
+		p, err := buildPlan(ctx, file, prune)
+		if err != nil {
+			return err
+		}
+		printPlanSummary(p)
+		return printOutput(p.Actions)
+	},
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Make the account match a manifest",
+	Long: `Compute the same plan as 'aads plan', show it, and execute it after
+confirmation. Actions run in order: parents are created before their
+children, so IDs of new campaigns and ad groups are filled in as they are
+created. Keyword and negative changes are sent in bulk requests.
+
+Apply stops at the first failed action and prints the per-action results,
+including the ones that already succeeded. Re-running apply picks up from
+the remaining differences.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		file, _ := cmd.Flags().GetString("file")
+		prune, _ := cmd.Flags().GetBool("prune")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		p, err := buildPlan(ctx, file, prune)
+		if err != nil {
+			return err
+		}
+		if len(p.Actions) == 0 {
+			fmt.Fprintln(os.Stderr, "No changes. The account matches the manifest.")
+			return nil
+		}
+
+		for _, a := range p.Actions {
+			fmt.Fprintln(os.Stderr, formatAction(a))
+		}
+		printPlanSummary(p)
+
+		if !yes {
+			ok, err := confirm("Apply these changes?")
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(os.Stderr, "Apply cancelled.")
+				return nil
+			}
+		}
+
+		results, applyErr := newPlanApplier(p).run(ctx)
+		if err := printOutput(results); err != nil {
+			return err
+		}
+		return applyErr
+	},
+}
+
+func buildPlan(ctx context.Context, file string, prune bool) (*account.Plan, error) {
+	m, err := account.LoadManifest(file)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := normalizeCurrencyCode(m.Currency)
+	if currency == "" {
+		if currency, err = resolveMoneyCurrency(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	names := map[string]bool{}
+	for _, c := range m.Campaigns {
+		names[c.Name] = true
+	}
+	st, err := fetchAccountState(ctx, func(c types.Campaign) bool { return names[c.Name] })
+	if err != nil {
+		return nil, err
+	}
+
+	return account.Build(m, st, account.Options{Currency: currency, Prune: prune})
+}
+
+func printPlanSummary(p *account.Plan) {
+	create, update, del := p.Counts()
+	fmt.Fprintf(os.Stderr, "Plan: %d to create, %d to update, %d to delete.\n", create, update, del)
+}
+
+func formatAction(a *account.Action) string {
+	sign := map[account.Op]string{account.OpCreate: "+", account.OpUpdate: "~", account.OpDelete: "-"}[a.Op]
+	line := fmt.Sprintf("%s %s %s", sign, a.Kind, a.Path)
+	if len(a.Changes) > 0 {
+		line += " (" + strings.Join(a.Changes, "; ") + ")"
+	}
+	return line
+}
+
+// applyResult is the outcome of one plan action.
+type applyResult struct {
+	Op     account.Op   `json:"op"`
+	Kind   account.Kind `json:"kind"`
+	Path   string       `json:"path"`
+	ID     int64        `json:"id,omitempty"`
+	Status string       `json:"status"`
+	Error  string       `json:"error,omitempty"`
+}
+
+type planApplier struct {
+	actions   []*account.Action
+	campaigns map[string]int64 // by campaign name
+	adGroups  map[string]int64 // by "campaign/ad group" name
+	results   []applyResult
+}
+
+func newPlanApplier(p *account.Plan) *planApplier {
+	ap := &planApplier{
+		actions:   p.Actions,
+		campaigns: map[string]int64{},
+		adGroups:  map[string]int64{},
+	}
+	for _, a := range p.Actions {
+		if a.CampaignID != 0 {
+			ap.campaigns[a.CampaignName] = a.CampaignID
+		}
+		if a.AdGroupID != 0 {
+			ap.adGroups[a.CampaignName+"/"+a.AdGroupName] = a.AdGroupID
+		}
+	}
+	return ap
+}
+
+// run executes the actions in order, batching up to maxBulkKeywords
+// consecutive keyword and negative actions that share an op and parent. Each
+// batch is one request, so its results show what was applied. It stops at
+// the first error.
+func (ap *planApplier) run(ctx context.Context) ([]applyResult, error) {
+	for i := 0; i < len(ap.actions); {
+		a := ap.actions[i]
+		j := i + 1
+		if bulkKind(a.Kind) {
+			for j < len(ap.actions) && j-i < maxBulkKeywords && sameBatch(a, ap.actions[j]) {
+				j++
+			}
+		}
+		batch := ap.actions[i:j]
+		ids, err := ap.exec(ctx, batch)
+		for k, b := range batch {
+			r := applyResult{Op: b.Op, Kind: b.Kind, Path: b.Path, ID: b.ID, Status: "ok"}
+			if k < len(ids) && ids[k] != 0 {
+				r.ID = ids[k]
+			}
+			if err != nil {
+				r.Status = "error"
+				r.Error = err.Error()
+			}
+			ap.results = append(ap.results, r)
+		}
+		if err != nil {
+			return ap.results, fmt.Errorf("apply %s %s %s: %w", a.Op, a.Kind, a.Path, err)
+		}
+		i = j
+	}
+	return ap.results, nil
+}
+
+func bulkKind(k account.Kind) bool {
+	return k == account.KindKeyword || k == account.KindCampaignNegative || k == account.KindAdGroupNegative
+}
+
+func sameBatch(a, b *account.Action) bool {
+	return a.Op == b.Op && a.Kind == b.Kind && a.CampaignName == b.CampaignName && a.AdGroupName == b.AdGroupName
+}
+
+func (ap *planApplier) campaignID(a *account.Action) (int64, error) {
+	if id := ap.campaigns[a.CampaignName]; id != 0 {
+		return id, nil
+	}
+	return 0, fmt.Errorf("campaign %q has no ID (was it created?)", a.CampaignName)
+}
+
+func (ap *planApplier) adGroupID(a *account.Action) (int64, error) {
+	if id := ap.adGroups[a.CampaignName+"/"+a.AdGroupName]; id != 0 {
+		return id, nil
+	}
+	return 0, fmt.Errorf("ad group %q has no ID (was it created?)", a.CampaignName+"/"+a.AdGroupName)
+}
+
+// exec runs one action, or one batch of bulk actions, and returns the IDs of
+// the affected resources in batch order when the API reports them.
+func (ap *planApplier) exec(ctx context.Context, batch []*account.Action) ([]int64, error) {
+	a := batch[0]
+
+	if a.Kind == account.KindCampaign {
+		switch a.Op {
+		case account.OpCreate:
+			c, err := apiClient.Campaigns().Create(ctx, a.CampaignCreate)
+			if err != nil {
+				return nil, err
+			}
+			ap.campaigns[a.CampaignName] = c.ID
+			return []int64{c.ID}, nil
+		case account.OpUpdate:
+			_, err := apiClient.Campaigns().Update(ctx, a.ID, a.CampaignUpdate)
+			return nil, err
+		}
+		return nil, fmt.Errorf("unsupported op %q", a.Op)
+	}
+
+	cid, err := ap.campaignID(a)
+	if err != nil {
+		return nil, err
+	}
+
+	switch a.Kind {
+	case account.KindAdGroup:
+		switch a.Op {
+		case account.OpCreate:
+			g, err := apiClient.AdGroups().Create(ctx, cid, a.AdGroupCreate)
+			if err != nil {
+				return nil, err
+			}
+			ap.adGroups[a.CampaignName+"/"+a.AdGroupName] = g.ID
+			return []int64{g.ID}, nil
+		case account.OpUpdate:
+			_, err := apiClient.AdGroups().Update(ctx, cid, a.ID, a.AdGroupUpdate)
+			return nil, err
+		case account.OpDelete:
+			return nil, apiClient.AdGroups().Delete(ctx, cid, a.ID)
+		}
+
+	case account.KindCampaignNegative:
+		switch a.Op {
+		case account.OpCreate:
+			return execBulk(batch, func(a *account.Action) types.NegativeKeyword { return *a.Negative },
+				func(in []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
+					return apiClient.Negatives().CampaignCreate(ctx, cid, in)
+				}, func(n types.NegativeKeyword) int64 { return n.ID })
+		case account.OpDelete:
+			return nil, deleteBulk(batch, func(ids []int64) error { return apiClient.Negatives().CampaignDelete(ctx, cid, ids) })
+		}
+
+	case account.KindKeyword, account.KindAdGroupNegative, account.KindAd:
+		gid, err := ap.adGroupID(a)
+		if err != nil {
+			return nil, err
+		}
+		return ap.execAdGroupChild(ctx, batch, cid, gid)
+	}
+	return nil, fmt.Errorf("unsupported op %q for %s", a.Op, a.Kind)
+}
+
+func (ap *planApplier) execAdGroupChild(ctx context.Context, batch []*account.Action, cid, gid int64) ([]int64, error) {
+	a := batch[0]
+	switch a.Kind {
+	case account.KindKeyword:
+		keyword := func(a *account.Action) types.Keyword { return *a.Keyword }
+		keywordID := func(k types.Keyword) int64 { return k.ID }
+		switch a.Op {
+		case account.OpCreate:
+			return execBulk(batch, keyword, func(in []types.Keyword) ([]types.Keyword, error) {
+				return apiClient.Keywords().Create(ctx, cid, gid, in)
+			}, keywordID)
+		case account.OpUpdate:
+			return execBulk(batch, keyword, func(in []types.Keyword) ([]types.Keyword, error) {
+				return apiClient.Keywords().Update(ctx, cid, gid, in)
+			}, keywordID)
+		case account.OpDelete:
+			return nil, deleteBulk(batch, func(ids []int64) error { return apiClient.Keywords().Delete(ctx, cid, gid, ids) })
+		}
+
+	case account.KindAdGroupNegative:
+		switch a.Op {
+		case account.OpCreate:
+			return execBulk(batch, func(a *account.Action) types.NegativeKeyword { return *a.Negative },
+				func(in []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
+					return apiClient.Negatives().AdGroupCreate(ctx, cid, gid, in)
+				}, func(n types.NegativeKeyword) int64 { return n.ID })
+		case account.OpDelete:
+			return nil, deleteBulk(batch, func(ids []int64) error { return apiClient.Negatives().AdGroupDelete(ctx, cid, gid, ids) })
+		}
+
+	case account.KindAd:
+		switch a.Op {
+		case account.OpCreate:
+			ad, err := apiClient.Ads().Create(ctx, cid, gid, a.AdCreate)
+			if err != nil {
+				return nil, err
+			}
+			return []int64{ad.ID}, nil
+		case account.OpUpdate:
+			_, err := apiClient.Ads().Update(ctx, cid, gid, a.ID, a.AdUpdate)
+			return nil, err
+		case account.OpDelete:
+			return nil, apiClient.Ads().Delete(ctx, cid, gid, a.ID)
+		}
+	}
+	return nil, fmt.Errorf("unsupported op %q for %s", a.Op, a.Kind)
+}
+
+// execBulk sends the batch's payloads in one request and returns the
+// resulting IDs in order.
+func execBulk[T any](batch []*account.Action, payload func(*account.Action) T, send func([]T) ([]T, error), id func(T) int64) ([]int64, error) {
+	items := make([]T, len(batch))
+	for i, a := range batch {
+		items[i] = payload(a)
+	}
+	out, err := send(items)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(out))
+	for i, v := range out {
+		ids[i] = id(v)
+	}
+	return ids, nil
+}
+
+func deleteBulk(batch []*account.Action, send func([]int64) error) error {
+	ids := make([]int64, len(batch))
+	for i, a := range batch {
+		ids[i] = a.ID
+	}
+	return send(ids)
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(applyCmd)
+
+	for _, c := range []*cobra.Command{planCmd, applyCmd} {
+		c.Flags().StringP("file", "f", "", "Manifest file (YAML or JSON)")
+		c.MarkFlagRequired("file")
+		c.Flags().Bool("prune", false, "Delete ad groups, keywords, negatives and ads under managed campaigns that aren't in the manifest")
+	}
+	applyCmd.Flags().Bool("yes", false, "Apply without asking for confirmation")
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/account"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func TestPlanApplyRecordsEachChunk(t *testing.T) {
+	writes := useMockAPI(t)
+
+	// One more keyword than fits in a request; the mock rejects the second
+	// request since its keyword has no text.
+	var actions []*account.Action
+	for i := 0; i <= maxBulkKeywords; i++ {
+		text := fmt.Sprintf("term %d", i)
+		if i == maxBulkKeywords {
+			text = ""
+		}
+		actions = append(actions, &account.Action{
+			Op: account.OpCreate, Kind: account.KindKeyword, Path: "Demo - Brand/Exact/" + text,
+			CampaignName: "Demo - Brand", AdGroupName: "Exact", CampaignID: mockCampaignID, AdGroupID: mockExactGroup,
+			Keyword: &types.Keyword{Text: text, MatchType: "EXACT"},
+		})
+	}
+
+	results, err := newPlanApplier(&account.Plan{Actions: actions}).run(context.Background())
+	if err == nil {
+		t.Fatal("want an error from the second request")
+	}
+	if w := writes.list(); len(w) != 2 {
+		t.Fatalf("writes = %v, want 2 requests", w)
+	}
+	if len(results) != len(actions) {
+		t.Fatalf("got %d results, want %d", len(results), len(actions))
+	}
+	for i, r := range results[:maxBulkKeywords] {
+		if r.Status != "ok" || r.ID == 0 {
+			t.Fatalf("result %d = %+v, want ok with an ID", i, r)
+		}
+	}
+	if r := results[maxBulkKeywords]; r.Status != "error" || r.Error == "" {
+		t.Errorf("last result = %+v, want error", r)
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultConcurrency = 4
+
+func effectiveConcurrency() int {
+	if concurrencyFlag > 0 {
+		return concurrencyFlag
+	}
+	return 1
+}
+
+// mapConcurrent calls fn for every item using at most --concurrency workers
+// and returns the results in input order. The first error cancels the
+// remaining work and is returned.
+func mapConcurrent[T, R any](ctx context.Context, items []T, fn func(context.Context, T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	if len(items) == 0 {
+		return results, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	jobs := make(chan int)
+
+	workers := min(effectiveConcurrency(), len(items))
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r, err := fn(ctx, items[i])
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				results[i] = r
+			}
+		}()
+	}
+
+feed:
+	for i := range items {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// forEachConcurrent is mapConcurrent for functions without a result.
+func forEachConcurrent[T any](ctx context.Context, items []T, fn func(context.Context, T) error) error {
+	_, err := mapConcurrent(ctx, items, func(ctx context.Context, item T) (struct{}, error) {
+		return struct{}{}, fn(ctx, item)
+	})
+	return err
+}
@@ -15,8 +15,9 @@ var ppListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List product pages for an app",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		adamID, _ := cmd.Flags().GetInt64("adam-id")
-		result, err := apiClient.ProductPages().List(adamID)
+		result, err := apiClient.ProductPages().List(ctx, adamID)
 		if err != nil {
 			return err
 		}
@@ -28,9 +29,10 @@ var ppGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a product page by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		id, _ := cmd.Flags().GetString("id")
 		adamID, _ := cmd.Flags().GetInt64("adam-id")
-		result, err := apiClient.ProductPages().Get(id, adamID)
+		result, err := apiClient.ProductPages().Get(ctx, id, adamID)
 		if err != nil {
 			return err
 		}
@@ -42,9 +44,10 @@ var ppLocalesCmd = &cobra.Command{
 	Use:   "locales",
 	Short: "Get product page locale details",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		id, _ := cmd.Flags().GetString("id")
 		adamID, _ := cmd.Flags().GetInt64("adam-id")
-		result, err := apiClient.ProductPages().Locales(id, adamID)
+		result, err := apiClient.ProductPages().Locales(ctx, id, adamID)
 		if err != nil {
 			return err
 		}
@@ -56,7 +59,8 @@ var ppCountriesCmd = &cobra.Command{
 	Use:   "countries",
 	Short: "List supported countries and regions",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		result, err := apiClient.ProductPages().Countries()
+		ctx := cmd.Context()
+		result, err := apiClient.ProductPages().Countries(ctx)
 		if err != nil {
 			return err
 		}
@@ -68,7 +72,8 @@ var ppDeviceSizesCmd = &cobra.Command{
 	Use:   "device-sizes",
 	Short: "Get app preview device size mapping",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		result, err := apiClient.ProductPages().DeviceSizes()
+		ctx := cmd.Context()
+		result, err := apiClient.ProductPages().DeviceSizes(ctx)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var profilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "Manage named credential profiles in ~/.aads/config.yaml",
+}
+
+type profileSummary struct {
+	Name     string `json:"name"`
+	Active   bool   `json:"active"`
+	ClientID string `json:"clientId,omitempty"`
+	OrgID    string `json:"orgId,omitempty"`
+	Currency string `json:"defaultCurrency,omitempty"`
+}
+
+var profilesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadFile()
+		if err != nil {
+			return err
+		}
+		active := cfg.ProfileName(profileFlag)
+
+		var result []profileSummary
+		if cfg.ClientID != "" {
+			result = append(result, profileSummary{
+				Name:     config.DefaultProfile,
+				Active:   active == config.DefaultProfile,
+				ClientID: cfg.ClientID,
+				OrgID:    cfg.OrgID,
+				Currency: cfg.DefaultCurrency,
+			})
+		}
+
+		names := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p := cfg.Profiles[name]
+			if p == nil {
+				continue
+			}
+			result = append(result, profileSummary{
+				Name:     name,
+				Active:   active == name,
+				ClientID: p.ClientID,
+				OrgID:    p.OrgID,
+				Currency: p.DefaultCurrency,
+			})
+		}
+		return printOutput(result)
+	},
+}
+
+var profilesUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the profile used when --profile and AADS_PROFILE are not set",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		cfg, err := config.LoadFile()
+		if err != nil {
+			return err
+		}
+
+		if name == config.DefaultProfile {
+			cfg.ActiveProfile = ""
+		} else {
+			if cfg.Profiles[name] == nil {
+				return fmt.Errorf("profile %q not found (run 'aads configure --profile %s' to create it)", name, name)
+			}
+			cfg.ActiveProfile = name
+		}
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+		fmt.Printf("Active profile set to %s\n", name)
+		return nil
+	},
+}
+
+var profilesDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a named profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if name == config.DefaultProfile {
+			return fmt.Errorf("the default profile can't be deleted; edit ~/.aads/config.yaml or run 'aads configure' instead")
+		}
+
+		cfg, err := config.LoadFile()
+		if err != nil {
+			return err
+		}
+		if _, ok := cfg.Profiles[name]; !ok {
+			return fmt.Errorf("profile %q not found", name)
+		}
+
+		delete(cfg.Profiles, name)
+		if cfg.ActiveProfile == name {
+			cfg.ActiveProfile = ""
+		}
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+		fmt.Printf("Profile %s deleted\n", name)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profilesCmd)
+	profilesCmd.AddCommand(profilesListCmd)
+	profilesCmd.AddCommand(profilesUseCmd)
+	profilesCmd.AddCommand(profilesDeleteCmd)
+}
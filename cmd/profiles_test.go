@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfilesListActiveMarker(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".aads")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	data := "client_id: SEARCHADS.top\norg_id: \"100\"\nactive_profile: emea\nprofiles:\n  emea:\n    org_id: \"200\"\n  apac:\n    org_id: \"300\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		flag, env  string
+		wantActive string
+	}{
+		{name: "active_profile", wantActive: "emea"},
+		{name: "env", env: "apac", wantActive: "apac"},
+		{name: "flag over env", flag: "default", env: "apac", wantActive: "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AADS_PROFILE", tt.env)
+			profileFlag, outputFormat = tt.flag, "json"
+			t.Cleanup(func() { profileFlag = "" })
+
+			out, _ := runCommand(t, profilesListCmd)
+			var got []profileSummary
+			if err := json.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("decode %q: %v", out, err)
+			}
+			if len(got) != 3 || got[0].Name != "default" || got[1].Name != "apac" || got[2].Name != "emea" {
+				t.Fatalf("profiles = %+v, want default, apac, emea", got)
+			}
+			for _, p := range got {
+				if p.Active != (p.Name == tt.wantActive) {
+					t.Errorf("%s active = %v, want only %s active", p.Name, p.Active, tt.wantActive)
+				}
+			}
+		})
+	}
+}
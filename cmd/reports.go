@@ -1,6 +1,16 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/api"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/output"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/report"
 	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
 	"github.com/spf13/cobra"
 )
@@ -10,50 +20,582 @@ var reportsCmd = &cobra.Command{
 	Short: "Generate reports",
 }
 
+// reportGroupBy lists the dimensions Apple accepts in a report groupBy.
+var reportGroupBy = []string{"countryOrRegion", "deviceClass", "ageRange", "gender", "adminArea", "locality"}
+
+// selectorOperators are the condition operators Apple accepts in a selector.
+var selectorOperators = []string{
+	"EQUALS", "NOT_EQUALS", "IN", "NOT_IN", "CONTAINS", "CONTAINS_ANY", "CONTAINS_ALL",
+	"STARTSWITH", "ENDSWITH", "GREATER_THAN", "LESS_THAN",
+}
+
 func buildReportRequest(cmd *cobra.Command) (*types.ReportingRequest, error) {
-	startTime, _ := cmd.Flags().GetString("start-time")
-	endTime, _ := cmd.Flags().GetString("end-time")
 	granularity, _ := cmd.Flags().GetString("granularity")
-	groupBy, _ := cmd.Flags().GetString("group-by")
+	groupBy, _ := cmd.Flags().GetStringSlice("group-by")
 	selectorJSON, _ := cmd.Flags().GetString("selector-json")
+	sorts, _ := cmd.Flags().GetStringArray("sort")
+	filters, _ := cmd.Flags().GetStringArray("filter")
+	rowTotals, _ := cmd.Flags().GetBool("row-totals")
+	grandTotals, _ := cmd.Flags().GetBool("grand-totals")
+	noMetrics, _ := cmd.Flags().GetBool("records-with-no-metrics")
+
+	timeZone, err := reportTimeZone(cmd)
+	if err != nil {
+		return nil, err
+	}
+	startTime, endTime, err := reportDates(cmd, timeZone)
+	if err != nil {
+		return nil, err
+	}
 
 	req := &types.ReportingRequest{
-		StartTime:         startTime,
-		EndTime:           endTime,
-		Granularity:       granularity,
-		ReturnRowTotals:   true,
-		ReturnGrandTotals: true,
+		StartTime:                  startTime,
+		EndTime:                    endTime,
+		TimeZone:                   timeZone,
+		Granularity:                granularity,
+		ReturnRowTotals:            rowTotals,
+		ReturnGrandTotals:          grandTotals,
+		ReturnRecordsWithNoMetrics: noMetrics,
 	}
 
-	if groupBy != "" {
-		req.GroupBy = []string{groupBy}
+	for _, dim := range groupBy {
+		canonical, ok := lookupFold(reportGroupBy, strings.TrimSpace(dim))
+		if !ok {
+			return nil, fmt.Errorf("invalid --group-by %q (want one of: %s)", dim, strings.Join(reportGroupBy, ", "))
+		}
+		req.GroupBy = append(req.GroupBy, canonical)
 	}
 
 	if selectorJSON != "" {
+		if len(sorts) > 0 || len(filters) > 0 {
+			return nil, fmt.Errorf("--selector-json cannot be combined with --sort or --filter")
+		}
 		sel, err := parseSelectorJSON(selectorJSON)
 		if err != nil {
 			return nil, err
 		}
 		req.Selector = sel
-	} else {
-		req.Selector = &types.Selector{
-			OrderBy: []*types.Sorting{
-				{Field: "localSpend", SortOrder: "DESCENDING"},
-			},
+		return req, nil
+	}
+
+	req.Selector = &types.Selector{}
+	for _, f := range filters {
+		cond, err := parseFilterFlag(f)
+		if err != nil {
+			return nil, err
 		}
+		req.Selector.Conditions = append(req.Selector.Conditions, cond)
+	}
+	for _, s := range sorts {
+		sorting, err := parseSortFlag(s)
+		if err != nil {
+			return nil, err
+		}
+		req.Selector.OrderBy = append(req.Selector.OrderBy, sorting)
+	}
+	if len(req.Selector.OrderBy) == 0 {
+		req.Selector.OrderBy = []*types.Sorting{{Field: "localSpend", SortOrder: "DESCENDING"}}
 	}
 
 	return req, nil
 }
 
+// parseSortFlag parses a --sort value of the form field[:asc|desc].
+// The order defaults to ascending.
+func parseSortFlag(v string) (*types.Sorting, error) {
+	field, order, _ := strings.Cut(strings.TrimSpace(v), ":")
+	if field == "" {
+		return nil, fmt.Errorf("invalid --sort %q (want field[:asc|desc])", v)
+	}
+	switch strings.ToLower(order) {
+	case "", "asc", "ascending":
+		return &types.Sorting{Field: field, SortOrder: "ASCENDING"}, nil
+	case "desc", "descending":
+		return &types.Sorting{Field: field, SortOrder: "DESCENDING"}, nil
+	}
+	return nil, fmt.Errorf("invalid --sort %q: order must be asc or desc", v)
+}
+
+// parseFilterFlag parses a --filter value of the form field=OPERATOR:value.
+// IN, NOT_IN, CONTAINS_ANY and CONTAINS_ALL take a comma-separated list.
+func parseFilterFlag(v string) (*types.Condition, error) {
+	field, rest, ok := strings.Cut(v, "=")
+	field = strings.TrimSpace(field)
+	op, value, ok2 := strings.Cut(rest, ":")
+	if !ok || !ok2 || field == "" {
+		return nil, fmt.Errorf("invalid --filter %q (want field=OPERATOR:value, e.g. countryOrRegion=IN:US,GB)", v)
+	}
+	op, known := lookupFold(selectorOperators, strings.TrimSpace(op))
+	if !known {
+		return nil, fmt.Errorf("invalid --filter %q: unknown operator (want one of: %s)", v, strings.Join(selectorOperators, ", "))
+	}
+
+	values := []string{value}
+	switch op {
+	case "IN", "NOT_IN", "CONTAINS_ANY", "CONTAINS_ALL":
+		values = strings.Split(value, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+	}
+	return &types.Condition{Field: field, Operator: op, Values: values}, nil
+}
+
+// lookupFold returns the entry of list equal to v ignoring case.
+func lookupFold(list []string, v string) (string, bool) {
+	for _, s := range list {
+		if strings.EqualFold(s, v) {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// reportTimeZone returns the normalized --time-zone value.
+func reportTimeZone(cmd *cobra.Command) (string, error) {
+	timeZone, _ := cmd.Flags().GetString("time-zone")
+	timeZone = strings.ToUpper(strings.TrimSpace(timeZone))
+	switch timeZone {
+	case "", "UTC", "ORTZ":
+		return timeZone, nil
+	}
+	return "", fmt.Errorf("invalid --time-zone %q (want UTC or ORTZ)", timeZone)
+}
+
+// reportDates returns the report's start and end dates, either as given or
+// resolved from --range. Presets resolve in UTC for --time-zone UTC and in
+// the org's time zone otherwise, matching the days Apple reports on.
+func reportDates(cmd *cobra.Command, timeZone string) (string, string, error) {
+	startTime, _ := cmd.Flags().GetString("start-time")
+	endTime, _ := cmd.Flags().GetString("end-time")
+	rangeName, _ := cmd.Flags().GetString("range")
+
+	if rangeName == "" {
+		if startTime == "" || endTime == "" {
+			return "", "", fmt.Errorf("pass --range or both --start-time and --end-time")
+		}
+		return startTime, endTime, nil
+	}
+	if startTime != "" || endTime != "" {
+		return "", "", fmt.Errorf("--range cannot be combined with --start-time or --end-time")
+	}
+
+	loc, err := reportLocation(cmd.Context(), timeZone)
+	if err != nil {
+		return "", "", err
+	}
+	w, err := report.Resolve(rangeName, time.Now().In(loc))
+	if err != nil {
+		return "", "", err
+	}
+	startTime = w.Start.Format(report.DateLayout)
+	endTime = w.End.Format(report.DateLayout)
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Range %s resolves to %s..%s (%s)\n", rangeName, startTime, endTime, loc)
+	}
+	return startTime, endTime, nil
+}
+
+// reportLocation returns the time zone report days are counted in: UTC for
+// --time-zone UTC, the org's time zone otherwise.
+func reportLocation(ctx context.Context, timeZone string) (*time.Location, error) {
+	if timeZone == "UTC" {
+		return time.UTC, nil
+	}
+	return orgLocation(ctx)
+}
+
+// orgLocation returns the time zone of the active org from GET /acls.
+func orgLocation(ctx context.Context) (*time.Location, error) {
+	acls, err := apiClient.ACLs().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("look up org time zone: %w", err)
+	}
+
+	var zones []string
+	if orgID, err := strconv.ParseInt(activeOrgID, 10, 64); err == nil {
+		for _, acl := range acls {
+			if acl.OrgID == orgID && acl.TimeZone != "" {
+				zones = append(zones, acl.TimeZone)
+			}
+		}
+	}
+	if len(zones) == 0 {
+		// Without a matching org, only an unambiguous time zone is usable.
+		seen := map[string]bool{}
+		for _, acl := range acls {
+			if acl.TimeZone != "" && !seen[acl.TimeZone] {
+				seen[acl.TimeZone] = true
+				zones = append(zones, acl.TimeZone)
+			}
+		}
+		if len(zones) != 1 {
+			return nil, fmt.Errorf("unable to determine org time zone; pass --org-id, or --time-zone UTC to resolve --range in UTC")
+		}
+	}
+	return report.Location(zones[0])
+}
+
+// runReport fetches the report, adds --derive columns and prints it. With
+// --compare or --compare-range it prints a period-over-period comparison
+// instead.
+func runReport(cmd *cobra.Command, req *types.ReportingRequest, fetch reportFetcher) error {
+	compare, _ := cmd.Flags().GetString("compare")
+	compareRange, _ := cmd.Flags().GetString("compare-range")
+	if compare != "" || compareRange != "" {
+		return runComparison(cmd, req, fetch, compare, compareRange)
+	}
+
+	if err := enableReportCache(cmd, req.TimeZone); err != nil {
+		return err
+	}
+	result, err := fetchReport(cmd.Context(), req, fetch)
+	if err != nil {
+		return err
+	}
+	if derive, _ := cmd.Flags().GetBool("derive"); derive {
+		report.Derive(result)
+	}
+	return printReport(result)
+}
+
+// runComparison runs req for its own dates and for the baseline period, and
+// prints the row totals side by side with their changes. Granularity is
+// dropped since only totals are compared.
+func runComparison(cmd *cobra.Command, req *types.ReportingRequest, fetch reportFetcher, compare, compareRange string) error {
+	ctx := cmd.Context()
+	if compare != "" && compareRange != "" {
+		return fmt.Errorf("--compare cannot be combined with --compare-range")
+	}
+	if err := enableReportCache(cmd, req.TimeZone); err != nil {
+		return err
+	}
+
+	var baseline report.Window
+	if compare != "" {
+		if !strings.EqualFold(compare, "previous-period") {
+			return fmt.Errorf("invalid --compare %q (want previous-period)", compare)
+		}
+		start, err := time.Parse(report.DateLayout, req.StartTime)
+		if err != nil {
+			return fmt.Errorf("invalid --start-time %q: %w", req.StartTime, err)
+		}
+		end, err := time.Parse(report.DateLayout, req.EndTime)
+		if err != nil {
+			return fmt.Errorf("invalid --end-time %q: %w", req.EndTime, err)
+		}
+		baseline = report.PreviousPeriod(report.Window{Start: start, End: end})
+	} else {
+		from, to, ok := strings.Cut(compareRange, "..")
+		start, err1 := time.Parse(report.DateLayout, strings.TrimSpace(from))
+		end, err2 := time.Parse(report.DateLayout, strings.TrimSpace(to))
+		if !ok || err1 != nil || err2 != nil || end.Before(start) {
+			return fmt.Errorf("invalid --compare-range %q (want YYYY-MM-DD..YYYY-MM-DD)", compareRange)
+		}
+		baseline = report.Window{Start: start, End: end}
+	}
+
+	cur := *req
+	cur.Granularity = ""
+	cur.ReturnRowTotals = true
+	prev := cur
+	prev.StartTime = baseline.Start.Format(report.DateLayout)
+	prev.EndTime = baseline.End.Format(report.DateLayout)
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Comparing %s..%s with %s..%s\n", cur.StartTime, cur.EndTime, prev.StartTime, prev.EndTime)
+	}
+
+	curData, err := fetchReport(ctx, &cur, fetch)
+	if err != nil {
+		return err
+	}
+	prevData, err := fetchReport(ctx, &prev, fetch)
+	if err != nil {
+		return fmt.Errorf("baseline %s..%s: %w", prev.StartTime, prev.EndTime, err)
+	}
+	if derive, _ := cmd.Flags().GetBool("derive"); derive {
+		report.Derive(curData)
+		report.Derive(prevData)
+	}
+
+	result := report.Compare(curData, prevData)
+	result.Current = report.Period{StartTime: cur.StartTime, EndTime: cur.EndTime}
+	result.Previous = report.Period{StartTime: prev.StartTime, EndTime: prev.EndTime}
+	return printComparison(result)
+}
+
+// enableReportCache turns on the on-disk report cache for --cache. Reports
+// in the org's time zone settle by its calendar, so it is looked up unless
+// timeZone is UTC. Without it the cache settles them conservatively.
+func enableReportCache(cmd *cobra.Command, timeZone string) error {
+	if on, _ := cmd.Flags().GetBool("cache"); !on {
+		return nil
+	}
+	ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+	settleDays, _ := cmd.Flags().GetInt("cache-settle-days")
+	if settleDays < 0 {
+		return fmt.Errorf("--cache-settle-days must be >= 0")
+	}
+	dir, err := api.ReportCacheDir()
+	if err != nil {
+		return fmt.Errorf("report cache: %w", err)
+	}
+	rc := &api.ReportCache{Dir: dir, TTL: ttl, SettleDays: settleDays}
+	if timeZone != "UTC" {
+		if loc, err := orgLocation(cmd.Context()); err == nil {
+			rc.OrgLocation = loc
+		}
+	}
+	apiClient.SetReportCache(rc)
+	return nil
+}
+
+type reportFetcher func(ctx context.Context, req *types.ReportingRequest) (*types.ReportingDataResponse, error)
+
+// fetchReport runs req, splitting date ranges longer than Apple allows for
+// the granularity into windows that are fetched with up to --concurrency
+// requests at a time and merged back into one response.
+func fetchReport(ctx context.Context, req *types.ReportingRequest, fetch reportFetcher) (*types.ReportingDataResponse, error) {
+	start, err1 := time.Parse(report.DateLayout, req.StartTime)
+	end, err2 := time.Parse(report.DateLayout, req.EndTime)
+	if err1 != nil || err2 != nil {
+		// Let the API report malformed dates.
+		return fetch(ctx, req)
+	}
+	windows := report.Split(start, end, req.Granularity)
+	if len(windows) == 1 {
+		return fetch(ctx, req)
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Splitting %s..%s into %d requests (max %d days each for %s)\n",
+			req.StartTime, req.EndTime, len(windows), report.MaxDays(req.Granularity), granularityName(req.Granularity))
+	}
+	parts, err := mapConcurrent(ctx, windows, func(ctx context.Context, w report.Window) (*types.ReportingDataResponse, error) {
+		r := *req
+		r.StartTime = w.Start.Format(report.DateLayout)
+		r.EndTime = w.End.Format(report.DateLayout)
+		data, err := fetch(ctx, &r)
+		if err != nil {
+			return nil, fmt.Errorf("%s..%s: %w", r.StartTime, r.EndTime, err)
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged := report.Merge(parts)
+	if req.Selector != nil {
+		report.SortRows(merged.Row, req.Selector.OrderBy)
+	}
+	return merged, nil
+}
+
+// pagedReport wraps fetch so every page of rows is fetched. Grand totals come
+// from the first page.
+func pagedReport(fetch reportFetcher) reportFetcher {
+	return func(ctx context.Context, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+		out := &types.ReportingDataResponse{}
+		first := true
+		err := walkSelectorPaginated(ctx, req.Selector, maxReportRows, func(ctx context.Context, sel *types.Selector) ([]types.ReportRow, *types.PageDetail, error) {
+			r := *req
+			r.Selector = sel
+			data, err := fetch(ctx, &r)
+			if err != nil {
+				return nil, nil, err
+			}
+			if first {
+				out.GrandTotals = data.GrandTotals
+				first = false
+			}
+			return data.Row, nil, nil
+		}, func(page []types.ReportRow) error {
+			out.Row = append(out.Row, page...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
+
+// maxReportRows is the largest page Apple returns for a report.
+const maxReportRows = 1000
+
+func granularityName(g string) string {
+	if g == "" {
+		return "row totals"
+	}
+	return strings.ToUpper(g)
+}
+
+// printReport prints a typed report. JSON and YAML keep the full response
+// (rows, grand totals and granularity buckets); table, CSV and TSV output get
+// one flattened row per entity with a column per dimension and metric, and
+// NDJSON writes one typed row per line.
+func printReport(data *types.ReportingDataResponse) error {
+	switch format := getOutputFormat(); {
+	case format.IsTabular():
+		return printOutput(reportTableRows(data))
+	case format == output.FormatNDJSON:
+		return printOutput(data.Row)
+	}
+	return printOutput(data)
+}
+
+// printComparison prints a period-over-period report. Tabular output gets
+// one row per entity: the current value of each metric plus
+// <metric>.previous, <metric>.change and <metric>.pct columns.
+func printComparison(c *report.Comparison) error {
+	switch format := getOutputFormat(); {
+	case format.IsTabular():
+		rows := make([]map[string]any, 0, len(c.Row))
+		for _, r := range c.Row {
+			row := make(map[string]any)
+			flattenReportValue(row, "", r.Metadata)
+			addSpendColumns(row, r.Current)
+			prev := make(map[string]any)
+			addSpendColumns(prev, r.Previous)
+			for _, m := range report.Metrics {
+				ch, ok := r.Change[m]
+				if !ok {
+					continue
+				}
+				row[m+".previous"] = prev[m]
+				row[m+".change"] = ch.Abs
+				if ch.Pct != nil {
+					row[m+".pct"] = *ch.Pct
+				}
+			}
+			rows = append(rows, row)
+		}
+		return printOutput(rows)
+	case format == output.FormatNDJSON:
+		return printOutput(c.Row)
+	}
+	return printOutput(c)
+}
+
+// reportTableRows flattens report rows into one map per entity. Metadata
+// fields become dimension columns and row totals become metric columns.
+// Rows that only carry granularity buckets expand into one row per bucket.
+func reportTableRows(data *types.ReportingDataResponse) []map[string]any {
+	var rows []map[string]any
+	for _, r := range data.Row {
+		dims := make(map[string]any)
+		flattenReportValue(dims, "", r.Metadata)
+
+		if r.Total != nil || len(r.Granularity) == 0 {
+			row := copyRow(dims)
+			addSpendColumns(row, r.Total)
+			if r.Insights != nil && r.Insights.BidRecommendation != nil && r.Insights.BidRecommendation.SuggestedBidAmount != nil {
+				row["suggestedBidAmount"] = r.Insights.BidRecommendation.SuggestedBidAmount.Amount
+			}
+			rows = append(rows, row)
+			continue
+		}
+
+		for _, g := range r.Granularity {
+			row := copyRow(dims)
+			row["date"] = g.Date
+			addSpendColumns(row, &g.SpendRow)
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func copyRow(src map[string]any) map[string]any {
+	dst := make(map[string]any, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// flattenReportValue writes nested metadata into row using dotted keys.
+// Arrays are joined so they fit in a single cell.
+func flattenReportValue(row map[string]any, key string, v any) {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, fv := range val {
+			if key != "" {
+				k = key + "." + k
+			}
+			flattenReportValue(row, k, fv)
+		}
+	case []any:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			parts = append(parts, fmt.Sprintf("%v", item))
+		}
+		row[key] = strings.Join(parts, ",")
+	default:
+		if key != "" {
+			row[key] = val
+		}
+	}
+}
+
+func addSpendColumns(row map[string]any, s *types.SpendRow) {
+	if s == nil {
+		return
+	}
+	row["impressions"] = s.Impressions
+	row["taps"] = s.Taps
+	row["installs"] = s.Installs
+	row["newDownloads"] = s.NewDownloads
+	row["redownloads"] = s.Redownloads
+	row["latOnInstalls"] = s.LatOnInstalls
+	row["latOffInstalls"] = s.LatOffInstalls
+	row["ttr"] = s.TTR
+	row["conversionRate"] = s.ConversionRate
+	for name, m := range map[string]*types.Money{"avgCPA": s.AvgCPA, "avgCPT": s.AvgCPT, "localSpend": s.LocalSpend} {
+		if m == nil {
+			continue
+		}
+		row[name] = m.Amount
+		if m.Currency != "" {
+			row["currency"] = m.Currency
+		}
+	}
+	if d := s.Derived; d != nil {
+		for name, m := range map[string]*types.Money{"derived.cpt": d.CPT, "derived.cpi": d.CPI, "derived.costPerNewDownload": d.CostPerNewDownload} {
+			if m != nil {
+				row[name] = m.Amount
+			}
+		}
+		for name, f := range map[string]*float64{
+			"derived.ttr":             d.TTR,
+			"derived.conversionRate":  d.ConversionRate,
+			"derived.redownloadShare": d.RedownloadShare,
+			"derived.spendShare":      d.SpendShare,
+		} {
+			if f != nil {
+				row[name] = *f
+			}
+		}
+	}
+}
+
 func addReportFlags(cmd *cobra.Command) {
 	cmd.Flags().String("start-time", "", "Start time (YYYY-MM-DD)")
-	cmd.MarkFlagRequired("start-time")
 	cmd.Flags().String("end-time", "", "End time (YYYY-MM-DD)")
-	cmd.MarkFlagRequired("end-time")
+	cmd.Flags().String("range", "", "Relative date range instead of --start-time/--end-time: "+strings.Join(report.Ranges, ", "))
+	cmd.Flags().String("time-zone", "", "Report time zone: UTC or ORTZ (org time zone, Apple's default)")
 	cmd.Flags().String("granularity", "DAILY", "HOURLY, DAILY, WEEKLY, or MONTHLY")
-	cmd.Flags().String("group-by", "", "Group by dimension (e.g., countryOrRegion)")
-	cmd.Flags().String("selector-json", "", "Selector JSON for filtering")
+	cmd.Flags().StringSlice("group-by", nil, "Group by dimensions, repeated or comma-separated: "+strings.Join(reportGroupBy, ", "))
+	cmd.Flags().Bool("row-totals", true, "Return a total per row (returnRowTotals)")
+	cmd.Flags().Bool("grand-totals", true, "Return totals across all rows (returnGrandTotals)")
+	cmd.Flags().Bool("records-with-no-metrics", false, "Include rows without any activity (returnRecordsWithNoMetrics)")
+	cmd.Flags().StringArray("sort", nil, "Sort by field[:asc|desc], repeatable (default localSpend:desc)")
+	cmd.Flags().StringArray("filter", nil, "Filter as field=OPERATOR:value, repeatable (e.g. countryOrRegion=IN:US,GB or impressions=GREATER_THAN:100)")
+	cmd.Flags().String("selector-json", "", "Selector JSON for filtering (instead of --filter/--sort)")
+	cmd.Flags().String("compare", "", "Compare row totals with another period: previous-period")
+	cmd.Flags().String("compare-range", "", "Compare row totals with this period (YYYY-MM-DD..YYYY-MM-DD)")
+	cmd.Flags().Bool("derive", false, "Add computed KPIs: CPT, CPI, cost per new download, TTR, conversion rate, redownload share, share of spend")
 }
 
 var reportsCampaignsCmd = &cobra.Command{
@@ -64,11 +606,7 @@ var reportsCampaignsCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		result, err := apiClient.Reports().Campaigns(req)
-		if err != nil {
-			return err
-		}
-		return printRawJSON(result)
+		return runReport(cmd, req, apiClient.Reports().Campaigns)
 	},
 }
 
@@ -81,11 +619,9 @@ var reportsAdGroupsCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		result, err := apiClient.Reports().AdGroups(campaignID, req)
-		if err != nil {
-			return err
-		}
-		return printRawJSON(result)
+		return runReport(cmd, req, func(ctx context.Context, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+			return apiClient.Reports().AdGroups(ctx, campaignID, req)
+		})
 	},
 }
 
@@ -103,11 +639,9 @@ var reportsKeywordsCmd = &cobra.Command{
 		if agID > 0 {
 			adGroupID = &agID
 		}
-		result, err := apiClient.Reports().Keywords(campaignID, adGroupID, req)
-		if err != nil {
-			return err
-		}
-		return printRawJSON(result)
+		return runReport(cmd, req, func(ctx context.Context, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+			return apiClient.Reports().Keywords(ctx, campaignID, adGroupID, req)
+		})
 	},
 }
 
@@ -130,11 +664,9 @@ var reportsSearchTermsCmd = &cobra.Command{
 		if agID > 0 {
 			adGroupID = &agID
 		}
-		result, err := apiClient.Reports().SearchTerms(campaignID, adGroupID, req)
-		if err != nil {
-			return err
-		}
-		return printRawJSON(result)
+		return runReport(cmd, req, func(ctx context.Context, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+			return apiClient.Reports().SearchTerms(ctx, campaignID, adGroupID, req)
+		})
 	},
 }
 
@@ -147,16 +679,17 @@ var reportsAdsCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		result, err := apiClient.Reports().Ads(campaignID, req)
-		if err != nil {
-			return err
-		}
-		return printRawJSON(result)
+		return runReport(cmd, req, func(ctx context.Context, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+			return apiClient.Reports().Ads(ctx, campaignID, req)
+		})
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(reportsCmd)
+	reportsCmd.PersistentFlags().Bool("cache", false, "Cache responses in ~/.aads/cache/reports (clear with 'aads cache clear')")
+	reportsCmd.PersistentFlags().Duration("cache-ttl", time.Hour, "How long cached reports that include recent days stay fresh")
+	reportsCmd.PersistentFlags().Int("cache-settle-days", 3, "Reports ending more than this many days ago, in the report's time zone, are cached without expiry")
 
 	addReportFlags(reportsCampaignsCmd)
 	reportsCmd.AddCommand(reportsCampaignsCmd)
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+func TestParseSortFlag(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *types.Sorting
+		wantErr string
+	}{
+		{in: "localSpend", want: &types.Sorting{Field: "localSpend", SortOrder: "ASCENDING"}},
+		{in: " taps:asc ", want: &types.Sorting{Field: "taps", SortOrder: "ASCENDING"}},
+		{in: "taps:ASCENDING", want: &types.Sorting{Field: "taps", SortOrder: "ASCENDING"}},
+		{in: "localSpend:desc", want: &types.Sorting{Field: "localSpend", SortOrder: "DESCENDING"}},
+		{in: "localSpend:Descending", want: &types.Sorting{Field: "localSpend", SortOrder: "DESCENDING"}},
+		{in: "", wantErr: "want field[:asc|desc]"},
+		{in: ":desc", wantErr: "want field[:asc|desc]"},
+		{in: "taps:down", wantErr: "order must be asc or desc"},
+	}
+	for _, tt := range tests {
+		got, err := parseSortFlag(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseSortFlag(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSortFlag(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseSortFlag(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilterFlag(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *types.Condition
+		wantErr string
+	}{
+		{
+			in:   "countryOrRegion=IN:US, GB",
+			want: &types.Condition{Field: "countryOrRegion", Operator: "IN", Values: []string{"US", "GB"}},
+		},
+		{
+			in:   "impressions=greater_than:100",
+			want: &types.Condition{Field: "impressions", Operator: "GREATER_THAN", Values: []string{"100"}},
+		},
+		{
+			// Only list operators split on commas.
+			in:   "keyword=CONTAINS:a,b",
+			want: &types.Condition{Field: "keyword", Operator: "CONTAINS", Values: []string{"a,b"}},
+		},
+		{
+			// Only the first colon separates the operator from the value.
+			in:   " searchTermText =EQUALS:a:b",
+			want: &types.Condition{Field: "searchTermText", Operator: "EQUALS", Values: []string{"a:b"}},
+		},
+		{in: "countryOrRegion", wantErr: "want field=OPERATOR:value"},
+		{in: "countryOrRegion=US", wantErr: "want field=OPERATOR:value"},
+		{in: "=IN:US", wantErr: "want field=OPERATOR:value"},
+		{in: "countryOrRegion=LIKE:US", wantErr: "unknown operator"},
+	}
+	for _, tt := range tests {
+		got, err := parseFilterFlag(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseFilterFlag(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseFilterFlag(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseFilterFlag(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLookupFold(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{in: "deviceClass", want: "deviceClass", wantOK: true},
+		{in: "DEVICECLASS", want: "deviceClass", wantOK: true},
+		{in: "countryorregion", want: "countryOrRegion", wantOK: true},
+		{in: "device", wantOK: false},
+		{in: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := lookupFold(reportGroupBy, tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("lookupFold(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestBuildReportRequestFlags(t *testing.T) {
+	dates := []string{"--start-time", "2025-01-01", "--end-time", "2025-01-31"}
+	tests := []struct {
+		name        string
+		args        []string
+		wantGroupBy []string
+		wantOrderBy []*types.Sorting
+		wantConds   int
+		wantErr     string
+	}{
+		{
+			name:        "defaults",
+			wantOrderBy: []*types.Sorting{{Field: "localSpend", SortOrder: "DESCENDING"}},
+		},
+		{
+			name:        "repeated and comma-separated group-by",
+			args:        []string{"--group-by", "COUNTRYORREGION,deviceClass", "--group-by", " gender "},
+			wantGroupBy: []string{"countryOrRegion", "deviceClass", "gender"},
+			wantOrderBy: []*types.Sorting{{Field: "localSpend", SortOrder: "DESCENDING"}},
+		},
+		{
+			name:    "unknown group-by",
+			args:    []string{"--group-by", "countryOrRegion,city"},
+			wantErr: `invalid --group-by "city"`,
+		},
+		{
+			name: "sorts and filters",
+			args: []string{"--sort", "taps:desc", "--sort", "impressions", "--filter", "countryOrRegion=IN:US,GB"},
+			wantOrderBy: []*types.Sorting{
+				{Field: "taps", SortOrder: "DESCENDING"},
+				{Field: "impressions", SortOrder: "ASCENDING"},
+			},
+			wantConds: 1,
+		},
+		{
+			name:    "selector-json with sort",
+			args:    []string{"--selector-json", `{"orderBy":[]}`, "--sort", "taps"},
+			wantErr: "cannot be combined",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &cobra.Command{}
+			addReportFlags(c)
+			if err := c.ParseFlags(append(append([]string{}, dates...), tt.args...)); err != nil {
+				t.Fatal(err)
+			}
+			req, err := buildReportRequest(c)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(req.GroupBy, tt.wantGroupBy) {
+				t.Errorf("groupBy = %v, want %v", req.GroupBy, tt.wantGroupBy)
+			}
+			if !reflect.DeepEqual(req.Selector.OrderBy, tt.wantOrderBy) {
+				t.Errorf("orderBy = %+v, want %+v", req.Selector.OrderBy, tt.wantOrderBy)
+			}
+			if len(req.Selector.Conditions) != tt.wantConds {
+				t.Errorf("conditions = %+v, want %d", req.Selector.Conditions, tt.wantConds)
+			}
+			if !req.ReturnRowTotals || !req.ReturnGrandTotals || req.Granularity != "DAILY" {
+				t.Errorf("flag defaults not applied: %+v", req)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/SaadBelfqih/apple-ads-cli/internal/api"
 	"github.com/SaadBelfqih/apple-ads-cli/internal/config"
@@ -13,16 +16,23 @@ import (
 )
 
 var (
-	outputFormat string
-	verbose      bool
-	orgIDFlag    string
-	fieldsFlag   string
-	currencyFlag string
+	outputFormat    string
+	verbose         bool
+	orgIDFlag       string
+	profileFlag     string
+	fieldsFlag      string
+	currencyFlag    string
+	timeoutFlag     time.Duration
+	rpsFlag         float64
+	concurrencyFlag int
 
 	apiClient *api.Client
 
 	activeOrgID               string
 	defaultCurrencyFromConfig string
+
+	// cancelTimeout releases the --timeout context once the command returns.
+	cancelTimeout context.CancelFunc
 )
 
 var rootCmd = &cobra.Command{
@@ -30,25 +40,35 @@ var rootCmd = &cobra.Command{
 	Short: "Apple Ads CLI (Campaign Management API v5)",
 	Long:  "A command-line interface for Apple's Apple Ads Campaign Management API v5, with safe retries, auto-pagination, and multiple output formats.",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if timeoutFlag > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeoutFlag)
+			cancelTimeout = cancel
+			cmd.SetContext(ctx)
+		}
+
 		// Lightweight update check (cached). Never installs anything, only prints a notice.
 		// Skip for version/help/configure/update to avoid noisy output.
 		switch cmd.Name() {
-		case "configure", "version", "help", "update":
+		case "configure", "version", "help", "update", "mock-server":
 			// no-op
 		default:
 			updatecheck.MaybeNotify(cmd.Context(), os.Stderr, Version)
 		}
 
 		// Skip client init for commands that don't need it
-		if cmd.Name() == "configure" || cmd.Name() == "version" || cmd.Name() == "help" {
+		if cmd.Name() == "configure" || cmd.Name() == "version" || cmd.Name() == "help" || cmd.Name() == "mock-server" {
 			return nil
 		}
 		// Also skip for parent commands (e.g., "campaigns" without subcommand)
 		if !cmd.HasParent() || cmd.HasSubCommands() && len(args) == 0 {
 			return nil
 		}
+		// Profile and cache management only touch local files.
+		if cmd.Parent() != nil && (cmd.Parent().Name() == "profiles" || cmd.Parent().Name() == "cache") {
+			return nil
+		}
 
-		cfg, err := config.Load()
+		cfg, err := config.LoadProfile(profileFlag)
 		if err != nil {
 			return fmt.Errorf("load config: %w\nRun 'aads configure' to set up", err)
 		}
@@ -65,11 +85,17 @@ var rootCmd = &cobra.Command{
 			requiresOrgID = false
 		}
 
-		if requiresOrgID {
+		switch {
+		case api.ReplayDir() != "":
+			// Cassette replay serves recorded responses; credentials aren't used.
+			if err := cfg.ValidateEndpoints(); err != nil {
+				return fmt.Errorf("invalid config: %w", err)
+			}
+		case requiresOrgID:
 			if err := cfg.Validate(); err != nil {
 				return fmt.Errorf("invalid config: %w\nRun 'aads configure' to fix (or set env vars like AADS_CLIENT_ID, AADS_TEAM_ID, AADS_KEY_ID, AADS_ORG_ID, AADS_PRIVATE_KEY_PATH)", err)
 			}
-		} else {
+		default:
 			if err := cfg.ValidateAuth(); err != nil {
 				return fmt.Errorf("invalid config: %w\nRun 'aads configure' to fix (or set env vars like AADS_CLIENT_ID, AADS_TEAM_ID, AADS_KEY_ID, AADS_PRIVATE_KEY_PATH)", err)
 			}
@@ -81,13 +107,30 @@ var rootCmd = &cobra.Command{
 		}
 
 		client.SetVerbose(verbose)
+		client.SetRateLimit(rpsFlag)
+		if verbose {
+			if cfg.APIBaseURL != "" {
+				fmt.Fprintf(os.Stderr, "API base URL: %s (override)\n", cfg.APIBaseURL)
+			}
+			if cfg.TokenURL != "" {
+				fmt.Fprintf(os.Stderr, "Token URL: %s (override)\n", cfg.TokenURL)
+			}
+		}
 		apiClient = client
 		return nil
 	},
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl-C cancels in-flight requests and retry waits instead of killing
+	// the process mid-write.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if cancelTimeout != nil {
+		cancelTimeout()
+	}
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -97,11 +140,15 @@ func init() {
 	rootCmd.Version = versionLine()
 	rootCmd.SetVersionTemplate("{{.Version}}\n")
 
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "Output format: json, table, yaml")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "Output format: json, table, yaml, csv, tsv, ndjson")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
 	rootCmd.PersistentFlags().StringVar(&orgIDFlag, "org-id", "", "Override org ID from config")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Named profile from ~/.aads/config.yaml (env: AADS_PROFILE)")
 	rootCmd.PersistentFlags().StringVar(&fieldsFlag, "fields", "", "Comma-separated fields for partial fetch")
 	rootCmd.PersistentFlags().StringVar(&currencyFlag, "currency", "", "Override currency for money fields (e.g., USD)")
+	rootCmd.PersistentFlags().Float64Var(&rpsFlag, "rps", 0, "Max API requests per second, retries included (0 = unlimited)")
+	rootCmd.PersistentFlags().IntVar(&concurrencyFlag, "concurrency", defaultConcurrency, "Max parallel API requests for commands that fan out")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 0, "Abort the command after this long, including retries (e.g. 30s, 5m; 0 = no limit)")
 }
 
 func getOutputFormat() output.Format {
@@ -110,6 +157,12 @@ func getOutputFormat() output.Format {
 		return output.FormatTable
 	case "yaml":
 		return output.FormatYAML
+	case "csv":
+		return output.FormatCSV
+	case "tsv":
+		return output.FormatTSV
+	case "ndjson":
+		return output.FormatNDJSON
 	default:
 		return output.FormatJSON
 	}
@@ -118,15 +171,3 @@ func getOutputFormat() output.Format {
 func printOutput(data any) error {
 	return output.Print(os.Stdout, getOutputFormat(), data)
 }
-
-func printRawJSON(data []byte) error {
-	// Parse and re-output through our formatter for consistent formatting
-	var parsed any
-	if err := json.Unmarshal(data, &parsed); err != nil {
-		// If we can't parse, just write raw
-		os.Stdout.Write(data)
-		fmt.Fprintln(os.Stdout)
-		return nil
-	}
-	return output.Print(os.Stdout, getOutputFormat(), parsed)
-}
@@ -0,0 +1,419 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/account"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Export an org to a file, or recreate an export in another org",
+}
+
+var snapshotExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export campaigns, ad groups, keywords, negatives, ads and budget orders",
+	Long: `Walk the org's campaigns (with their negatives, ad groups, keywords and
+ads) and budget orders, and write them as one versioned JSON or YAML document.
+Deleted entities are not included.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		file, _ := cmd.Flags().GetString("file")
+		format, _ := cmd.Flags().GetString("format")
+		idsStr, _ := cmd.Flags().GetString("campaign-ids")
+		skipBudgetOrders, _ := cmd.Flags().GetBool("skip-budget-orders")
+
+		if format == "" {
+			format = account.SnapshotFormat(file)
+		}
+		if format != "json" && format != "yaml" {
+			return fmt.Errorf("invalid --format %q (want json or yaml)", format)
+		}
+
+		include := func(types.Campaign) bool { return true }
+		if idsStr != "" {
+			ids, err := parseIDList(idsStr)
+			if err != nil {
+				return err
+			}
+			wanted := map[int64]bool{}
+			for _, id := range ids {
+				wanted[id] = true
+			}
+			include = func(c types.Campaign) bool { return wanted[c.ID] }
+		}
+
+		st, err := fetchAccountState(ctx, include)
+		if err != nil {
+			return err
+		}
+
+		snap := &account.Snapshot{
+			Version:    account.SnapshotVersion,
+			ExportedAt: time.Now().UTC().Format(time.RFC3339),
+			Campaigns:  st.Campaigns,
+		}
+		snap.OrgID, _ = strconv.ParseInt(activeOrgID, 10, 64)
+		if cur, err := resolveMoneyCurrency(ctx); err == nil {
+			snap.Currency = cur
+		}
+		if !skipBudgetOrders {
+			snap.BudgetOrders, err = collectAllOffsetPaginated(ctx, defaultPageSize, 0, func(ctx context.Context, lim, off int) ([]types.BudgetOrder, *types.PageDetail, error) {
+				return apiClient.BudgetOrders().List(ctx, lim, off)
+			})
+			if err != nil {
+				return fmt.Errorf("list budget orders: %w", err)
+			}
+		}
+
+		data, err := account.MarshalSnapshot(snap, format)
+		if err != nil {
+			return err
+		}
+		if file == "" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(file, data, 0600); err != nil {
+			return fmt.Errorf("write snapshot: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d campaigns and %d budget orders to %s\n", len(snap.Campaigns), len(snap.BudgetOrders), file)
+		return nil
+	},
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Recreate a snapshot in the current org",
+	Long: `Create every campaign in a snapshot, with its negatives, ad groups,
+keywords and ads, in the org selected by --org-id or the active profile.
+New IDs are assigned by the API; the output maps each old ID to its new one.
+
+Money amounts are copied as-is, so restoring into an org with a different
+currency requires --allow-currency-change. Ads reference creatives by ID;
+use --skip-ads when the target org doesn't have the same creatives.
+Restore stops at the first failure and prints what was created so far.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		file, _ := cmd.Flags().GetString("file")
+		var opts restoreOptions
+		opts.dryRun, _ = cmd.Flags().GetBool("dry-run")
+		opts.paused, _ = cmd.Flags().GetBool("paused")
+		opts.budgetOrders, _ = cmd.Flags().GetBool("budget-orders")
+		opts.skipAds, _ = cmd.Flags().GetBool("skip-ads")
+		opts.nameSuffix, _ = cmd.Flags().GetString("name-suffix")
+		allowCurrencyChange, _ := cmd.Flags().GetBool("allow-currency-change")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		snap, err := account.LoadSnapshot(file)
+		if err != nil {
+			return err
+		}
+
+		opts.currency, err = resolveMoneyCurrency(ctx)
+		if err != nil {
+			return err
+		}
+		if snap.Currency != "" && !strings.EqualFold(snap.Currency, opts.currency) && !allowCurrencyChange {
+			return fmt.Errorf("snapshot amounts are in %s but org %s uses %s; pass --allow-currency-change to copy amounts unchanged", snap.Currency, activeOrgID, opts.currency)
+		}
+
+		if !opts.dryRun && !yes {
+			ok, err := confirm(fmt.Sprintf("Create %d campaigns from %s in org %s?", len(snap.Campaigns), file, activeOrgID))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(os.Stderr, "Restore cancelled.")
+				return nil
+			}
+		}
+
+		r := &snapshotRestorer{opts: opts}
+		restoreErr := r.restore(ctx, snap)
+		if err := printOutput(r.results); err != nil {
+			return err
+		}
+		return restoreErr
+	},
+}
+
+type restoreOptions struct {
+	currency     string
+	dryRun       bool
+	paused       bool
+	budgetOrders bool
+	skipAds      bool
+	nameSuffix   string
+}
+
+// restoreResult maps one snapshot entity to the entity created for it.
+type restoreResult struct {
+	Kind   string `json:"kind"`
+	Path   string `json:"path"`
+	OldID  int64  `json:"oldId,omitempty"`
+	NewID  int64  `json:"newId,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type snapshotRestorer struct {
+	opts    restoreOptions
+	results []restoreResult
+}
+
+func (r *snapshotRestorer) money(m *types.Money) *types.Money {
+	if m == nil {
+		return nil
+	}
+	return &types.Money{Amount: m.Amount, Currency: r.opts.currency}
+}
+
+// create runs fn unless this is a dry run and records the old -> new ID.
+func (r *snapshotRestorer) create(kind, path string, oldID int64, fn func() (int64, error)) (int64, error) {
+	ids, err := r.createBulk(kind, []string{path}, []int64{oldID}, func() ([]int64, error) {
+		id, err := fn()
+		return []int64{id}, err
+	})
+	if len(ids) > 0 {
+		return ids[0], err
+	}
+	return 0, err
+}
+
+// createBulk is create for a batch of entities made in one request. The API
+// returns created entities in request order.
+func (r *snapshotRestorer) createBulk(kind string, paths []string, oldIDs []int64, fn func() ([]int64, error)) ([]int64, error) {
+	if r.opts.dryRun {
+		for i, p := range paths {
+			r.results = append(r.results, restoreResult{Kind: kind, Path: p, OldID: oldIDs[i], Status: "planned"})
+		}
+		return nil, nil
+	}
+	ids, err := fn()
+	for i, p := range paths {
+		res := restoreResult{Kind: kind, Path: p, OldID: oldIDs[i], Status: "created"}
+		if i < len(ids) {
+			res.NewID = ids[i]
+		}
+		if err != nil {
+			res.Status = "error"
+			res.Error = err.Error()
+		}
+		r.results = append(r.results, res)
+	}
+	if err != nil {
+		return ids, fmt.Errorf("restore %s %s: %w", kind, paths[0], err)
+	}
+	return ids, nil
+}
+
+func (r *snapshotRestorer) restore(ctx context.Context, snap *account.Snapshot) error {
+	if r.opts.budgetOrders {
+		for _, bo := range snap.BudgetOrders {
+			req := &types.BudgetOrderCreate{
+				Name:              bo.Name,
+				StartDate:         bo.StartDate,
+				EndDate:           bo.EndDate,
+				Budget:            r.money(bo.Budget),
+				OrderNumber:       bo.OrderNumber,
+				SupplySource:      bo.SupplySource,
+				LOCInvoiceDetails: bo.LOCInvoiceDetails,
+			}
+			_, err := r.create("budget-order", bo.Name, bo.ID, func() (int64, error) {
+				out, err := apiClient.BudgetOrders().Create(ctx, req)
+				if err != nil {
+					return 0, err
+				}
+				return out.ID, nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, cs := range snap.Campaigns {
+		if err := r.restoreCampaign(ctx, cs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *snapshotRestorer) restoreCampaign(ctx context.Context, cs account.CampaignState) error {
+	c := cs.Campaign
+	name := c.Name + r.opts.nameSuffix
+	req := &types.CampaignCreate{
+		Name:               name,
+		BudgetAmount:       r.money(c.BudgetAmount),
+		DailyBudgetAmount:  r.money(c.DailyBudgetAmount),
+		AdamID:             c.AdamID,
+		CountriesOrRegions: c.CountriesOrRegions,
+		Status:             c.Status,
+		SupplySources:      c.SupplySources,
+		AdChannelType:      c.AdChannelType,
+	}
+	if r.opts.paused {
+		req.Status = "PAUSED"
+	}
+	campaignID, err := r.create("campaign", name, c.ID, func() (int64, error) {
+		out, err := apiClient.Campaigns().Create(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+		return out.ID, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = r.restoreNegatives("campaign-negative", name, cs.Negatives, func(in []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
+		return apiClient.Negatives().CampaignCreate(ctx, campaignID, in)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, gs := range cs.AdGroups {
+		if err := r.restoreAdGroup(ctx, campaignID, name, gs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *snapshotRestorer) restoreAdGroup(ctx context.Context, campaignID int64, campaignPath string, gs account.AdGroupState) error {
+	g := gs.AdGroup
+	path := campaignPath + "/" + g.Name
+	req := &types.AdGroupCreate{
+		Name:                   g.Name,
+		DefaultBidAmount:       r.money(g.DefaultBidAmount),
+		CpaGoal:                r.money(g.CpaGoal),
+		AutomatedKeywordsOptIn: g.AutomatedKeywordsOptIn,
+		EndTime:                g.EndTime,
+		Status:                 g.Status,
+		TargetingDimensions:    g.TargetingDimensions,
+	}
+	// The API rejects start times in the past; leaving it empty starts now.
+	if g.StartTime > time.Now().UTC().Format("2006-01-02T15:04:05.000") {
+		req.StartTime = g.StartTime
+	}
+	adGroupID, err := r.create("adgroup", path, g.ID, func() (int64, error) {
+		out, err := apiClient.AdGroups().Create(ctx, campaignID, req)
+		if err != nil {
+			return 0, err
+		}
+		return out.ID, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, part := range chunk(gs.Keywords, maxBulkKeywords) {
+		paths := make([]string, len(part))
+		oldIDs := make([]int64, len(part))
+		in := make([]types.Keyword, len(part))
+		for i, k := range part {
+			paths[i] = fmt.Sprintf("%s/%q %s", path, k.Text, k.MatchType)
+			oldIDs[i] = k.ID
+			in[i] = types.Keyword{Text: k.Text, MatchType: k.MatchType, BidAmount: r.money(k.BidAmount), Status: k.Status}
+		}
+		_, err := r.createBulk("keyword", paths, oldIDs, func() ([]int64, error) {
+			out, err := apiClient.Keywords().Create(ctx, campaignID, adGroupID, in)
+			return keywordIDs(out), err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.restoreNegatives("adgroup-negative", path, gs.Negatives, func(in []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
+		return apiClient.Negatives().AdGroupCreate(ctx, campaignID, adGroupID, in)
+	})
+	if err != nil {
+		return err
+	}
+
+	if r.opts.skipAds {
+		return nil
+	}
+	for _, a := range gs.Ads {
+		req := &types.AdCreate{Name: a.Name, CreativeID: a.CreativeID, Status: a.Status}
+		_, err := r.create("ad", fmt.Sprintf("%s/ad:%d", path, a.CreativeID), a.ID, func() (int64, error) {
+			out, err := apiClient.Ads().Create(ctx, campaignID, adGroupID, req)
+			if err != nil {
+				return 0, err
+			}
+			return out.ID, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *snapshotRestorer) restoreNegatives(kind, parent string, negatives []types.NegativeKeyword, send func([]types.NegativeKeyword) ([]types.NegativeKeyword, error)) error {
+	for _, part := range chunk(negatives, maxBulkKeywords) {
+		paths := make([]string, len(part))
+		oldIDs := make([]int64, len(part))
+		in := make([]types.NegativeKeyword, len(part))
+		for i, n := range part {
+			paths[i] = fmt.Sprintf("%s/-%q %s", parent, n.Text, n.MatchType)
+			oldIDs[i] = n.ID
+			in[i] = types.NegativeKeyword{Text: n.Text, MatchType: n.MatchType, Status: n.Status}
+		}
+		_, err := r.createBulk(kind, paths, oldIDs, func() ([]int64, error) {
+			out, err := send(in)
+			ids := make([]int64, len(out))
+			for i, n := range out {
+				ids[i] = n.ID
+			}
+			return ids, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func keywordIDs(in []types.Keyword) []int64 {
+	ids := make([]int64, len(in))
+	for i, k := range in {
+		ids[i] = k.ID
+	}
+	return ids
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+
+	// export
+	snapshotExportCmd.Flags().StringP("file", "f", "", "Output file (default: stdout)")
+	snapshotExportCmd.Flags().String("format", "", "json or yaml (default: from file extension, else json)")
+	snapshotExportCmd.Flags().String("campaign-ids", "", "Comma-separated campaign IDs to export (default: all)")
+	snapshotExportCmd.Flags().Bool("skip-budget-orders", false, "Don't export budget orders")
+	snapshotCmd.AddCommand(snapshotExportCmd)
+
+	// restore
+	snapshotRestoreCmd.Flags().StringP("file", "f", "", "Snapshot file (JSON or YAML)")
+	snapshotRestoreCmd.MarkFlagRequired("file")
+	snapshotRestoreCmd.Flags().Bool("dry-run", false, "List what would be created without calling the API")
+	snapshotRestoreCmd.Flags().Bool("yes", false, "Restore without asking for confirmation")
+	snapshotRestoreCmd.Flags().Bool("paused", false, "Create campaigns as PAUSED regardless of their snapshot status")
+	snapshotRestoreCmd.Flags().Bool("budget-orders", false, "Also create the snapshot's budget orders")
+	snapshotRestoreCmd.Flags().Bool("skip-ads", false, "Don't create ads")
+	snapshotRestoreCmd.Flags().String("name-suffix", "", "Append to every campaign name (e.g. \" (copy)\")")
+	snapshotRestoreCmd.Flags().Bool("allow-currency-change", false, "Restore into an org whose currency differs from the snapshot's")
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+}
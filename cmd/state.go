@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/account"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// fetchAccountState walks the campaigns accepted by include and loads their
+// negatives, ad groups, keywords and ads. Deleted entities are skipped.
+// Campaigns, then ad groups, are fetched in parallel up to --concurrency.
+func fetchAccountState(ctx context.Context, include func(types.Campaign) bool) (*account.State, error) {
+	campaigns, err := collectAllOffsetPaginated(ctx, defaultPageSize, 0, func(ctx context.Context, lim, off int) ([]types.Campaign, *types.PageDetail, error) {
+		return apiClient.Campaigns().List(ctx, lim, off, "")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list campaigns: %w", err)
+	}
+
+	var selected []types.Campaign
+	for _, c := range campaigns {
+		if !c.Deleted && include(c) {
+			selected = append(selected, c)
+		}
+	}
+
+	states, err := mapConcurrent(ctx, selected, fetchCampaignState)
+	if err != nil {
+		return nil, err
+	}
+
+	// Flatten ad groups across campaigns so one pool covers all of them.
+	var adGroups []*account.AdGroupState
+	for _, cs := range states {
+		for i := range cs.AdGroups {
+			adGroups = append(adGroups, &cs.AdGroups[i])
+		}
+	}
+	if err := forEachConcurrent(ctx, adGroups, fillAdGroupState); err != nil {
+		return nil, err
+	}
+
+	st := &account.State{}
+	for _, cs := range states {
+		st.Campaigns = append(st.Campaigns, *cs)
+	}
+	return st, nil
+}
+
+// fetchCampaignState loads a campaign's negatives and ad groups. Ad group
+// children are filled in by fillAdGroupState.
+func fetchCampaignState(ctx context.Context, c types.Campaign) (*account.CampaignState, error) {
+	cs := &account.CampaignState{Campaign: c}
+
+	negatives, err := collectAllOffsetPaginated(ctx, defaultPageSize, 0, func(ctx context.Context, lim, off int) ([]types.NegativeKeyword, *types.PageDetail, error) {
+		return apiClient.Negatives().CampaignList(ctx, c.ID, lim, off)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("campaign %d (%s): list negatives: %w", c.ID, c.Name, err)
+	}
+	cs.Negatives = liveNegatives(negatives)
+
+	adGroups, err := collectAllOffsetPaginated(ctx, defaultPageSize, 0, func(ctx context.Context, lim, off int) ([]types.AdGroup, *types.PageDetail, error) {
+		return apiClient.AdGroups().List(ctx, c.ID, lim, off, "")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("campaign %d (%s): list ad groups: %w", c.ID, c.Name, err)
+	}
+	for _, g := range adGroups {
+		if g.Deleted {
+			continue
+		}
+		if g.CampaignID == 0 {
+			g.CampaignID = c.ID
+		}
+		cs.AdGroups = append(cs.AdGroups, account.AdGroupState{AdGroup: g})
+	}
+	return cs, nil
+}
+
+// fillAdGroupState loads the keywords, negatives and ads of gs.AdGroup.
+func fillAdGroupState(ctx context.Context, gs *account.AdGroupState) error {
+	g := gs.AdGroup
+
+	keywords, err := collectAllOffsetPaginated(ctx, defaultPageSize, 0, func(ctx context.Context, lim, off int) ([]types.Keyword, *types.PageDetail, error) {
+		return apiClient.Keywords().List(ctx, g.CampaignID, g.ID, lim, off)
+	})
+	if err != nil {
+		return fmt.Errorf("ad group %d: list keywords: %w", g.ID, err)
+	}
+	for _, k := range keywords {
+		if !k.Deleted {
+			gs.Keywords = append(gs.Keywords, k)
+		}
+	}
+
+	negatives, err := collectAllOffsetPaginated(ctx, defaultPageSize, 0, func(ctx context.Context, lim, off int) ([]types.NegativeKeyword, *types.PageDetail, error) {
+		return apiClient.Negatives().AdGroupList(ctx, g.CampaignID, g.ID, lim, off)
+	})
+	if err != nil {
+		return fmt.Errorf("ad group %d: list negatives: %w", g.ID, err)
+	}
+	gs.Negatives = liveNegatives(negatives)
+
+	ads, err := collectAllOffsetPaginated(ctx, defaultPageSize, 0, func(ctx context.Context, lim, off int) ([]types.Ad, *types.PageDetail, error) {
+		return apiClient.Ads().List(ctx, g.CampaignID, g.ID, lim, off)
+	})
+	if err != nil {
+		return fmt.Errorf("ad group %d: list ads: %w", g.ID, err)
+	}
+	for _, a := range ads {
+		if !a.Deleted {
+			gs.Ads = append(gs.Ads, a)
+		}
+	}
+	return nil
+}
+
+func liveNegatives(in []types.NegativeKeyword) []types.NegativeKeyword {
+	var out []types.NegativeKeyword
+	for _, n := range in {
+		if !n.Deleted {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -16,7 +15,7 @@ var updateCmd = &cobra.Command{
 	Long:  "Checks GitHub Releases for a newer version and prints the result. This command does not download or install updates.",
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := updatecheck.CheckLatest(context.Background(), os.Stdout, Version); err != nil {
+		if err := updatecheck.CheckLatest(cmd.Context(), os.Stdout, Version); err != nil {
 			if strings.Contains(err.Error(), "404") {
 				fmt.Fprintln(os.Stderr, "Update check unavailable (repo may be private). If you're a contributor, set AADS_GITHUB_TOKEN or GH_TOKEN and try again.")
 				return nil
@@ -0,0 +1,5 @@
+// Package account models an Apple Ads account structure (campaigns, ad groups,
+// keywords, negatives and ads) as a tree. It holds the declarative manifest
+// used by `aads plan`/`aads apply`, the live state fetched from the API, and
+// the diff between the two.
+package account
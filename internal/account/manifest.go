@@ -0,0 +1,223 @@
+package account
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// Manifest is the desired account structure. Field names follow the API's
+// camelCase JSON names so a manifest reads like the --from-json payloads.
+type Manifest struct {
+	// Currency for money amounts. If empty, the CLI resolves it like any
+	// other command (--currency, config, then GET /acls).
+	Currency  string             `json:"currency,omitempty"`
+	Campaigns []ManifestCampaign `json:"campaigns"`
+}
+
+// ManifestCampaign is a campaign matched to live state by name.
+type ManifestCampaign struct {
+	Name               string             `json:"name"`
+	AdamID             int64              `json:"adamId"`
+	CountriesOrRegions []string           `json:"countriesOrRegions"`
+	Budget             string             `json:"budget,omitempty"`
+	DailyBudget        string             `json:"dailyBudget,omitempty"`
+	Status             string             `json:"status,omitempty"`
+	SupplySources      []string           `json:"supplySources,omitempty"`
+	AdChannelType      string             `json:"adChannelType,omitempty"`
+	Negatives          []ManifestNegative `json:"negatives,omitempty"`
+	AdGroups           []ManifestAdGroup  `json:"adGroups,omitempty"`
+}
+
+// ManifestAdGroup is an ad group matched by name within its campaign.
+type ManifestAdGroup struct {
+	Name                   string                     `json:"name"`
+	DefaultBid             string                     `json:"defaultBid"`
+	CpaGoal                string                     `json:"cpaGoal,omitempty"`
+	AutomatedKeywordsOptIn *bool                      `json:"automatedKeywordsOptIn,omitempty"`
+	Status                 string                     `json:"status,omitempty"`
+	StartTime              string                     `json:"startTime,omitempty"`
+	EndTime                string                     `json:"endTime,omitempty"`
+	TargetingDimensions    *types.TargetingDimensions `json:"targetingDimensions,omitempty"`
+	Keywords               []ManifestKeyword          `json:"keywords,omitempty"`
+	Negatives              []ManifestNegative         `json:"negatives,omitempty"`
+	Ads                    []ManifestAd               `json:"ads,omitempty"`
+}
+
+// ManifestKeyword is a targeting keyword matched by text and match type.
+type ManifestKeyword struct {
+	Text      string `json:"text"`
+	MatchType string `json:"matchType,omitempty"` // BROAD (default) or EXACT
+	Bid       string `json:"bid,omitempty"`
+	Status    string `json:"status,omitempty"` // ACTIVE or PAUSED
+}
+
+// ManifestNegative is a negative keyword matched by text and match type.
+type ManifestNegative struct {
+	Text      string `json:"text"`
+	MatchType string `json:"matchType,omitempty"` // EXACT (default) or BROAD
+}
+
+// ManifestAd is an ad matched by creative ID within its ad group.
+type ManifestAd struct {
+	Name       string `json:"name,omitempty"`
+	CreativeID int64  `json:"creativeId"`
+	Status     string `json:"status,omitempty"`
+}
+
+// LoadManifest reads a YAML (or JSON) manifest from path, applies defaults
+// and validates it.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	return ParseManifest(data)
+}
+
+// ParseManifest decodes a YAML (or JSON) manifest, applies defaults and
+// validates it.
+func ParseManifest(data []byte) (*Manifest, error) {
+	// Decode through JSON so the manifest shares the json tags used by the
+	// API types (e.g. TargetingDimensions).
+	var m Manifest
+	if err := decodeDocument(data, &m, true); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+
+	m.applyDefaults()
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (m *Manifest) applyDefaults() {
+	for ci := range m.Campaigns {
+		c := &m.Campaigns[ci]
+		for i := range c.Negatives {
+			c.Negatives[i].MatchType = defaultMatchType(c.Negatives[i].MatchType, "EXACT")
+		}
+		for gi := range c.AdGroups {
+			g := &c.AdGroups[gi]
+			for i := range g.Keywords {
+				g.Keywords[i].MatchType = defaultMatchType(g.Keywords[i].MatchType, "BROAD")
+			}
+			for i := range g.Negatives {
+				g.Negatives[i].MatchType = defaultMatchType(g.Negatives[i].MatchType, "EXACT")
+			}
+		}
+	}
+}
+
+func defaultMatchType(v, def string) string {
+	v = strings.ToUpper(strings.TrimSpace(v))
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// Validate checks that names are present and unique and that every entity
+// can be matched unambiguously against live state.
+func (m *Manifest) Validate() error {
+	campaigns := map[string]bool{}
+	for _, c := range m.Campaigns {
+		if strings.TrimSpace(c.Name) == "" {
+			return fmt.Errorf("manifest: campaign name is required")
+		}
+		if campaigns[c.Name] {
+			return fmt.Errorf("manifest: duplicate campaign %q", c.Name)
+		}
+		campaigns[c.Name] = true
+		if c.AdamID == 0 {
+			return fmt.Errorf("manifest: campaign %q: adamId is required", c.Name)
+		}
+		if len(c.CountriesOrRegions) == 0 {
+			return fmt.Errorf("manifest: campaign %q: countriesOrRegions is required", c.Name)
+		}
+		if err := validateNegatives(c.Name, c.Negatives); err != nil {
+			return err
+		}
+
+		adGroups := map[string]bool{}
+		for _, g := range c.AdGroups {
+			path := c.Name + "/" + g.Name
+			if strings.TrimSpace(g.Name) == "" {
+				return fmt.Errorf("manifest: campaign %q: ad group name is required", c.Name)
+			}
+			if adGroups[g.Name] {
+				return fmt.Errorf("manifest: campaign %q: duplicate ad group %q", c.Name, g.Name)
+			}
+			adGroups[g.Name] = true
+			if g.DefaultBid == "" {
+				return fmt.Errorf("manifest: %s: defaultBid is required", path)
+			}
+
+			keywords := map[string]bool{}
+			for _, k := range g.Keywords {
+				if strings.TrimSpace(k.Text) == "" {
+					return fmt.Errorf("manifest: %s: keyword text is required", path)
+				}
+				if err := validateMatchType(path, k.MatchType); err != nil {
+					return err
+				}
+				key := keywordKey(k.Text, k.MatchType)
+				if keywords[key] {
+					return fmt.Errorf("manifest: %s: duplicate keyword %q (%s)", path, k.Text, k.MatchType)
+				}
+				keywords[key] = true
+			}
+			if err := validateNegatives(path, g.Negatives); err != nil {
+				return err
+			}
+
+			creatives := map[int64]bool{}
+			for _, a := range g.Ads {
+				if a.CreativeID == 0 {
+					return fmt.Errorf("manifest: %s: ad creativeId is required", path)
+				}
+				if creatives[a.CreativeID] {
+					return fmt.Errorf("manifest: %s: duplicate ad for creative %d", path, a.CreativeID)
+				}
+				creatives[a.CreativeID] = true
+			}
+		}
+	}
+	return nil
+}
+
+func validateNegatives(path string, negatives []ManifestNegative) error {
+	seen := map[string]bool{}
+	for _, n := range negatives {
+		if strings.TrimSpace(n.Text) == "" {
+			return fmt.Errorf("manifest: %s: negative keyword text is required", path)
+		}
+		if err := validateMatchType(path, n.MatchType); err != nil {
+			return err
+		}
+		key := keywordKey(n.Text, n.MatchType)
+		if seen[key] {
+			return fmt.Errorf("manifest: %s: duplicate negative keyword %q (%s)", path, n.Text, n.MatchType)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func validateMatchType(path, matchType string) error {
+	switch matchType {
+	case "BROAD", "EXACT":
+		return nil
+	default:
+		return fmt.Errorf("manifest: %s: invalid matchType %q (want BROAD or EXACT)", path, matchType)
+	}
+}
+
+// keywordKey identifies a keyword or negative within its parent. Apple treats
+// keyword text case-insensitively.
+func keywordKey(text, matchType string) string {
+	return strings.ToLower(strings.TrimSpace(text)) + "|" + strings.ToUpper(matchType)
+}
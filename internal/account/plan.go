@@ -0,0 +1,570 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// Op is what an Action does to its resource.
+type Op string
+
+const (
+	OpCreate Op = "create"
+	OpUpdate Op = "update"
+	OpDelete Op = "delete"
+)
+
+// Kind is the resource type an Action targets.
+type Kind string
+
+const (
+	KindCampaign         Kind = "campaign"
+	KindCampaignNegative Kind = "campaign-negative"
+	KindAdGroup          Kind = "adgroup"
+	KindKeyword          Kind = "keyword"
+	KindAdGroupNegative  Kind = "adgroup-negative"
+	KindAd               Kind = "ad"
+)
+
+// Action is one change needed to bring live state in line with the manifest.
+// CampaignID and AdGroupID are zero when the parent is created by an earlier
+// action in the same plan; apply resolves them by name.
+type Action struct {
+	Op      Op       `json:"op"`
+	Kind    Kind     `json:"kind"`
+	Path    string   `json:"path"`
+	ID      int64    `json:"id,omitempty"`
+	Changes []string `json:"changes,omitempty"`
+
+	CampaignName string `json:"-"`
+	AdGroupName  string `json:"-"`
+	CampaignID   int64  `json:"-"`
+	AdGroupID    int64  `json:"-"`
+
+	CampaignCreate *types.CampaignCreate  `json:"-"`
+	CampaignUpdate *types.CampaignUpdate  `json:"-"`
+	AdGroupCreate  *types.AdGroupCreate   `json:"-"`
+	AdGroupUpdate  *types.AdGroupUpdate   `json:"-"`
+	Keyword        *types.Keyword         `json:"-"`
+	Negative       *types.NegativeKeyword `json:"-"`
+	AdCreate       *types.AdCreate        `json:"-"`
+	AdUpdate       *types.AdUpdate        `json:"-"`
+}
+
+// Plan is an ordered list of actions. Creates and updates come first in
+// parent-before-child order, followed by deletes in child-before-parent order.
+type Plan struct {
+	Actions []*Action `json:"actions"`
+}
+
+// Counts returns the number of create, update and delete actions.
+func (p *Plan) Counts() (create, update, del int) {
+	for _, a := range p.Actions {
+		switch a.Op {
+		case OpCreate:
+			create++
+		case OpUpdate:
+			update++
+		case OpDelete:
+			del++
+		}
+	}
+	return create, update, del
+}
+
+// Options controls how a plan is built.
+type Options struct {
+	// Currency is used for every money amount in the manifest.
+	Currency string
+	// Prune deletes ad groups, keywords, negatives and ads that exist under a
+	// campaign named in the manifest but aren't declared there. Campaigns
+	// themselves are never deleted.
+	Prune bool
+}
+
+// Build diffs the manifest against live state.
+func Build(m *Manifest, st *State, opts Options) (*Plan, error) {
+	b := &builder{opts: opts}
+
+	live := map[string]*CampaignState{}
+	if st != nil {
+		for i := range st.Campaigns {
+			c := &st.Campaigns[i]
+			if c.Campaign.Deleted {
+				continue
+			}
+			live[c.Campaign.Name] = c
+		}
+	}
+
+	for _, mc := range m.Campaigns {
+		if err := b.campaign(mc, live[mc.Name]); err != nil {
+			return nil, err
+		}
+	}
+
+	actions := make([]*Action, 0, len(b.actions)+len(b.childDeletes)+len(b.adGroupDeletes))
+	actions = append(actions, b.actions...)
+	actions = append(actions, b.childDeletes...)
+	actions = append(actions, b.adGroupDeletes...)
+	return &Plan{Actions: actions}, nil
+}
+
+type builder struct {
+	opts           Options
+	actions        []*Action
+	childDeletes   []*Action
+	adGroupDeletes []*Action
+}
+
+func (b *builder) add(a *Action) {
+	b.actions = append(b.actions, a)
+}
+
+func (b *builder) money(amount string) (*types.Money, error) {
+	if amount == "" {
+		return nil, nil
+	}
+	if _, err := strconv.ParseFloat(amount, 64); err != nil {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	if b.opts.Currency == "" {
+		return nil, fmt.Errorf("currency is required for amount %q", amount)
+	}
+	return &types.Money{Amount: amount, Currency: b.opts.Currency}, nil
+}
+
+func (b *builder) campaign(mc ManifestCampaign, lc *CampaignState) error {
+	path := mc.Name
+	if lc == nil {
+		req := &types.CampaignCreate{
+			Name:               mc.Name,
+			AdamID:             mc.AdamID,
+			CountriesOrRegions: mc.CountriesOrRegions,
+			Status:             mc.Status,
+			SupplySources:      mc.SupplySources,
+			AdChannelType:      mc.AdChannelType,
+		}
+		var err error
+		if req.BudgetAmount, err = b.money(mc.Budget); err != nil {
+			return fmt.Errorf("%s: budget: %w", path, err)
+		}
+		if req.DailyBudgetAmount, err = b.money(mc.DailyBudget); err != nil {
+			return fmt.Errorf("%s: dailyBudget: %w", path, err)
+		}
+		changes := []string{
+			fmt.Sprintf("adamId: %d", mc.AdamID),
+			"countriesOrRegions: " + strings.Join(mc.CountriesOrRegions, ","),
+		}
+		changes = appendSet(changes, "budget", mc.Budget)
+		changes = appendSet(changes, "dailyBudget", mc.DailyBudget)
+		changes = appendSet(changes, "status", mc.Status)
+		b.add(&Action{Op: OpCreate, Kind: KindCampaign, Path: path, CampaignName: mc.Name, CampaignCreate: req, Changes: changes})
+	} else {
+		c := lc.Campaign
+		if c.AdamID != 0 && c.AdamID != mc.AdamID {
+			return fmt.Errorf("%s: live campaign %d promotes app %d, manifest says %d; adamId can't be changed", path, c.ID, c.AdamID, mc.AdamID)
+		}
+		req := &types.CampaignUpdate{}
+		var changes []string
+		if mc.Budget != "" && !amountEqual(mc.Budget, c.BudgetAmount) {
+			m, err := b.money(mc.Budget)
+			if err != nil {
+				return fmt.Errorf("%s: budget: %w", path, err)
+			}
+			req.BudgetAmount = m
+			changes = append(changes, fmt.Sprintf("budget: %s -> %s", moneyString(c.BudgetAmount), mc.Budget))
+		}
+		if mc.DailyBudget != "" && !amountEqual(mc.DailyBudget, c.DailyBudgetAmount) {
+			m, err := b.money(mc.DailyBudget)
+			if err != nil {
+				return fmt.Errorf("%s: dailyBudget: %w", path, err)
+			}
+			req.DailyBudgetAmount = m
+			changes = append(changes, fmt.Sprintf("dailyBudget: %s -> %s", moneyString(c.DailyBudgetAmount), mc.DailyBudget))
+		}
+		if mc.Status != "" && !strings.EqualFold(mc.Status, c.Status) {
+			req.Status = mc.Status
+			changes = append(changes, fmt.Sprintf("status: %s -> %s", c.Status, mc.Status))
+		}
+		if !sameSet(mc.CountriesOrRegions, c.CountriesOrRegions) {
+			req.CountriesOrRegions = mc.CountriesOrRegions
+			changes = append(changes, fmt.Sprintf("countriesOrRegions: %s -> %s", strings.Join(c.CountriesOrRegions, ","), strings.Join(mc.CountriesOrRegions, ",")))
+		}
+		if len(changes) > 0 {
+			b.add(&Action{Op: OpUpdate, Kind: KindCampaign, Path: path, ID: c.ID, CampaignName: mc.Name, CampaignID: c.ID, CampaignUpdate: req, Changes: changes})
+		}
+	}
+
+	var campaignID int64
+	var liveNegatives []types.NegativeKeyword
+	var liveAdGroups []AdGroupState
+	if lc != nil {
+		campaignID = lc.Campaign.ID
+		liveNegatives = lc.Negatives
+		liveAdGroups = lc.AdGroups
+	}
+
+	b.negatives(KindCampaignNegative, path, mc.Name, "", campaignID, 0, mc.Negatives, liveNegatives)
+
+	liveByName := map[string]*AdGroupState{}
+	for i := range liveAdGroups {
+		g := &liveAdGroups[i]
+		if g.AdGroup.Deleted {
+			continue
+		}
+		liveByName[g.AdGroup.Name] = g
+	}
+	declared := map[string]bool{}
+	for _, mg := range mc.AdGroups {
+		declared[mg.Name] = true
+		if err := b.adGroup(mc.Name, campaignID, mg, liveByName[mg.Name]); err != nil {
+			return err
+		}
+	}
+	if b.opts.Prune {
+		for _, g := range liveAdGroups {
+			if g.AdGroup.Deleted || declared[g.AdGroup.Name] {
+				continue
+			}
+			b.adGroupDeletes = append(b.adGroupDeletes, &Action{
+				Op: OpDelete, Kind: KindAdGroup, Path: path + "/" + g.AdGroup.Name, ID: g.AdGroup.ID,
+				CampaignName: mc.Name, AdGroupName: g.AdGroup.Name, CampaignID: campaignID, AdGroupID: g.AdGroup.ID,
+			})
+		}
+	}
+	return nil
+}
+
+func (b *builder) adGroup(campaignName string, campaignID int64, mg ManifestAdGroup, lg *AdGroupState) error {
+	path := campaignName + "/" + mg.Name
+	if lg == nil {
+		req := &types.AdGroupCreate{
+			Name:                mg.Name,
+			StartTime:           mg.StartTime,
+			EndTime:             mg.EndTime,
+			Status:              mg.Status,
+			TargetingDimensions: mg.TargetingDimensions,
+		}
+		if mg.AutomatedKeywordsOptIn != nil {
+			req.AutomatedKeywordsOptIn = *mg.AutomatedKeywordsOptIn
+		}
+		var err error
+		if req.DefaultBidAmount, err = b.money(mg.DefaultBid); err != nil {
+			return fmt.Errorf("%s: defaultBid: %w", path, err)
+		}
+		if req.CpaGoal, err = b.money(mg.CpaGoal); err != nil {
+			return fmt.Errorf("%s: cpaGoal: %w", path, err)
+		}
+		changes := appendSet(nil, "defaultBid", mg.DefaultBid)
+		changes = appendSet(changes, "cpaGoal", mg.CpaGoal)
+		changes = appendSet(changes, "status", mg.Status)
+		if mg.AutomatedKeywordsOptIn != nil {
+			changes = append(changes, fmt.Sprintf("automatedKeywordsOptIn: %t", *mg.AutomatedKeywordsOptIn))
+		}
+		if mg.TargetingDimensions != nil {
+			changes = append(changes, "targetingDimensions: set")
+		}
+		b.add(&Action{Op: OpCreate, Kind: KindAdGroup, Path: path, CampaignName: campaignName, AdGroupName: mg.Name, CampaignID: campaignID, AdGroupCreate: req, Changes: changes})
+	} else {
+		g := lg.AdGroup
+		req := &types.AdGroupUpdate{}
+		var changes []string
+		if !amountEqual(mg.DefaultBid, g.DefaultBidAmount) {
+			m, err := b.money(mg.DefaultBid)
+			if err != nil {
+				return fmt.Errorf("%s: defaultBid: %w", path, err)
+			}
+			req.DefaultBidAmount = m
+			changes = append(changes, fmt.Sprintf("defaultBid: %s -> %s", moneyString(g.DefaultBidAmount), mg.DefaultBid))
+		}
+		if mg.CpaGoal != "" && !amountEqual(mg.CpaGoal, g.CpaGoal) {
+			m, err := b.money(mg.CpaGoal)
+			if err != nil {
+				return fmt.Errorf("%s: cpaGoal: %w", path, err)
+			}
+			req.CpaGoal = m
+			changes = append(changes, fmt.Sprintf("cpaGoal: %s -> %s", moneyString(g.CpaGoal), mg.CpaGoal))
+		}
+		if mg.Status != "" && !strings.EqualFold(mg.Status, g.Status) {
+			req.Status = mg.Status
+			changes = append(changes, fmt.Sprintf("status: %s -> %s", g.Status, mg.Status))
+		}
+		if mg.AutomatedKeywordsOptIn != nil && *mg.AutomatedKeywordsOptIn != g.AutomatedKeywordsOptIn {
+			req.AutomatedKeywordsOptIn = mg.AutomatedKeywordsOptIn
+			changes = append(changes, fmt.Sprintf("automatedKeywordsOptIn: %t -> %t", g.AutomatedKeywordsOptIn, *mg.AutomatedKeywordsOptIn))
+		}
+		if dims := changedDimensions(mg.TargetingDimensions, g.TargetingDimensions); len(dims) > 0 {
+			req.TargetingDimensions = mg.TargetingDimensions
+			changes = append(changes, "targetingDimensions: "+strings.Join(dims, ","))
+		}
+		if len(changes) > 0 {
+			b.add(&Action{Op: OpUpdate, Kind: KindAdGroup, Path: path, ID: g.ID, CampaignName: campaignName, AdGroupName: mg.Name, CampaignID: campaignID, AdGroupID: g.ID, AdGroupUpdate: req, Changes: changes})
+		}
+	}
+
+	var adGroupID int64
+	var liveKeywords []types.Keyword
+	var liveNegatives []types.NegativeKeyword
+	var liveAds []types.Ad
+	if lg != nil {
+		adGroupID = lg.AdGroup.ID
+		liveKeywords = lg.Keywords
+		liveNegatives = lg.Negatives
+		liveAds = lg.Ads
+	}
+
+	if err := b.keywords(path, campaignName, mg.Name, campaignID, adGroupID, mg.Keywords, liveKeywords); err != nil {
+		return err
+	}
+	b.negatives(KindAdGroupNegative, path, campaignName, mg.Name, campaignID, adGroupID, mg.Negatives, liveNegatives)
+	b.ads(path, campaignName, mg.Name, campaignID, adGroupID, mg.Ads, liveAds)
+	return nil
+}
+
+func (b *builder) keywords(parent, campaignName, adGroupName string, campaignID, adGroupID int64, want []ManifestKeyword, have []types.Keyword) error {
+	live := map[string]types.Keyword{}
+	for _, k := range have {
+		if !k.Deleted {
+			live[keywordKey(k.Text, k.MatchType)] = k
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, mk := range want {
+		key := keywordKey(mk.Text, mk.MatchType)
+		declared[key] = true
+		path := fmt.Sprintf("%s/%q %s", parent, mk.Text, mk.MatchType)
+		base := Action{Kind: KindKeyword, Path: path, CampaignName: campaignName, AdGroupName: adGroupName, CampaignID: campaignID, AdGroupID: adGroupID}
+
+		lk, ok := live[key]
+		if !ok {
+			kw := &types.Keyword{Text: mk.Text, MatchType: mk.MatchType, Status: mk.Status}
+			var err error
+			if kw.BidAmount, err = b.money(mk.Bid); err != nil {
+				return fmt.Errorf("%s: bid: %w", path, err)
+			}
+			a := base
+			a.Op = OpCreate
+			a.Keyword = kw
+			a.Changes = appendSet(appendSet(nil, "bid", mk.Bid), "status", mk.Status)
+			b.add(&a)
+			continue
+		}
+
+		kw := &types.Keyword{ID: lk.ID}
+		var changes []string
+		if mk.Bid != "" && !amountEqual(mk.Bid, lk.BidAmount) {
+			m, err := b.money(mk.Bid)
+			if err != nil {
+				return fmt.Errorf("%s: bid: %w", path, err)
+			}
+			kw.BidAmount = m
+			changes = append(changes, fmt.Sprintf("bid: %s -> %s", moneyString(lk.BidAmount), mk.Bid))
+		}
+		if mk.Status != "" && !strings.EqualFold(mk.Status, lk.Status) {
+			kw.Status = mk.Status
+			changes = append(changes, fmt.Sprintf("status: %s -> %s", lk.Status, mk.Status))
+		}
+		if len(changes) > 0 {
+			a := base
+			a.Op = OpUpdate
+			a.ID = lk.ID
+			a.Keyword = kw
+			a.Changes = changes
+			b.add(&a)
+		}
+	}
+
+	if b.opts.Prune {
+		for _, k := range sortedKeywords(have) {
+			if k.Deleted || declared[keywordKey(k.Text, k.MatchType)] {
+				continue
+			}
+			b.childDeletes = append(b.childDeletes, &Action{
+				Op: OpDelete, Kind: KindKeyword, Path: fmt.Sprintf("%s/%q %s", parent, k.Text, k.MatchType), ID: k.ID,
+				CampaignName: campaignName, AdGroupName: adGroupName, CampaignID: campaignID, AdGroupID: adGroupID,
+			})
+		}
+	}
+	return nil
+}
+
+func (b *builder) negatives(kind Kind, parent, campaignName, adGroupName string, campaignID, adGroupID int64, want []ManifestNegative, have []types.NegativeKeyword) {
+	live := map[string]bool{}
+	for _, n := range have {
+		if !n.Deleted {
+			live[keywordKey(n.Text, n.MatchType)] = true
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, mn := range want {
+		key := keywordKey(mn.Text, mn.MatchType)
+		declared[key] = true
+		if live[key] {
+			continue
+		}
+		b.add(&Action{
+			Op: OpCreate, Kind: kind, Path: fmt.Sprintf("%s/-%q %s", parent, mn.Text, mn.MatchType),
+			CampaignName: campaignName, AdGroupName: adGroupName, CampaignID: campaignID, AdGroupID: adGroupID,
+			Negative: &types.NegativeKeyword{Text: mn.Text, MatchType: mn.MatchType},
+		})
+	}
+
+	if b.opts.Prune {
+		for _, n := range have {
+			if n.Deleted || declared[keywordKey(n.Text, n.MatchType)] {
+				continue
+			}
+			b.childDeletes = append(b.childDeletes, &Action{
+				Op: OpDelete, Kind: kind, Path: fmt.Sprintf("%s/-%q %s", parent, n.Text, n.MatchType), ID: n.ID,
+				CampaignName: campaignName, AdGroupName: adGroupName, CampaignID: campaignID, AdGroupID: adGroupID,
+			})
+		}
+	}
+}
+
+func (b *builder) ads(parent, campaignName, adGroupName string, campaignID, adGroupID int64, want []ManifestAd, have []types.Ad) {
+	live := map[int64]types.Ad{}
+	for _, a := range have {
+		if !a.Deleted {
+			live[a.CreativeID] = a
+		}
+	}
+
+	declared := map[int64]bool{}
+	for _, ma := range want {
+		declared[ma.CreativeID] = true
+		path := fmt.Sprintf("%s/ad:%d", parent, ma.CreativeID)
+		base := Action{Kind: KindAd, Path: path, CampaignName: campaignName, AdGroupName: adGroupName, CampaignID: campaignID, AdGroupID: adGroupID}
+
+		la, ok := live[ma.CreativeID]
+		if !ok {
+			a := base
+			a.Op = OpCreate
+			a.AdCreate = &types.AdCreate{Name: ma.Name, CreativeID: ma.CreativeID, Status: ma.Status}
+			a.Changes = appendSet(appendSet(nil, "name", ma.Name), "status", ma.Status)
+			b.add(&a)
+			continue
+		}
+
+		req := &types.AdUpdate{}
+		var changes []string
+		if ma.Name != "" && ma.Name != la.Name {
+			req.Name = ma.Name
+			changes = append(changes, fmt.Sprintf("name: %s -> %s", la.Name, ma.Name))
+		}
+		if ma.Status != "" && !strings.EqualFold(ma.Status, la.Status) {
+			req.Status = ma.Status
+			changes = append(changes, fmt.Sprintf("status: %s -> %s", la.Status, ma.Status))
+		}
+		if len(changes) > 0 {
+			a := base
+			a.Op = OpUpdate
+			a.ID = la.ID
+			a.AdUpdate = req
+			a.Changes = changes
+			b.add(&a)
+		}
+	}
+
+	if b.opts.Prune {
+		for _, a := range have {
+			if a.Deleted || declared[a.CreativeID] {
+				continue
+			}
+			b.childDeletes = append(b.childDeletes, &Action{
+				Op: OpDelete, Kind: KindAd, Path: fmt.Sprintf("%s/ad:%d", parent, a.CreativeID), ID: a.ID,
+				CampaignName: campaignName, AdGroupName: adGroupName, CampaignID: campaignID, AdGroupID: adGroupID,
+			})
+		}
+	}
+}
+
+// changedDimensions lists the targeting dimensions set in want whose value
+// differs from have. Dimensions the manifest leaves out are not compared.
+func changedDimensions(want, have *types.TargetingDimensions) []string {
+	if want == nil {
+		return nil
+	}
+	wantMap := dimensionMap(want)
+	haveMap := dimensionMap(have)
+	var changed []string
+	for k, v := range wantMap {
+		if string(v) != string(haveMap[k]) {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func dimensionMap(d *types.TargetingDimensions) map[string]json.RawMessage {
+	out := map[string]json.RawMessage{}
+	if d == nil {
+		return out
+	}
+	b, err := json.Marshal(d)
+	if err != nil {
+		return out
+	}
+	_ = json.Unmarshal(b, &out)
+	return out
+}
+
+func sortedKeywords(in []types.Keyword) []types.Keyword {
+	out := append([]types.Keyword(nil), in...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Text < out[j].Text })
+	return out
+}
+
+func amountEqual(amount string, m *types.Money) bool {
+	if m == nil {
+		return amount == ""
+	}
+	a, errA := strconv.ParseFloat(amount, 64)
+	b, errB := strconv.ParseFloat(m.Amount, 64)
+	if errA != nil || errB != nil {
+		return amount == m.Amount
+	}
+	diff := a - b
+	return diff < 1e-9 && diff > -1e-9
+}
+
+func moneyString(m *types.Money) string {
+	if m == nil {
+		return "(none)"
+	}
+	return m.Amount
+}
+
+func appendSet(changes []string, field, value string) []string {
+	if value == "" {
+		return changes
+	}
+	return append(changes, field+": "+value)
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := map[string]int{}
+	for _, v := range a {
+		seen[strings.ToUpper(v)]++
+	}
+	for _, v := range b {
+		key := strings.ToUpper(v)
+		if seen[key] == 0 {
+			return false
+		}
+		seen[key]--
+	}
+	return true
+}
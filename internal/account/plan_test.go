@@ -0,0 +1,113 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+const testManifest = `
+currency: USD
+campaigns:
+  - name: Brand US
+    adamId: 123
+    countriesOrRegions: [US]
+    dailyBudget: "50"
+    negatives:
+      - text: free
+    adGroups:
+      - name: Exact
+        defaultBid: "1.50"
+        keywords:
+          - text: my app
+            matchType: EXACT
+            bid: "2"
+          - text: my app pro
+            matchType: EXACT
+      - name: Discovery
+        defaultBid: "1"
+        keywords:
+          - text: photo editor
+`
+
+func TestBuildDiffsManifestAgainstState(t *testing.T) {
+	m, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatalf("parse manifest: %v", err)
+	}
+
+	usd := func(a string) *types.Money { return &types.Money{Amount: a, Currency: "USD"} }
+	st := &State{Campaigns: []CampaignState{{
+		Campaign:  types.Campaign{ID: 1, Name: "Brand US", AdamID: 123, CountriesOrRegions: []string{"us"}, DailyBudgetAmount: usd("40.00")},
+		Negatives: []types.NegativeKeyword{{ID: 5, Text: "FREE", MatchType: "EXACT"}},
+		AdGroups: []AdGroupState{
+			{
+				AdGroup: types.AdGroup{ID: 10, Name: "Exact", DefaultBidAmount: usd("1.5")},
+				Keywords: []types.Keyword{
+					{ID: 100, Text: "my app", MatchType: "EXACT", BidAmount: usd("1")},
+					{ID: 101, Text: "old term", MatchType: "EXACT"},
+				},
+			},
+			{AdGroup: types.AdGroup{ID: 11, Name: "Legacy", DefaultBidAmount: usd("1")}},
+		},
+	}}}
+
+	p, err := Build(m, st, Options{Currency: "USD", Prune: true})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	want := []struct {
+		op   Op
+		kind Kind
+		path string
+	}{
+		{OpUpdate, KindCampaign, "Brand US"},
+		{OpUpdate, KindKeyword, `Brand US/Exact/"my app" EXACT`},
+		{OpCreate, KindKeyword, `Brand US/Exact/"my app pro" EXACT`},
+		{OpCreate, KindAdGroup, "Brand US/Discovery"},
+		{OpCreate, KindKeyword, `Brand US/Discovery/"photo editor" BROAD`},
+		{OpDelete, KindKeyword, `Brand US/Exact/"old term" EXACT`},
+		{OpDelete, KindAdGroup, "Brand US/Legacy"},
+	}
+	if len(p.Actions) != len(want) {
+		for _, a := range p.Actions {
+			t.Logf("%s %s %s %v", a.Op, a.Kind, a.Path, a.Changes)
+		}
+		t.Fatalf("got %d actions, want %d", len(p.Actions), len(want))
+	}
+	for i, w := range want {
+		a := p.Actions[i]
+		if a.Op != w.op || a.Kind != w.kind || a.Path != w.path {
+			t.Errorf("action %d = %s %s %s, want %s %s %s", i, a.Op, a.Kind, a.Path, w.op, w.kind, w.path)
+		}
+	}
+
+	if got := p.Actions[0].Changes; len(got) != 1 || got[0] != "dailyBudget: 40.00 -> 50" {
+		t.Errorf("campaign changes = %v", got)
+	}
+	if kw := p.Actions[1].Keyword; kw.ID != 100 || kw.BidAmount == nil || kw.BidAmount.Amount != "2" {
+		t.Errorf("keyword update = %+v", kw)
+	}
+	if a := p.Actions[4]; a.AdGroupID != 0 || a.AdGroupName != "Discovery" || a.CampaignID != 1 {
+		t.Errorf("new ad group keyword parent = %d/%q/%d", a.AdGroupID, a.AdGroupName, a.CampaignID)
+	}
+	if c, u, d := p.Counts(); c != 3 || u != 2 || d != 2 {
+		t.Errorf("counts = %d/%d/%d", c, u, d)
+	}
+
+	p, err = Build(m, st, Options{Currency: "USD"})
+	if err != nil {
+		t.Fatalf("build without prune: %v", err)
+	}
+	if _, _, d := p.Counts(); d != 0 {
+		t.Errorf("deletes without --prune = %d", d)
+	}
+}
+
+func TestParseManifestRejectsUnknownFields(t *testing.T) {
+	_, err := ParseManifest([]byte("campaigns:\n  - name: A\n    adamId: 1\n    countriesOrRegions: [US]\n    dailyBuget: \"5\"\n"))
+	if err == nil {
+		t.Fatal("expected error for misspelled field")
+	}
+}
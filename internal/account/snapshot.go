@@ -0,0 +1,89 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// SnapshotVersion is the snapshot format written by this version of the CLI.
+// Bump it when a change would make older readers misinterpret a snapshot.
+const SnapshotVersion = 1
+
+// Snapshot is a point-in-time export of an org's campaign tree and budget
+// orders. IDs are the source org's; restore maps them to new ones.
+type Snapshot struct {
+	Version      int                 `json:"version"`
+	ExportedAt   string              `json:"exportedAt"`
+	OrgID        int64               `json:"orgId,omitempty"`
+	Currency     string              `json:"currency,omitempty"`
+	BudgetOrders []types.BudgetOrder `json:"budgetOrders,omitempty"`
+	Campaigns    []CampaignState     `json:"campaigns"`
+}
+
+// MarshalSnapshot encodes s as JSON, or as YAML when format is "yaml".
+// YAML output uses the same camelCase keys as the JSON form.
+func MarshalSnapshot(s *Snapshot, format string) ([]byte, error) {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode snapshot: %w", err)
+	}
+	if format != "yaml" {
+		return append(b, '\n'), nil
+	}
+	// JSON is valid YAML; decoding it with yaml.v3 keeps integers intact.
+	var doc any
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("encode snapshot: %w", err)
+	}
+	return yaml.Marshal(doc)
+}
+
+// SnapshotFormat picks "yaml" for .yaml/.yml paths and "json" otherwise.
+func SnapshotFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return "json"
+	}
+}
+
+// LoadSnapshot reads a JSON or YAML snapshot and checks its version.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	var s Snapshot
+	if err := decodeDocument(data, &s, false); err != nil {
+		return nil, fmt.Errorf("parse snapshot: %w", err)
+	}
+	if s.Version < 1 || s.Version > SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d (this CLI reads up to %d)", s.Version, SnapshotVersion)
+	}
+	return &s, nil
+}
+
+// decodeDocument decodes YAML (or JSON) into v via JSON so that v's json tags
+// apply. With strict set, unknown fields are an error.
+func decodeDocument(data []byte, v any, strict bool) error {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(v)
+}
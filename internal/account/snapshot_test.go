@@ -0,0 +1,52 @@
+package account
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func TestSnapshotRoundTripsThroughYAML(t *testing.T) {
+	snap := &Snapshot{
+		Version:  SnapshotVersion,
+		OrgID:    40669820,
+		Currency: "USD",
+		Campaigns: []CampaignState{{
+			Campaign: types.Campaign{ID: 1234567890123, Name: "Brand", DailyBudgetAmount: &types.Money{Amount: "50", Currency: "USD"}},
+			AdGroups: []AdGroupState{{
+				AdGroup:  types.AdGroup{ID: 9876543210, Name: "Exact"},
+				Keywords: []types.Keyword{{ID: 42, Text: "my app", MatchType: "EXACT"}},
+			}},
+		}},
+	}
+
+	data, err := MarshalSnapshot(snap, "yaml")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), "dailyBudgetAmount:") || strings.Contains(string(data), "e+") {
+		t.Fatalf("unexpected yaml:\n%s", data)
+	}
+
+	path := filepath.Join(t.TempDir(), "snap.yaml")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got.Campaigns[0].Campaign.ID != 1234567890123 || got.Campaigns[0].AdGroups[0].Keywords[0].Text != "my app" {
+		t.Fatalf("round trip mismatch: %+v", got.Campaigns[0])
+	}
+
+	if err := os.WriteFile(path, []byte("version: 99\ncampaigns: []\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSnapshot(path); err == nil || !strings.Contains(err.Error(), "unsupported snapshot version") {
+		t.Fatalf("expected version error, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package account
+
+import "github.com/SaadBelfqih/apple-ads-cli/internal/types"
+
+// State is the live structure of (part of) an account as returned by the API.
+type State struct {
+	Campaigns []CampaignState `json:"campaigns"`
+}
+
+// CampaignState is a campaign with its campaign-level negatives and ad groups.
+type CampaignState struct {
+	Campaign  types.Campaign          `json:"campaign"`
+	Negatives []types.NegativeKeyword `json:"negatives,omitempty"`
+	AdGroups  []AdGroupState          `json:"adGroups,omitempty"`
+}
+
+// AdGroupState is an ad group with its keywords, negatives and ads.
+type AdGroupState struct {
+	AdGroup   types.AdGroup           `json:"adGroup"`
+	Keywords  []types.Keyword         `json:"keywords,omitempty"`
+	Negatives []types.NegativeKeyword `json:"negatives,omitempty"`
+	Ads       []types.Ad              `json:"ads,omitempty"`
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -18,8 +19,8 @@ func (c *Client) ACLs() *ACLService {
 }
 
 // List retrieves all user ACLs.
-func (s *ACLService) List() ([]types.UserACL, error) {
-	body, err := s.client.Get("/acls")
+func (s *ACLService) List(ctx context.Context) ([]types.UserACL, error) {
+	body, err := s.client.Get(ctx, "/acls")
 	if err != nil {
 		return nil, err
 	}
@@ -32,8 +33,8 @@ func (s *ACLService) List() ([]types.UserACL, error) {
 }
 
 // Me retrieves the calling user's details.
-func (s *ACLService) Me() (*types.MeDetail, error) {
-	body, err := s.client.Get("/me")
+func (s *ACLService) Me(ctx context.Context) (*types.MeDetail, error) {
+	body, err := s.client.Get(ctx, "/me")
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,8 +16,8 @@ func (c *Client) AdRejections() *AdRejectionService {
 	return &AdRejectionService{client: c}
 }
 
-func (s *AdRejectionService) Find(selector *types.Selector) ([]types.ProductPageReason, *types.PageDetail, error) {
-	body, err := s.client.Post("/product-page-reasons/find", selector)
+func (s *AdRejectionService) Find(ctx context.Context, selector *types.Selector) ([]types.ProductPageReason, *types.PageDetail, error) {
+	body, err := s.client.Post(ctx, "/product-page-reasons/find", selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -27,9 +28,9 @@ func (s *AdRejectionService) Find(selector *types.Selector) ([]types.ProductPage
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *AdRejectionService) Get(productPageReasonID int64) (*types.ProductPageReason, error) {
+func (s *AdRejectionService) Get(ctx context.Context, productPageReasonID int64) (*types.ProductPageReason, error) {
 	path := fmt.Sprintf("/product-page-reasons/%d", productPageReasonID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -40,8 +41,8 @@ func (s *AdRejectionService) Get(productPageReasonID int64) (*types.ProductPageR
 	return resp.Data, nil
 }
 
-func (s *AdRejectionService) FindAssets(adamID int64, selector *types.Selector) ([]types.AppAsset, *types.PageDetail, error) {
-	body, err := s.client.Post(fmt.Sprintf("/apps/%d/assets/find", adamID), selector)
+func (s *AdRejectionService) FindAssets(ctx context.Context, adamID int64, selector *types.Selector) ([]types.AppAsset, *types.PageDetail, error) {
+	body, err := s.client.Post(ctx, fmt.Sprintf("/apps/%d/assets/find", adamID), selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -16,8 +17,8 @@ func (c *Client) AdGroups() *AdGroupService {
 	return &AdGroupService{client: c}
 }
 
-func (s *AdGroupService) Create(campaignID int64, req *types.AdGroupCreate) (*types.AdGroup, error) {
-	body, err := s.client.Post(fmt.Sprintf("/campaigns/%d/adgroups", campaignID), req)
+func (s *AdGroupService) Create(ctx context.Context, campaignID int64, req *types.AdGroupCreate) (*types.AdGroup, error) {
+	body, err := s.client.Post(ctx, fmt.Sprintf("/campaigns/%d/adgroups", campaignID), req)
 	if err != nil {
 		return nil, err
 	}
@@ -28,12 +29,12 @@ func (s *AdGroupService) Create(campaignID int64, req *types.AdGroupCreate) (*ty
 	return resp.Data, nil
 }
 
-func (s *AdGroupService) Get(campaignID, adGroupID int64, fields string) (*types.AdGroup, error) {
+func (s *AdGroupService) Get(ctx context.Context, campaignID, adGroupID int64, fields string) (*types.AdGroup, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d", campaignID, adGroupID)
 	if fields != "" {
 		path += "?fields=" + url.QueryEscape(fields)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +45,7 @@ func (s *AdGroupService) Get(campaignID, adGroupID int64, fields string) (*types
 	return resp.Data, nil
 }
 
-func (s *AdGroupService) List(campaignID int64, limit, offset int, fields string) ([]types.AdGroup, *types.PageDetail, error) {
+func (s *AdGroupService) List(ctx context.Context, campaignID int64, limit, offset int, fields string) ([]types.AdGroup, *types.PageDetail, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups", campaignID)
 	sep := "?"
 	if fields != "" {
@@ -58,7 +59,7 @@ func (s *AdGroupService) List(campaignID int64, limit, offset int, fields string
 	if offset > 0 {
 		path += fmt.Sprintf("%soffset=%d", sep, offset)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -69,8 +70,8 @@ func (s *AdGroupService) List(campaignID int64, limit, offset int, fields string
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *AdGroupService) Find(campaignID int64, selector *types.Selector) ([]types.AdGroup, *types.PageDetail, error) {
-	body, err := s.client.Post(fmt.Sprintf("/campaigns/%d/adgroups/find", campaignID), selector)
+func (s *AdGroupService) Find(ctx context.Context, campaignID int64, selector *types.Selector) ([]types.AdGroup, *types.PageDetail, error) {
+	body, err := s.client.Post(ctx, fmt.Sprintf("/campaigns/%d/adgroups/find", campaignID), selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -81,8 +82,8 @@ func (s *AdGroupService) Find(campaignID int64, selector *types.Selector) ([]typ
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *AdGroupService) FindAll(selector *types.Selector) ([]types.AdGroup, *types.PageDetail, error) {
-	body, err := s.client.Post("/adgroups/find", selector)
+func (s *AdGroupService) FindAll(ctx context.Context, selector *types.Selector) ([]types.AdGroup, *types.PageDetail, error) {
+	body, err := s.client.Post(ctx, "/adgroups/find", selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -93,8 +94,8 @@ func (s *AdGroupService) FindAll(selector *types.Selector) ([]types.AdGroup, *ty
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *AdGroupService) Update(campaignID, adGroupID int64, req *types.AdGroupUpdate) (*types.AdGroup, error) {
-	body, err := s.client.Put(fmt.Sprintf("/campaigns/%d/adgroups/%d", campaignID, adGroupID), req)
+func (s *AdGroupService) Update(ctx context.Context, campaignID, adGroupID int64, req *types.AdGroupUpdate) (*types.AdGroup, error) {
+	body, err := s.client.Put(ctx, fmt.Sprintf("/campaigns/%d/adgroups/%d", campaignID, adGroupID), req)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +106,7 @@ func (s *AdGroupService) Update(campaignID, adGroupID int64, req *types.AdGroupU
 	return resp.Data, nil
 }
 
-func (s *AdGroupService) Delete(campaignID, adGroupID int64) error {
-	_, err := s.client.Delete(fmt.Sprintf("/campaigns/%d/adgroups/%d", campaignID, adGroupID))
+func (s *AdGroupService) Delete(ctx context.Context, campaignID, adGroupID int64) error {
+	_, err := s.client.Delete(ctx, fmt.Sprintf("/campaigns/%d/adgroups/%d", campaignID, adGroupID))
 	return err
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,9 +16,9 @@ func (c *Client) Ads() *AdService {
 	return &AdService{client: c}
 }
 
-func (s *AdService) Create(campaignID, adGroupID int64, req *types.AdCreate) (*types.Ad, error) {
+func (s *AdService) Create(ctx context.Context, campaignID, adGroupID int64, req *types.AdCreate) (*types.Ad, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/ads", campaignID, adGroupID)
-	body, err := s.client.Post(path, req)
+	body, err := s.client.Post(ctx, path, req)
 	if err != nil {
 		return nil, err
 	}
@@ -28,9 +29,9 @@ func (s *AdService) Create(campaignID, adGroupID int64, req *types.AdCreate) (*t
 	return resp.Data, nil
 }
 
-func (s *AdService) Get(campaignID, adGroupID, adID int64) (*types.Ad, error) {
+func (s *AdService) Get(ctx context.Context, campaignID, adGroupID, adID int64) (*types.Ad, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/ads/%d", campaignID, adGroupID, adID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +42,7 @@ func (s *AdService) Get(campaignID, adGroupID, adID int64) (*types.Ad, error) {
 	return resp.Data, nil
 }
 
-func (s *AdService) List(campaignID, adGroupID int64, limit, offset int) ([]types.Ad, *types.PageDetail, error) {
+func (s *AdService) List(ctx context.Context, campaignID, adGroupID int64, limit, offset int) ([]types.Ad, *types.PageDetail, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/ads", campaignID, adGroupID)
 	sep := "?"
 	if limit > 0 {
@@ -51,7 +52,7 @@ func (s *AdService) List(campaignID, adGroupID int64, limit, offset int) ([]type
 	if offset > 0 {
 		path += fmt.Sprintf("%soffset=%d", sep, offset)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -62,9 +63,9 @@ func (s *AdService) List(campaignID, adGroupID int64, limit, offset int) ([]type
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *AdService) Find(campaignID, adGroupID int64, selector *types.Selector) ([]types.Ad, *types.PageDetail, error) {
+func (s *AdService) Find(ctx context.Context, campaignID, adGroupID int64, selector *types.Selector) ([]types.Ad, *types.PageDetail, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/ads/find", campaignID, adGroupID)
-	body, err := s.client.Post(path, selector)
+	body, err := s.client.Post(ctx, path, selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -75,8 +76,8 @@ func (s *AdService) Find(campaignID, adGroupID int64, selector *types.Selector)
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *AdService) FindAll(selector *types.Selector) ([]types.Ad, *types.PageDetail, error) {
-	body, err := s.client.Post("/ads/find", selector)
+func (s *AdService) FindAll(ctx context.Context, selector *types.Selector) ([]types.Ad, *types.PageDetail, error) {
+	body, err := s.client.Post(ctx, "/ads/find", selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -87,9 +88,9 @@ func (s *AdService) FindAll(selector *types.Selector) ([]types.Ad, *types.PageDe
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *AdService) Update(campaignID, adGroupID, adID int64, req *types.AdUpdate) (*types.Ad, error) {
+func (s *AdService) Update(ctx context.Context, campaignID, adGroupID, adID int64, req *types.AdUpdate) (*types.Ad, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/ads/%d", campaignID, adGroupID, adID)
-	body, err := s.client.Put(path, req)
+	body, err := s.client.Put(ctx, path, req)
 	if err != nil {
 		return nil, err
 	}
@@ -100,8 +101,8 @@ func (s *AdService) Update(campaignID, adGroupID, adID int64, req *types.AdUpdat
 	return resp.Data, nil
 }
 
-func (s *AdService) Delete(campaignID, adGroupID, adID int64) error {
+func (s *AdService) Delete(ctx context.Context, campaignID, adGroupID, adID int64) error {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/ads/%d", campaignID, adGroupID, adID)
-	_, err := s.client.Delete(path)
+	_, err := s.client.Delete(ctx, path)
 	return err
 }
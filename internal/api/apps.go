@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -16,7 +17,7 @@ func (c *Client) Apps() *AppService {
 	return &AppService{client: c}
 }
 
-func (s *AppService) Search(query string, returnOwnedApps bool, limit, offset int) ([]types.AppInfo, *types.PageDetail, error) {
+func (s *AppService) Search(ctx context.Context, query string, returnOwnedApps bool, limit, offset int) ([]types.AppInfo, *types.PageDetail, error) {
 	path := "/search/apps?query=" + url.QueryEscape(query)
 	if returnOwnedApps {
 		path += "&returnOwnedApps=true"
@@ -27,7 +28,7 @@ func (s *AppService) Search(query string, returnOwnedApps bool, limit, offset in
 	if offset > 0 {
 		path += fmt.Sprintf("&offset=%d", offset)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -38,8 +39,8 @@ func (s *AppService) Search(query string, returnOwnedApps bool, limit, offset in
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *AppService) Eligibility(selector *types.Selector) ([]types.EligibilityRecord, error) {
-	body, err := s.client.Post("/app-eligibility/find", selector)
+func (s *AppService) Eligibility(ctx context.Context, selector *types.Selector) ([]types.EligibilityRecord, error) {
+	body, err := s.client.Post(ctx, "/app-eligibility/find", selector)
 	if err != nil {
 		return nil, err
 	}
@@ -50,9 +51,9 @@ func (s *AppService) Eligibility(selector *types.Selector) ([]types.EligibilityR
 	return resp.Data, nil
 }
 
-func (s *AppService) Details(adamID int64) (*types.AppDetail, error) {
+func (s *AppService) Details(ctx context.Context, adamID int64) (*types.AppDetail, error) {
 	path := fmt.Sprintf("/apps/%d", adamID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -63,9 +64,9 @@ func (s *AppService) Details(adamID int64) (*types.AppDetail, error) {
 	return resp.Data, nil
 }
 
-func (s *AppService) LocalizedDetails(adamID int64) (*types.LocalizedAppDetail, error) {
+func (s *AppService) LocalizedDetails(ctx context.Context, adamID int64) (*types.LocalizedAppDetail, error) {
 	path := fmt.Sprintf("/apps/%d/localized-details", adamID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
 	"encoding/json"
@@ -19,9 +20,10 @@ import (
 )
 
 const (
-	tokenURL    = "https://appleid.apple.com/auth/oauth2/token"
-	tokenScope  = "searchadsorg"
-	jwtLifetime = 180 * 24 * time.Hour
+	// DefaultTokenURL is Apple's OAuth2 token endpoint.
+	DefaultTokenURL = "https://appleid.apple.com/auth/oauth2/token"
+	tokenScope      = "searchadsorg"
+	jwtLifetime     = 180 * 24 * time.Hour
 	// Refresh token 60 seconds before expiry
 	tokenRefreshBuffer = 60 * time.Second
 )
@@ -30,6 +32,11 @@ const (
 type TokenSource struct {
 	cfg        *config.Config
 	privateKey *ecdsa.PrivateKey
+	verbose    bool
+
+	// cachePath is the on-disk token cache shared with other processes.
+	// Empty disables it.
+	cachePath string
 
 	mu          sync.Mutex
 	accessToken string
@@ -62,25 +69,82 @@ func NewTokenSource(cfg *config.Config) (*TokenSource, error) {
 		return nil, fmt.Errorf("private key is not ECDSA")
 	}
 
-	return &TokenSource{
+	ts := &TokenSource{
 		cfg:        cfg,
 		privateKey: ecKey,
-	}, nil
+	}
+	if tokenCacheEnabled() {
+		if path, err := tokenCachePath(ts.cacheKey()); err == nil {
+			ts.cachePath = path
+		}
+	}
+	return ts, nil
 }
 
 // Token returns a valid access token, refreshing if necessary.
-func (ts *TokenSource) Token() (string, error) {
+// Tokens are shared with other aads processes through the on-disk cache, so
+// scripts that run the CLI many times only hit the token endpoint once per
+// token lifetime.
+func (ts *TokenSource) Token(ctx context.Context) (string, error) {
 	ts.mu.Lock()
 	defer ts.mu.Unlock()
 
-	if ts.accessToken != "" && time.Now().Before(ts.expiresAt.Add(-tokenRefreshBuffer)) {
+	if ts.valid(ts.accessToken, ts.expiresAt) {
 		return ts.accessToken, nil
 	}
 
-	return ts.refresh()
+	if ts.cachePath == "" {
+		return ts.refresh(ctx)
+	}
+
+	// Hold the lock across the refresh so concurrent processes wait for one
+	// token exchange and then reuse its result.
+	unlock, err := lockTokenCache(ts.cachePath)
+	if err != nil {
+		// The cache is only an optimization.
+		return ts.refresh(ctx)
+	}
+	defer unlock()
+
+	if c, err := readTokenCache(ts.cachePath, ts.cacheKey()); err == nil && ts.valid(c.AccessToken, c.ExpiresAt) {
+		ts.accessToken = c.AccessToken
+		ts.expiresAt = c.ExpiresAt
+		return ts.accessToken, nil
+	}
+
+	token, err := ts.refresh(ctx)
+	if err != nil {
+		return "", err
+	}
+	_ = writeTokenCache(ts.cachePath, &cachedToken{
+		ClientID:    ts.cacheKey(),
+		AccessToken: ts.accessToken,
+		ExpiresAt:   ts.expiresAt,
+	})
+	return token, nil
+}
+
+// cacheKey identifies the client a cached token belongs to. Tokens from an
+// overridden token endpoint are kept apart from Apple's.
+func (ts *TokenSource) cacheKey() string {
+	if ts.cfg.TokenURL == "" {
+		return ts.cfg.ClientID
+	}
+	return ts.cfg.ClientID + " " + ts.cfg.TokenURL
 }
 
-func (ts *TokenSource) refresh() (string, error) {
+func (ts *TokenSource) tokenURL() string {
+	if ts.cfg.TokenURL == "" {
+		return DefaultTokenURL
+	}
+	return ts.cfg.TokenURL
+}
+
+func (ts *TokenSource) valid(token string, expiresAt time.Time) bool {
+	return token != "" && time.Now().Before(expiresAt.Add(-tokenRefreshBuffer))
+}
+
+func (ts *TokenSource) refresh(ctx context.Context) (string, error) {
 	clientSecret, err := ts.buildJWT()
 	if err != nil {
 		return "", fmt.Errorf("build JWT: %w", err)
@@ -93,8 +157,18 @@ func (ts *TokenSource) refresh() (string, error) {
 		"scope":         {tokenScope},
 	}
 
-	httpClient := &http.Client{Timeout: 30 * time.Second}
-	resp, err := httpClient.Post(tokenURL, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.tokenURL(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if ts.verbose {
+		fmt.Printf("POST %s (token exchange)\n", ts.tokenURL())
+	}
+
+	httpClient := &http.Client{Timeout: attemptTimeout}
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("token request: %w", err)
 	}
@@ -142,11 +216,31 @@ func (ts *TokenSource) refresh() (string, error) {
 }
 
 // Invalidate clears the cached access token so the next Token() call refreshes.
+// The on-disk cache is cleared too, unless another process already replaced
+// the rejected token with a new one.
 func (ts *TokenSource) Invalidate() {
 	ts.mu.Lock()
 	defer ts.mu.Unlock()
+	rejected := ts.accessToken
+	if ts.cfg == nil {
+		// Static token (cassette replay); there is nothing to refresh from.
+		return
+	}
 	ts.accessToken = ""
 	ts.expiresAt = time.Time{}
+
+	if ts.cachePath == "" {
+		return
+	}
+	unlock, err := lockTokenCache(ts.cachePath)
+	if err != nil {
+		return
+	}
+	defer unlock()
+	if c, err := readTokenCache(ts.cachePath, ts.cacheKey()); err == nil && rejected != "" && c.AccessToken != rejected {
+		return
+	}
+	_ = os.Remove(ts.cachePath)
 }
 
 func (ts *TokenSource) buildJWT() (string, error) {
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,8 +16,8 @@ func (c *Client) BudgetOrders() *BudgetOrderService {
 	return &BudgetOrderService{client: c}
 }
 
-func (s *BudgetOrderService) Create(req *types.BudgetOrderCreate) (*types.BudgetOrder, error) {
-	body, err := s.client.Post("/budgetorders", req)
+func (s *BudgetOrderService) Create(ctx context.Context, req *types.BudgetOrderCreate) (*types.BudgetOrder, error) {
+	body, err := s.client.Post(ctx, "/budgetorders", req)
 	if err != nil {
 		return nil, err
 	}
@@ -27,9 +28,9 @@ func (s *BudgetOrderService) Create(req *types.BudgetOrderCreate) (*types.Budget
 	return resp.Data, nil
 }
 
-func (s *BudgetOrderService) Get(orderID int64) (*types.BudgetOrder, error) {
+func (s *BudgetOrderService) Get(ctx context.Context, orderID int64) (*types.BudgetOrder, error) {
 	path := fmt.Sprintf("/budgetorders/%d", orderID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +41,7 @@ func (s *BudgetOrderService) Get(orderID int64) (*types.BudgetOrder, error) {
 	return resp.Data, nil
 }
 
-func (s *BudgetOrderService) List(limit, offset int) ([]types.BudgetOrder, *types.PageDetail, error) {
+func (s *BudgetOrderService) List(ctx context.Context, limit, offset int) ([]types.BudgetOrder, *types.PageDetail, error) {
 	path := "/budgetorders"
 	sep := "?"
 	if limit > 0 {
@@ -50,7 +51,7 @@ func (s *BudgetOrderService) List(limit, offset int) ([]types.BudgetOrder, *type
 	if offset > 0 {
 		path += fmt.Sprintf("%soffset=%d", sep, offset)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -61,9 +62,9 @@ func (s *BudgetOrderService) List(limit, offset int) ([]types.BudgetOrder, *type
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *BudgetOrderService) Update(orderID int64, req *types.BudgetOrderUpdate) (*types.BudgetOrder, error) {
+func (s *BudgetOrderService) Update(ctx context.Context, orderID int64, req *types.BudgetOrderUpdate) (*types.BudgetOrder, error) {
 	path := fmt.Sprintf("/budgetorders/%d", orderID)
-	body, err := s.client.Put(path, req)
+	body, err := s.client.Put(ctx, path, req)
 	if err != nil {
 		return nil, err
 	}
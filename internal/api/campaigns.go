@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -19,8 +20,8 @@ func (c *Client) Campaigns() *CampaignService {
 }
 
 // Create creates a new campaign.
-func (s *CampaignService) Create(req *types.CampaignCreate) (*types.Campaign, error) {
-	body, err := s.client.Post("/campaigns", req)
+func (s *CampaignService) Create(ctx context.Context, req *types.CampaignCreate) (*types.Campaign, error) {
+	body, err := s.client.Post(ctx, "/campaigns", req)
 	if err != nil {
 		return nil, err
 	}
@@ -33,13 +34,13 @@ func (s *CampaignService) Create(req *types.CampaignCreate) (*types.Campaign, er
 }
 
 // Get retrieves a single campaign by ID.
-func (s *CampaignService) Get(campaignID int64, fields string) (*types.Campaign, error) {
+func (s *CampaignService) Get(ctx context.Context, campaignID int64, fields string) (*types.Campaign, error) {
 	path := fmt.Sprintf("/campaigns/%d", campaignID)
 	if fields != "" {
 		path += "?fields=" + url.QueryEscape(fields)
 	}
 
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +53,7 @@ func (s *CampaignService) Get(campaignID int64, fields string) (*types.Campaign,
 }
 
 // List retrieves all campaigns.
-func (s *CampaignService) List(limit, offset int, fields string) ([]types.Campaign, *types.PageDetail, error) {
+func (s *CampaignService) List(ctx context.Context, limit, offset int, fields string) ([]types.Campaign, *types.PageDetail, error) {
 	path := "/campaigns"
 	sep := "?"
 	if fields != "" {
@@ -67,7 +68,7 @@ func (s *CampaignService) List(limit, offset int, fields string) ([]types.Campai
 		path += fmt.Sprintf("%soffset=%d", sep, offset)
 	}
 
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -80,8 +81,8 @@ func (s *CampaignService) List(limit, offset int, fields string) ([]types.Campai
 }
 
 // Find searches campaigns using a selector.
-func (s *CampaignService) Find(selector *types.Selector) ([]types.Campaign, *types.PageDetail, error) {
-	body, err := s.client.Post("/campaigns/find", selector)
+func (s *CampaignService) Find(ctx context.Context, selector *types.Selector) ([]types.Campaign, *types.PageDetail, error) {
+	body, err := s.client.Post(ctx, "/campaigns/find", selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -94,9 +95,9 @@ func (s *CampaignService) Find(selector *types.Selector) ([]types.Campaign, *typ
 }
 
 // Update updates a campaign. Uses the {"campaign":{...}} envelope.
-func (s *CampaignService) Update(campaignID int64, req *types.CampaignUpdate) (*types.Campaign, error) {
+func (s *CampaignService) Update(ctx context.Context, campaignID int64, req *types.CampaignUpdate) (*types.Campaign, error) {
 	envelope := &types.UpdateCampaignRequest{Campaign: req}
-	body, err := s.client.Put(fmt.Sprintf("/campaigns/%d", campaignID), envelope)
+	body, err := s.client.Put(ctx, fmt.Sprintf("/campaigns/%d", campaignID), envelope)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +110,7 @@ func (s *CampaignService) Update(campaignID int64, req *types.CampaignUpdate) (*
 }
 
 // Delete deletes a campaign.
-func (s *CampaignService) Delete(campaignID int64) error {
-	_, err := s.client.Delete(fmt.Sprintf("/campaigns/%d", campaignID))
+func (s *CampaignService) Delete(ctx context.Context, campaignID int64) error {
+	_, err := s.client.Delete(ctx, fmt.Sprintf("/campaigns/%d", campaignID))
 	return err
 }
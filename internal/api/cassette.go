@@ -0,0 +1,230 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Cassette mode records API traffic to a directory (AADS_RECORD=dir) or
+// serves it back from one (AADS_REPLAY=dir) without network or credentials.
+// Each interaction is one JSON file named after a hash of the request
+// (method, path+query, body) and its occurrence number, so replay is
+// deterministic even when requests run concurrently.
+const (
+	envRecord = "AADS_RECORD"
+	envReplay = "AADS_REPLAY"
+)
+
+// errCassetteMiss means replay has no recording for a request. Retrying
+// can't help, so the client fails immediately.
+var errCassetteMiss = errors.New("cassette: no recorded response")
+
+// redactedHeaders are never written to a cassette.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// interaction is the on-disk form of one request/response pair.
+type interaction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method   string              `json:"method"`
+	URL      string              `json:"url"`
+	Headers  map[string][]string `json:"headers,omitempty"`
+	Body     json.RawMessage     `json:"body,omitempty"`
+	BodyText string              `json:"bodyText,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int                 `json:"statusCode"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       json.RawMessage     `json:"body,omitempty"`
+	BodyText   string              `json:"bodyText,omitempty"`
+}
+
+// ReplayDir returns the AADS_REPLAY directory, or "" when replay is off.
+// Commands use it to skip credential checks.
+func ReplayDir() string {
+	return os.Getenv(envReplay)
+}
+
+// cassetteTransport wraps next according to AADS_RECORD / AADS_REPLAY.
+func cassetteTransport(next http.RoundTripper) (http.RoundTripper, error) {
+	record, replay := os.Getenv(envRecord), os.Getenv(envReplay)
+	switch {
+	case record != "" && replay != "":
+		return nil, fmt.Errorf("%s and %s are mutually exclusive", envRecord, envReplay)
+	case record != "":
+		if err := os.MkdirAll(record, 0700); err != nil {
+			return nil, fmt.Errorf("create cassette dir: %w", err)
+		}
+		return &recorder{dir: record, next: next, seen: map[string]int{}}, nil
+	case replay != "":
+		if fi, err := os.Stat(replay); err != nil || !fi.IsDir() {
+			return nil, fmt.Errorf("%s: %s is not a directory", envReplay, replay)
+		}
+		return &replayer{dir: replay, seen: map[string]int{}}, nil
+	default:
+		return next, nil
+	}
+}
+
+// interactionKey identifies a request independent of host, headers and
+// credentials.
+func interactionKey(method, pathAndQuery string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", method, pathAndQuery)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func interactionFile(dir, key string, n int) string {
+	return filepath.Join(dir, fmt.Sprintf("%s-%03d.json", key, n))
+}
+
+// nextOccurrence returns how many times key has been seen before.
+func nextOccurrence(mu *sync.Mutex, seen map[string]int, key string) int {
+	mu.Lock()
+	defer mu.Unlock()
+	n := seen[key]
+	seen[key] = n + 1
+	return n
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+type recorder struct {
+	dir  string
+	next http.RoundTripper
+	mu   sync.Mutex
+	seen map[string]int
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	it := interaction{
+		Request:  cassetteRequest{Method: req.Method, URL: req.URL.RequestURI(), Headers: redact(req.Header)},
+		Response: cassetteResponse{StatusCode: resp.StatusCode, Headers: redact(resp.Header)},
+	}
+	it.Request.Body, it.Request.BodyText = encodeBody(reqBody)
+	it.Response.Body, it.Response.BodyText = encodeBody(respBody)
+
+	key := interactionKey(req.Method, req.URL.RequestURI(), reqBody)
+	n := nextOccurrence(&r.mu, r.seen, key)
+	data, err := json.MarshalIndent(it, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode cassette: %w", err)
+	}
+	if err := os.WriteFile(interactionFile(r.dir, key, n), append(data, '\n'), 0600); err != nil {
+		return nil, fmt.Errorf("write cassette: %w", err)
+	}
+	return resp, nil
+}
+
+type replayer struct {
+	dir  string
+	mu   sync.Mutex
+	seen map[string]int
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := interactionKey(req.Method, req.URL.RequestURI(), reqBody)
+	n := nextOccurrence(&r.mu, r.seen, key)
+
+	data, err := os.ReadFile(interactionFile(r.dir, key, n))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w for %s %s (occurrence %d) in %s", errCassetteMiss, req.Method, req.URL.RequestURI(), n+1, r.dir)
+		}
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+	var it interaction
+	if err := json.Unmarshal(data, &it); err != nil {
+		return nil, fmt.Errorf("parse cassette %s: %w", interactionFile(r.dir, key, n), err)
+	}
+
+	body := []byte(it.Response.BodyText)
+	if len(it.Response.Body) > 0 {
+		body = it.Response.Body
+	}
+	return &http.Response{
+		StatusCode: it.Response.StatusCode,
+		Status:     fmt.Sprintf("%d %s", it.Response.StatusCode, http.StatusText(it.Response.StatusCode)),
+		Header:     http.Header(it.Response.Headers),
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// encodeBody stores JSON bodies inline so cassettes stay readable and
+// editable, and anything else as text.
+func encodeBody(b []byte) (json.RawMessage, string) {
+	if len(b) == 0 {
+		return nil, ""
+	}
+	if json.Valid(b) {
+		var buf bytes.Buffer
+		if json.Compact(&buf, b) == nil {
+			return buf.Bytes(), ""
+		}
+	}
+	return nil, string(b)
+}
+
+func redact(h http.Header) map[string][]string {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(h))
+	for k, v := range h {
+		for _, r := range redactedHeaders {
+			if strings.EqualFold(k, r) {
+				v = []string{"REDACTED"}
+				break
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCassetteRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv(envRecord, dir)
+	upstream := 0
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		upstream++
+		if req.Header.Get("Authorization") == "" {
+			t.Fatal("recorder dropped the auth header from the live request")
+		}
+		return okJSON(`{"data":[{"id":1,"name":"Brand"}],"pagination":{"totalResults":1}}`), nil
+	})
+	rec, err := cassetteTransport(next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newTestClient(t, "123", nil)
+	c.httpClient = &http.Client{Transport: rec, Timeout: 5 * time.Second}
+
+	if _, _, err := c.Campaigns().List(context.Background(), 10, 0, ""); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if upstream != 1 {
+		t.Fatalf("upstream calls = %d", upstream)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("cassette files = %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "test-token") || !strings.Contains(string(data), "REDACTED") {
+		t.Fatalf("auth header not redacted:\n%s", data)
+	}
+
+	t.Setenv(envRecord, "")
+	t.Setenv(envReplay, dir)
+	rep, err := cassetteTransport(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.httpClient = &http.Client{Transport: rep, Timeout: 5 * time.Second}
+
+	got, _, err := c.Campaigns().List(context.Background(), 10, 0, "")
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "Brand" {
+		t.Fatalf("replayed campaigns = %+v", got)
+	}
+
+	// A second identical request has no recording of its own.
+	if _, _, err := c.Campaigns().List(context.Background(), 10, 0, ""); err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Fatalf("expected replay miss, got %v", err)
+	}
+}
@@ -2,10 +2,13 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -15,9 +18,14 @@ import (
 )
 
 const (
-	baseURL       = "https://api.searchads.apple.com/api/v5"
-	maxRetries    = 4
-	baseRetryWait = 2 * time.Second
+	// DefaultBaseURL is the production Apple Ads API.
+	DefaultBaseURL = "https://api.searchads.apple.com/api/v5"
+	maxRetries     = 4
+	baseRetryWait  = 2 * time.Second
+
+	// attemptTimeout bounds a single HTTP attempt. The overall deadline comes
+	// from the caller's context (e.g. --timeout).
+	attemptTimeout = 30 * time.Second
 )
 
 // Client is the Apple Ads API HTTP client.
@@ -25,26 +33,62 @@ type Client struct {
 	httpClient *http.Client
 	tokenSrc   *TokenSource
 	orgID      string
+	baseURL    string
 	verbose    bool
+	limiter    *rateLimiter
+
+	reportCache *ReportCache
 }
 
 // NewClient creates a new API client from config.
 func NewClient(cfg *config.Config) (*Client, error) {
-	ts, err := NewTokenSource(cfg)
+	transport, err := cassetteTransport(http.DefaultTransport)
 	if err != nil {
-		return nil, fmt.Errorf("init auth: %w", err)
+		return nil, err
+	}
+
+	var ts *TokenSource
+	if ReplayDir() != "" {
+		// Replayed responses don't depend on the token, so no credentials are needed.
+		ts = &TokenSource{accessToken: "replay", expiresAt: time.Now().Add(24 * time.Hour)}
+	} else {
+		ts, err = NewTokenSource(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("init auth: %w", err)
+		}
 	}
 
-	return &Client{
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+	c := &Client{
+		httpClient: &http.Client{Timeout: attemptTimeout, Transport: transport},
 		tokenSrc:   ts,
 		orgID:      cfg.OrgID,
-	}, nil
+	}
+	c.SetBaseURL(cfg.APIBaseURL)
+	return c, nil
 }
 
 // SetVerbose enables verbose logging.
 func (c *Client) SetVerbose(v bool) {
 	c.verbose = v
+	if c.tokenSrc != nil {
+		c.tokenSrc.verbose = v
+	}
+}
+
+// SetRateLimit caps the client at rps requests per second, retries
+// included. A value <= 0 disables the limit.
+func (c *Client) SetRateLimit(rps float64) {
+	if rps <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = newRateLimiter(rps)
+}
+
+// SetBaseURL points the client at another API root, such as a proxy or the
+// mock server. An empty value restores DefaultBaseURL.
+func (c *Client) SetBaseURL(u string) {
+	c.baseURL = strings.TrimRight(u, "/")
 }
 
 // SetOrgID overrides the org ID from config.
@@ -53,31 +97,31 @@ func (c *Client) SetOrgID(id string) {
 }
 
 // Get performs a GET request.
-func (c *Client) Get(path string) ([]byte, error) {
-	return c.do("GET", path, nil)
+func (c *Client) Get(ctx context.Context, path string) ([]byte, error) {
+	return c.do(ctx, "GET", path, nil)
 }
 
 // Post performs a POST request with a JSON body.
-func (c *Client) Post(path string, body any) ([]byte, error) {
-	return c.doJSON("POST", path, body)
+func (c *Client) Post(ctx context.Context, path string, body any) ([]byte, error) {
+	return c.doJSON(ctx, "POST", path, body)
 }
 
 // Put performs a PUT request with a JSON body.
-func (c *Client) Put(path string, body any) ([]byte, error) {
-	return c.doJSON("PUT", path, body)
+func (c *Client) Put(ctx context.Context, path string, body any) ([]byte, error) {
+	return c.doJSON(ctx, "PUT", path, body)
 }
 
 // Delete performs a DELETE request.
-func (c *Client) Delete(path string) ([]byte, error) {
-	return c.do("DELETE", path, nil)
+func (c *Client) Delete(ctx context.Context, path string) ([]byte, error) {
+	return c.do(ctx, "DELETE", path, nil)
 }
 
 // DeleteWithBody performs a POST-as-DELETE (for bulk delete endpoints).
-func (c *Client) DeleteWithBody(path string, body any) ([]byte, error) {
-	return c.doJSON("POST", path, body)
+func (c *Client) DeleteWithBody(ctx context.Context, path string, body any) ([]byte, error) {
+	return c.doJSON(ctx, "POST", path, body)
 }
 
-func (c *Client) doJSON(method, path string, body any) ([]byte, error) {
+func (c *Client) doJSON(ctx context.Context, method, path string, body any) ([]byte, error) {
 	var buf *bytes.Buffer
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -94,14 +138,14 @@ func (c *Client) doJSON(method, path string, body any) ([]byte, error) {
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	return c.doWithRetry(method, path, bodyReader, bodyBytes)
+	return c.doWithRetry(ctx, method, path, bodyReader, bodyBytes)
 }
 
-func (c *Client) do(method, path string, body io.Reader) ([]byte, error) {
-	return c.doWithRetry(method, path, body, nil)
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	return c.doWithRetry(ctx, method, path, body, nil)
 }
 
-func (c *Client) doWithRetry(method, path string, body io.Reader, bodyBytes []byte) ([]byte, error) {
+func (c *Client) doWithRetry(ctx context.Context, method, path string, body io.Reader, bodyBytes []byte) ([]byte, error) {
 	var lastErr error
 	var wait time.Duration
 	didAuthRefresh := false
@@ -111,8 +155,8 @@ func (c *Client) doWithRetry(method, path string, body io.Reader, bodyBytes []by
 			if c.verbose {
 				fmt.Printf("Retrying in %v (attempt %d/%d)...\n", wait, attempt, maxRetries)
 			}
-			if wait > 0 {
-				time.Sleep(wait)
+			if err := SleepContext(ctx, wait); err != nil {
+				return nil, err
 			}
 
 			// Reset body for retry
@@ -121,11 +165,20 @@ func (c *Client) doWithRetry(method, path string, body io.Reader, bodyBytes []by
 			}
 		}
 
-		result, retry, retryAfter, err := c.doOnce(method, path, body)
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		result, retry, retryAfter, err := c.doOnce(ctx, method, path, body)
 		if err == nil {
 			return result, nil
 		}
 		lastErr = err
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 
 		// Some auth failures can be resolved by forcing a token refresh, but don't loop forever.
 		if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusUnauthorized && !didAuthRefresh {
@@ -150,6 +203,21 @@ func (c *Client) doWithRetry(method, path string, body io.Reader, bodyBytes []by
 	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
+// SleepContext waits for d or until ctx is done, whichever comes first.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
 func retryWait(attempt int, retryAfter time.Duration) time.Duration {
 	// attempt is 0-based (0 is the first request). After each failed attempt,
 	// compute how long to wait before the next attempt.
@@ -207,23 +275,35 @@ func isSafeToRetry(method, path string) bool {
 	}
 }
 
-func (c *Client) doOnce(method, path string, body io.Reader) ([]byte, bool, time.Duration, error) {
-	url := baseURL + path
-
-	token, err := c.tokenSrc.Token()
-	if err != nil {
-		return nil, false, 0, fmt.Errorf("get token: %w", err)
+func (c *Client) doOnce(ctx context.Context, method, path string, body io.Reader) ([]byte, bool, time.Duration, error) {
+	base := c.baseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	url := base + path
+	// Absolute URLs (such as report downloads) are fetched as given. Other
+	// hosts don't get the API credentials.
+	apiHost := true
+	if strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://") {
+		url = path
+		apiHost = sameHost(path, base)
 	}
 
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, false, 0, fmt.Errorf("create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/json")
-	if c.orgID != "" {
-		req.Header.Set("X-AP-Context", "orgId="+c.orgID)
+	if apiHost {
+		token, err := c.tokenSrc.Token(ctx)
+		if err != nil {
+			return nil, false, 0, fmt.Errorf("get token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept", "application/json")
+		if c.orgID != "" {
+			req.Header.Set("X-AP-Context", "orgId="+c.orgID)
+		}
 	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
@@ -235,6 +315,9 @@ func (c *Client) doOnce(method, path string, body io.Reader) ([]byte, bool, time
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		if errors.Is(err, errCassetteMiss) {
+			return nil, false, 0, err
+		}
 		return nil, isSafeToRetry(method, path), 0, fmt.Errorf("http request: %w", err)
 	}
 	defer resp.Body.Close()
@@ -250,9 +333,16 @@ func (c *Client) doOnce(method, path string, body io.Reader) ([]byte, bool, time
 
 	if resp.StatusCode >= 400 {
 		apiErr := &APIError{StatusCode: resp.StatusCode, RawBody: string(respBody)}
-		var errResp types.APIError
+		var errResp struct {
+			types.APIError
+			// Apple nests the list as {"error":{"errors":[...]}}.
+			Error *types.APIError `json:"error"`
+		}
 		if json.Unmarshal(respBody, &errResp) == nil {
 			apiErr.Errors = errResp.Errors
+			if len(apiErr.Errors) == 0 && errResp.Error != nil {
+				apiErr.Errors = errResp.Error.Errors
+			}
 		}
 
 		// Always retry on 429. Respect Retry-After when present.
@@ -271,3 +361,9 @@ func (c *Client) doOnce(method, path string, body io.Reader) ([]byte, bool, time
 
 	return respBody, false, 0, nil
 }
+
+func sameHost(a, b string) bool {
+	ua, err1 := neturl.Parse(a)
+	ub, err2 := neturl.Parse(b)
+	return err1 == nil && err2 == nil && strings.EqualFold(ua.Host, ub.Host)
+}
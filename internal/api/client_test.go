@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/mockserver"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func TestRetryWaitStopsWhenContextIsCancelled(t *testing.T) {
+	calls := 0
+	c := newTestClient(t, "123", func(req *http.Request) (*http.Response, error) {
+		calls++
+		h := make(http.Header)
+		h.Set("Retry-After", "60")
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: h, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Get(ctx, "/campaigns")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("cancelled request took %v", elapsed)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+}
+
+func TestClientAgainstMockServer(t *testing.T) {
+	srv := httptest.NewServer(mockserver.New(mockserver.Options{OrgID: 42}))
+	defer srv.Close()
+
+	c := newTestClient(t, "42", nil)
+	c.httpClient = srv.Client()
+	c.SetBaseURL(srv.URL + mockserver.APIPrefix)
+
+	ctx := context.Background()
+	created, err := c.Campaigns().Create(ctx, &types.CampaignCreate{Name: "Brand", AdamID: 1, CountriesOrRegions: []string{"US"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	got, err := c.Campaigns().Get(ctx, created.ID, "")
+	if err != nil || got.Name != "Brand" {
+		t.Fatalf("get: %+v, %v", got, err)
+	}
+
+	// Errors arrive in Apple's {"error":{"errors":[...]}} envelope.
+	_, err = c.Campaigns().Create(ctx, &types.CampaignCreate{Name: "Brand", AdamID: 1, CountriesOrRegions: []string{"US"}})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Errors) != 1 || apiErr.Errors[0].Field != "name" {
+		t.Fatalf("duplicate create error = %v", err)
+	}
+}
+
+func TestAPIErrorDetails(t *testing.T) {
+	for name, body := range map[string]string{
+		"nested":    `{"data":null,"pagination":null,"error":{"errors":[{"messageCode":"INVALID_ATTRIBUTE_TYPE","message":"This is an invalid value for this attribute.","field":"name"}]}}`,
+		"top level": `{"errors":[{"messageCode":"INVALID_ATTRIBUTE_TYPE","message":"This is an invalid value for this attribute.","field":"name"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, "123", func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Header:     make(http.Header),
+					Body:       io.NopCloser(strings.NewReader(body)),
+				}, nil
+			})
+			_, err := c.Campaigns().Get(context.Background(), 1, "")
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || len(apiErr.Errors) != 1 {
+				t.Fatalf("error = %v", err)
+			}
+			if d := apiErr.Errors[0]; d.MessageCode != "INVALID_ATTRIBUTE_TYPE" || d.Field != "name" {
+				t.Fatalf("detail = %+v", d)
+			}
+			if want := "This is an invalid value for this attribute. (field: name)"; !strings.Contains(err.Error(), want) {
+				t.Fatalf("message %q does not contain %q", err.Error(), want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,8 +16,8 @@ func (c *Client) Creatives() *CreativeService {
 	return &CreativeService{client: c}
 }
 
-func (s *CreativeService) Create(req *types.CreativeCreate) (*types.Creative, error) {
-	body, err := s.client.Post("/creatives", req)
+func (s *CreativeService) Create(ctx context.Context, req *types.CreativeCreate) (*types.Creative, error) {
+	body, err := s.client.Post(ctx, "/creatives", req)
 	if err != nil {
 		return nil, err
 	}
@@ -27,8 +28,8 @@ func (s *CreativeService) Create(req *types.CreativeCreate) (*types.Creative, er
 	return resp.Data, nil
 }
 
-func (s *CreativeService) Get(creativeID int64) (*types.Creative, error) {
-	body, err := s.client.Get(fmt.Sprintf("/creatives/%d", creativeID))
+func (s *CreativeService) Get(ctx context.Context, creativeID int64) (*types.Creative, error) {
+	body, err := s.client.Get(ctx, fmt.Sprintf("/creatives/%d", creativeID))
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +40,7 @@ func (s *CreativeService) Get(creativeID int64) (*types.Creative, error) {
 	return resp.Data, nil
 }
 
-func (s *CreativeService) List(limit, offset int) ([]types.Creative, *types.PageDetail, error) {
+func (s *CreativeService) List(ctx context.Context, limit, offset int) ([]types.Creative, *types.PageDetail, error) {
 	path := "/creatives"
 	sep := "?"
 	if limit > 0 {
@@ -49,7 +50,7 @@ func (s *CreativeService) List(limit, offset int) ([]types.Creative, *types.Page
 	if offset > 0 {
 		path += fmt.Sprintf("%soffset=%d", sep, offset)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -60,8 +61,8 @@ func (s *CreativeService) List(limit, offset int) ([]types.Creative, *types.Page
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *CreativeService) Find(selector *types.Selector) ([]types.Creative, *types.PageDetail, error) {
-	body, err := s.client.Post("/creatives/find", selector)
+func (s *CreativeService) Find(ctx context.Context, selector *types.Selector) ([]types.Creative, *types.PageDetail, error) {
+	body, err := s.client.Post(ctx, "/creatives/find", selector)
 	if err != nil {
 		return nil, nil, err
 	}
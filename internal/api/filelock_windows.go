@@ -0,0 +1,33 @@
+//go:build windows
+
+package api
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// staleLockAge is how old a lock file can get before it's assumed to belong to
+// a crashed process.
+const staleLockAge = 2 * time.Minute
+
+// lockFile blocks until it creates path exclusively. Windows has no flock in
+// the standard library, so the lock is the file's existence.
+func lockFile(path string) (func(), error) {
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if fi, statErr := os.Stat(path); statErr == nil && time.Since(fi.ModTime()) > staleLockAge {
+			os.Remove(path)
+			continue
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
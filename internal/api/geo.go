@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -16,7 +17,7 @@ func (c *Client) Geo() *GeoService {
 	return &GeoService{client: c}
 }
 
-func (s *GeoService) Search(query, countryCode, entity string, limit int) ([]types.SearchEntity, error) {
+func (s *GeoService) Search(ctx context.Context, query, countryCode, entity string, limit int) ([]types.SearchEntity, error) {
 	path := "/search/geo?query=" + url.QueryEscape(query)
 	if countryCode != "" {
 		path += "&countrycode=" + url.QueryEscape(countryCode)
@@ -27,7 +28,7 @@ func (s *GeoService) Search(query, countryCode, entity string, limit int) ([]typ
 	if limit > 0 {
 		path += fmt.Sprintf("&limit=%d", limit)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -38,9 +39,9 @@ func (s *GeoService) Search(query, countryCode, entity string, limit int) ([]typ
 	return resp.Data, nil
 }
 
-func (s *GeoService) Get(geoID string) (json.RawMessage, error) {
+func (s *GeoService) Get(ctx context.Context, geoID string) (json.RawMessage, error) {
 	path := "/geodata?geoId=" + url.QueryEscape(geoID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -1,8 +1,15 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
 	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
 )
@@ -15,8 +22,8 @@ func (c *Client) ImpressionShare() *ImpressionShareService {
 	return &ImpressionShareService{client: c}
 }
 
-func (s *ImpressionShareService) Create(req *types.CustomReportRequest) (*types.CustomReportResponse, error) {
-	body, err := s.client.Post("/custom-reports", req)
+func (s *ImpressionShareService) Create(ctx context.Context, req *types.CustomReportRequest) (*types.CustomReportResponse, error) {
+	body, err := s.client.Post(ctx, "/custom-reports", req)
 	if err != nil {
 		return nil, err
 	}
@@ -27,9 +34,9 @@ func (s *ImpressionShareService) Create(req *types.CustomReportRequest) (*types.
 	return resp.Data, nil
 }
 
-func (s *ImpressionShareService) Get(reportID int64) (*types.CustomReportResponse, error) {
+func (s *ImpressionShareService) Get(ctx context.Context, reportID int64) (*types.CustomReportResponse, error) {
 	path := fmt.Sprintf("/custom-reports/%d", reportID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +47,7 @@ func (s *ImpressionShareService) Get(reportID int64) (*types.CustomReportRespons
 	return resp.Data, nil
 }
 
-func (s *ImpressionShareService) List(limit, offset int) ([]types.CustomReportResponse, *types.PageDetail, error) {
+func (s *ImpressionShareService) List(ctx context.Context, limit, offset int) ([]types.CustomReportResponse, *types.PageDetail, error) {
 	path := "/custom-reports"
 	sep := "?"
 	if limit > 0 {
@@ -50,7 +57,7 @@ func (s *ImpressionShareService) List(limit, offset int) ([]types.CustomReportRe
 	if offset > 0 {
 		path += fmt.Sprintf("%soffset=%d", sep, offset)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -60,3 +67,94 @@ func (s *ImpressionShareService) List(limit, offset int) ([]types.CustomReportRe
 	}
 	return resp.Data, resp.Pagination, nil
 }
+
+// Download fetches a completed report's CSV from its downloadUri and parses
+// it into typed rows.
+func (s *ImpressionShareService) Download(ctx context.Context, downloadURI string) ([]types.ImpressionShareRow, error) {
+	if downloadURI == "" {
+		return nil, fmt.Errorf("report has no downloadUri")
+	}
+	body, err := s.client.Get(ctx, downloadURI)
+	if err != nil {
+		return nil, err
+	}
+	return parseImpressionShareCSV(body)
+}
+
+// parseImpressionShareCSV maps columns by header name, so reordered or
+// additional columns are tolerated. Unknown columns go to Other.
+func parseImpressionShareCSV(data []byte) ([]types.ImpressionShareRow, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return []types.ImpressionShareRow{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse CSV header: %w", err)
+	}
+
+	rows := []types.ImpressionShareRow{}
+	for line := 2; ; line++ {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse CSV: %w", err)
+		}
+		var row types.ImpressionShareRow
+		for i, v := range rec {
+			if i >= len(header) || v == "" {
+				continue
+			}
+			if err := setImpressionShareField(&row, strings.TrimSpace(header[i]), v); err != nil {
+				return nil, fmt.Errorf("CSV line %d: %w", line, err)
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+func setImpressionShareField(row *types.ImpressionShareRow, name, v string) error {
+	var err error
+	switch name {
+	case "date":
+		row.Date = v
+	case "appName":
+		row.AppName = v
+	case "adamId":
+		row.AdamID, err = strconv.ParseInt(v, 10, 64)
+	case "countryOrRegion":
+		row.CountryOrRegion = v
+	case "searchTerm":
+		row.SearchTerm = v
+	case "lowImpressionShare":
+		row.LowImpressionShare, err = parseFloatPtr(v)
+	case "highImpressionShare":
+		row.HighImpressionShare, err = parseFloatPtr(v)
+	case "rank":
+		row.Rank = v
+	case "searchPopularity":
+		var n int
+		n, err = strconv.Atoi(v)
+		row.SearchPopularity = &n
+	default:
+		if row.Other == nil {
+			row.Other = map[string]string{}
+		}
+		row.Other[name] = v
+	}
+	if err != nil {
+		return fmt.Errorf("%s: invalid value %q", name, v)
+	}
+	return nil
+}
+
+func parseFloatPtr(v string) (*float64, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,10 +16,10 @@ func (c *Client) Keywords() *KeywordService {
 	return &KeywordService{client: c}
 }
 
-func (s *KeywordService) Create(campaignID, adGroupID int64, keywords []types.Keyword) ([]types.Keyword, error) {
+func (s *KeywordService) Create(ctx context.Context, campaignID, adGroupID int64, keywords []types.Keyword) ([]types.Keyword, error) {
 	// Bulk create endpoint per Apple Ads API docs.
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/targetingkeywords/bulk", campaignID, adGroupID)
-	body, err := s.client.Post(path, keywords)
+	body, err := s.client.Post(ctx, path, keywords)
 	if err != nil {
 		return nil, err
 	}
@@ -29,9 +30,9 @@ func (s *KeywordService) Create(campaignID, adGroupID int64, keywords []types.Ke
 	return resp.Data, nil
 }
 
-func (s *KeywordService) Get(campaignID, adGroupID, keywordID int64) (*types.Keyword, error) {
+func (s *KeywordService) Get(ctx context.Context, campaignID, adGroupID, keywordID int64) (*types.Keyword, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/targetingkeywords/%d", campaignID, adGroupID, keywordID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +43,7 @@ func (s *KeywordService) Get(campaignID, adGroupID, keywordID int64) (*types.Key
 	return resp.Data, nil
 }
 
-func (s *KeywordService) List(campaignID, adGroupID int64, limit, offset int) ([]types.Keyword, *types.PageDetail, error) {
+func (s *KeywordService) List(ctx context.Context, campaignID, adGroupID int64, limit, offset int) ([]types.Keyword, *types.PageDetail, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/targetingkeywords", campaignID, adGroupID)
 	sep := "?"
 	if limit > 0 {
@@ -52,7 +53,7 @@ func (s *KeywordService) List(campaignID, adGroupID int64, limit, offset int) ([
 	if offset > 0 {
 		path += fmt.Sprintf("%soffset=%d", sep, offset)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -63,9 +64,9 @@ func (s *KeywordService) List(campaignID, adGroupID int64, limit, offset int) ([
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *KeywordService) Find(campaignID, adGroupID int64, selector *types.Selector) ([]types.Keyword, *types.PageDetail, error) {
+func (s *KeywordService) Find(ctx context.Context, campaignID, adGroupID int64, selector *types.Selector) ([]types.Keyword, *types.PageDetail, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/targetingkeywords/find", campaignID, adGroupID)
-	body, err := s.client.Post(path, selector)
+	body, err := s.client.Post(ctx, path, selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -76,9 +77,9 @@ func (s *KeywordService) Find(campaignID, adGroupID int64, selector *types.Selec
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *KeywordService) FindCampaign(campaignID int64, selector *types.Selector) ([]types.Keyword, *types.PageDetail, error) {
+func (s *KeywordService) FindCampaign(ctx context.Context, campaignID int64, selector *types.Selector) ([]types.Keyword, *types.PageDetail, error) {
 	path := fmt.Sprintf("/campaigns/%d/targetingkeywords/find", campaignID)
-	body, err := s.client.Post(path, selector)
+	body, err := s.client.Post(ctx, path, selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -89,10 +90,10 @@ func (s *KeywordService) FindCampaign(campaignID int64, selector *types.Selector
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *KeywordService) Update(campaignID, adGroupID int64, keywords []types.Keyword) ([]types.Keyword, error) {
+func (s *KeywordService) Update(ctx context.Context, campaignID, adGroupID int64, keywords []types.Keyword) ([]types.Keyword, error) {
 	// Bulk update endpoint per Apple Ads API docs.
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/targetingkeywords/bulk", campaignID, adGroupID)
-	body, err := s.client.Put(path, keywords)
+	body, err := s.client.Put(ctx, path, keywords)
 	if err != nil {
 		return nil, err
 	}
@@ -104,16 +105,16 @@ func (s *KeywordService) Update(campaignID, adGroupID int64, keywords []types.Ke
 }
 
 // Delete bulk-deletes keywords by IDs.
-func (s *KeywordService) Delete(campaignID, adGroupID int64, keywordIDs []int64) error {
+func (s *KeywordService) Delete(ctx context.Context, campaignID, adGroupID int64, keywordIDs []int64) error {
 	// Bulk delete endpoint per Apple Ads API docs.
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/targetingkeywords/delete/bulk", campaignID, adGroupID)
-	_, err := s.client.DeleteWithBody(path, keywordIDs)
+	_, err := s.client.DeleteWithBody(ctx, path, keywordIDs)
 	return err
 }
 
 // DeleteOne deletes a single keyword.
-func (s *KeywordService) DeleteOne(campaignID, adGroupID, keywordID int64) error {
+func (s *KeywordService) DeleteOne(ctx context.Context, campaignID, adGroupID, keywordID int64) error {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/targetingkeywords/%d", campaignID, adGroupID, keywordID)
-	_, err := s.client.Delete(path)
+	_, err := s.client.Delete(ctx, path)
 	return err
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -17,10 +18,10 @@ func (c *Client) Negatives() *NegativeKeywordService {
 
 // Campaign-level negative keywords
 
-func (s *NegativeKeywordService) CampaignCreate(campaignID int64, keywords []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
+func (s *NegativeKeywordService) CampaignCreate(ctx context.Context, campaignID int64, keywords []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
 	// Bulk create endpoint per Apple Ads API docs.
 	path := fmt.Sprintf("/campaigns/%d/negativekeywords/bulk", campaignID)
-	body, err := s.client.Post(path, keywords)
+	body, err := s.client.Post(ctx, path, keywords)
 	if err != nil {
 		return nil, err
 	}
@@ -31,9 +32,9 @@ func (s *NegativeKeywordService) CampaignCreate(campaignID int64, keywords []typ
 	return resp.Data, nil
 }
 
-func (s *NegativeKeywordService) CampaignGet(campaignID, keywordID int64) (*types.NegativeKeyword, error) {
+func (s *NegativeKeywordService) CampaignGet(ctx context.Context, campaignID, keywordID int64) (*types.NegativeKeyword, error) {
 	path := fmt.Sprintf("/campaigns/%d/negativekeywords/%d", campaignID, keywordID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +45,7 @@ func (s *NegativeKeywordService) CampaignGet(campaignID, keywordID int64) (*type
 	return resp.Data, nil
 }
 
-func (s *NegativeKeywordService) CampaignList(campaignID int64, limit, offset int) ([]types.NegativeKeyword, *types.PageDetail, error) {
+func (s *NegativeKeywordService) CampaignList(ctx context.Context, campaignID int64, limit, offset int) ([]types.NegativeKeyword, *types.PageDetail, error) {
 	path := fmt.Sprintf("/campaigns/%d/negativekeywords", campaignID)
 	sep := "?"
 	if limit > 0 {
@@ -54,7 +55,7 @@ func (s *NegativeKeywordService) CampaignList(campaignID int64, limit, offset in
 	if offset > 0 {
 		path += fmt.Sprintf("%soffset=%d", sep, offset)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -65,9 +66,9 @@ func (s *NegativeKeywordService) CampaignList(campaignID int64, limit, offset in
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *NegativeKeywordService) CampaignFind(campaignID int64, selector *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
+func (s *NegativeKeywordService) CampaignFind(ctx context.Context, campaignID int64, selector *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
 	path := fmt.Sprintf("/campaigns/%d/negativekeywords/find", campaignID)
-	body, err := s.client.Post(path, selector)
+	body, err := s.client.Post(ctx, path, selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -78,10 +79,10 @@ func (s *NegativeKeywordService) CampaignFind(campaignID int64, selector *types.
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *NegativeKeywordService) CampaignUpdate(campaignID int64, keywords []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
+func (s *NegativeKeywordService) CampaignUpdate(ctx context.Context, campaignID int64, keywords []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
 	// Bulk update endpoint per Apple Ads API docs.
 	path := fmt.Sprintf("/campaigns/%d/negativekeywords/bulk", campaignID)
-	body, err := s.client.Put(path, keywords)
+	body, err := s.client.Put(ctx, path, keywords)
 	if err != nil {
 		return nil, err
 	}
@@ -92,19 +93,19 @@ func (s *NegativeKeywordService) CampaignUpdate(campaignID int64, keywords []typ
 	return resp.Data, nil
 }
 
-func (s *NegativeKeywordService) CampaignDelete(campaignID int64, keywordIDs []int64) error {
+func (s *NegativeKeywordService) CampaignDelete(ctx context.Context, campaignID int64, keywordIDs []int64) error {
 	// Bulk delete endpoint per Apple Ads API docs.
 	path := fmt.Sprintf("/campaigns/%d/negativekeywords/delete/bulk", campaignID)
-	_, err := s.client.DeleteWithBody(path, keywordIDs)
+	_, err := s.client.DeleteWithBody(ctx, path, keywordIDs)
 	return err
 }
 
 // Ad group-level negative keywords
 
-func (s *NegativeKeywordService) AdGroupCreate(campaignID, adGroupID int64, keywords []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
+func (s *NegativeKeywordService) AdGroupCreate(ctx context.Context, campaignID, adGroupID int64, keywords []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
 	// Bulk create endpoint per Apple Ads API docs.
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/negativekeywords/bulk", campaignID, adGroupID)
-	body, err := s.client.Post(path, keywords)
+	body, err := s.client.Post(ctx, path, keywords)
 	if err != nil {
 		return nil, err
 	}
@@ -115,9 +116,9 @@ func (s *NegativeKeywordService) AdGroupCreate(campaignID, adGroupID int64, keyw
 	return resp.Data, nil
 }
 
-func (s *NegativeKeywordService) AdGroupGet(campaignID, adGroupID, keywordID int64) (*types.NegativeKeyword, error) {
+func (s *NegativeKeywordService) AdGroupGet(ctx context.Context, campaignID, adGroupID, keywordID int64) (*types.NegativeKeyword, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/negativekeywords/%d", campaignID, adGroupID, keywordID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +129,7 @@ func (s *NegativeKeywordService) AdGroupGet(campaignID, adGroupID, keywordID int
 	return resp.Data, nil
 }
 
-func (s *NegativeKeywordService) AdGroupList(campaignID, adGroupID int64, limit, offset int) ([]types.NegativeKeyword, *types.PageDetail, error) {
+func (s *NegativeKeywordService) AdGroupList(ctx context.Context, campaignID, adGroupID int64, limit, offset int) ([]types.NegativeKeyword, *types.PageDetail, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/negativekeywords", campaignID, adGroupID)
 	sep := "?"
 	if limit > 0 {
@@ -138,7 +139,7 @@ func (s *NegativeKeywordService) AdGroupList(campaignID, adGroupID int64, limit,
 	if offset > 0 {
 		path += fmt.Sprintf("%soffset=%d", sep, offset)
 	}
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -149,9 +150,9 @@ func (s *NegativeKeywordService) AdGroupList(campaignID, adGroupID int64, limit,
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *NegativeKeywordService) AdGroupFind(campaignID, adGroupID int64, selector *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
+func (s *NegativeKeywordService) AdGroupFind(ctx context.Context, campaignID, adGroupID int64, selector *types.Selector) ([]types.NegativeKeyword, *types.PageDetail, error) {
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/negativekeywords/find", campaignID, adGroupID)
-	body, err := s.client.Post(path, selector)
+	body, err := s.client.Post(ctx, path, selector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -162,10 +163,10 @@ func (s *NegativeKeywordService) AdGroupFind(campaignID, adGroupID int64, select
 	return resp.Data, resp.Pagination, nil
 }
 
-func (s *NegativeKeywordService) AdGroupUpdate(campaignID, adGroupID int64, keywords []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
+func (s *NegativeKeywordService) AdGroupUpdate(ctx context.Context, campaignID, adGroupID int64, keywords []types.NegativeKeyword) ([]types.NegativeKeyword, error) {
 	// Bulk update endpoint per Apple Ads API docs.
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/negativekeywords/bulk", campaignID, adGroupID)
-	body, err := s.client.Put(path, keywords)
+	body, err := s.client.Put(ctx, path, keywords)
 	if err != nil {
 		return nil, err
 	}
@@ -176,9 +177,9 @@ func (s *NegativeKeywordService) AdGroupUpdate(campaignID, adGroupID int64, keyw
 	return resp.Data, nil
 }
 
-func (s *NegativeKeywordService) AdGroupDelete(campaignID, adGroupID int64, keywordIDs []int64) error {
+func (s *NegativeKeywordService) AdGroupDelete(ctx context.Context, campaignID, adGroupID int64, keywordIDs []int64) error {
 	// Bulk delete endpoint per Apple Ads API docs.
 	path := fmt.Sprintf("/campaigns/%d/adgroups/%d/negativekeywords/delete/bulk", campaignID, adGroupID)
-	_, err := s.client.DeleteWithBody(path, keywordIDs)
+	_, err := s.client.DeleteWithBody(ctx, path, keywordIDs)
 	return err
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strings"
@@ -40,13 +41,13 @@ func TestKeywordBulkPaths(t *testing.T) {
 		return okJSON(`{"data":[]}`), nil
 	})
 
-	if _, err := c.Keywords().Create(1, 2, []types.Keyword{}); err != nil {
+	if _, err := c.Keywords().Create(context.Background(), 1, 2, []types.Keyword{}); err != nil {
 		t.Fatalf("create: %v", err)
 	}
-	if err := c.Keywords().Delete(1, 2, []int64{10, 11}); err != nil {
+	if err := c.Keywords().Delete(context.Background(), 1, 2, []int64{10, 11}); err != nil {
 		t.Fatalf("delete bulk: %v", err)
 	}
-	if err := c.Keywords().DeleteOne(1, 2, 10); err != nil {
+	if err := c.Keywords().DeleteOne(context.Background(), 1, 2, 10); err != nil {
 		t.Fatalf("delete one: %v", err)
 	}
 
@@ -72,16 +73,16 @@ func TestNegativeKeywordBulkPaths(t *testing.T) {
 		return okJSON(`{"data":[]}`), nil
 	})
 
-	if _, err := c.Negatives().CampaignCreate(99, []types.NegativeKeyword{}); err != nil {
+	if _, err := c.Negatives().CampaignCreate(context.Background(), 99, []types.NegativeKeyword{}); err != nil {
 		t.Fatalf("campaign create: %v", err)
 	}
-	if err := c.Negatives().CampaignDelete(99, []int64{1, 2}); err != nil {
+	if err := c.Negatives().CampaignDelete(context.Background(), 99, []int64{1, 2}); err != nil {
 		t.Fatalf("campaign delete bulk: %v", err)
 	}
-	if _, err := c.Negatives().AdGroupCreate(99, 100, []types.NegativeKeyword{}); err != nil {
+	if _, err := c.Negatives().AdGroupCreate(context.Background(), 99, 100, []types.NegativeKeyword{}); err != nil {
 		t.Fatalf("ad group create: %v", err)
 	}
-	if err := c.Negatives().AdGroupDelete(99, 100, []int64{1, 2}); err != nil {
+	if err := c.Negatives().AdGroupDelete(context.Background(), 99, 100, []int64{1, 2}); err != nil {
 		t.Fatalf("ad group delete bulk: %v", err)
 	}
 
@@ -116,19 +117,19 @@ func TestProductPagesPaths(t *testing.T) {
 		}
 	})
 
-	if _, err := c.ProductPages().List(123456789); err != nil {
+	if _, err := c.ProductPages().List(context.Background(), 123456789); err != nil {
 		t.Fatalf("list: %v", err)
 	}
-	if _, err := c.ProductPages().Get("pp-123", 123456789); err != nil {
+	if _, err := c.ProductPages().Get(context.Background(), "pp-123", 123456789); err != nil {
 		t.Fatalf("get: %v", err)
 	}
-	if _, err := c.ProductPages().Locales("pp-123", 123456789); err != nil {
+	if _, err := c.ProductPages().Locales(context.Background(), "pp-123", 123456789); err != nil {
 		t.Fatalf("locales: %v", err)
 	}
-	if _, err := c.ProductPages().Countries(); err != nil {
+	if _, err := c.ProductPages().Countries(context.Background()); err != nil {
 		t.Fatalf("countries: %v", err)
 	}
-	if _, err := c.ProductPages().DeviceSizes(); err != nil {
+	if _, err := c.ProductPages().DeviceSizes(context.Background()); err != nil {
 		t.Fatalf("device sizes: %v", err)
 	}
 
@@ -159,13 +160,13 @@ func TestAdRejectionPaths(t *testing.T) {
 		return okJSON(`{"data":[]}`), nil
 	})
 
-	if _, _, err := c.AdRejections().Find(&types.Selector{}); err != nil {
+	if _, _, err := c.AdRejections().Find(context.Background(), &types.Selector{}); err != nil {
 		t.Fatalf("find: %v", err)
 	}
-	if _, err := c.AdRejections().Get(42); err != nil {
+	if _, err := c.AdRejections().Get(context.Background(), 42); err != nil {
 		t.Fatalf("get: %v", err)
 	}
-	if _, _, err := c.AdRejections().FindAssets(123456789, &types.Selector{}); err != nil {
+	if _, _, err := c.AdRejections().FindAssets(context.Background(), 123456789, &types.Selector{}); err != nil {
 		t.Fatalf("find assets: %v", err)
 	}
 
@@ -195,7 +196,118 @@ func TestNoOrgHeaderForACL(t *testing.T) {
 		return okJSON(`{"data":[]}`), nil
 	})
 
-	if _, err := c.ACLs().List(); err != nil {
+	if _, err := c.ACLs().List(context.Background()); err != nil {
 		t.Fatalf("acls list: %v", err)
 	}
 }
+
+func TestReportPathsAndDecoding(t *testing.T) {
+	var got []string
+	c := newTestClient(t, "123", func(req *http.Request) (*http.Response, error) {
+		got = append(got, req.Method+" "+req.URL.Path)
+		return okJSON(`{"data":{"reportingDataResponse":{
+			"row":[{
+				"metadata":{"campaignId":7,"campaignName":"Brand"},
+				"total":{"impressions":100,"taps":10,"localSpend":{"amount":"5.00","currency":"USD"}},
+				"granularity":[{"date":"2025-01-01","impressions":60,"taps":6}]
+			}],
+			"grandTotals":{"total":{"impressions":100,"taps":10}}
+		}}}`), nil
+	})
+
+	agID := int64(2)
+	report, err := c.Reports().Campaigns(context.Background(), &types.ReportingRequest{})
+	if err != nil {
+		t.Fatalf("campaigns: %v", err)
+	}
+	if _, err := c.Reports().Keywords(context.Background(), 1, &agID, &types.ReportingRequest{}); err != nil {
+		t.Fatalf("keywords: %v", err)
+	}
+	if _, err := c.Reports().SearchTerms(context.Background(), 1, nil, &types.ReportingRequest{}); err != nil {
+		t.Fatalf("search terms: %v", err)
+	}
+
+	want := []string{
+		"POST /api/v5/reports/campaigns",
+		"POST /api/v5/reports/campaigns/1/adgroups/2/keywords",
+		"POST /api/v5/reports/campaigns/1/searchterms",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d requests, want %d: %#v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("request[%d]=%q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if len(report.Row) != 1 {
+		t.Fatalf("got %d rows, want 1", len(report.Row))
+	}
+	row := report.Row[0]
+	if row.Metadata["campaignName"] != "Brand" {
+		t.Fatalf("metadata campaignName=%v", row.Metadata["campaignName"])
+	}
+	if row.Total == nil || row.Total.Taps != 10 || row.Total.LocalSpend == nil || row.Total.LocalSpend.Amount != "5.00" {
+		t.Fatalf("unexpected total: %#v", row.Total)
+	}
+	if len(row.Granularity) != 1 || row.Granularity[0].Date != "2025-01-01" || row.Granularity[0].Impressions != 60 {
+		t.Fatalf("unexpected granularity: %#v", row.Granularity)
+	}
+	if report.GrandTotals == nil || report.GrandTotals.Total == nil || report.GrandTotals.Total.Impressions != 100 {
+		t.Fatalf("unexpected grand totals: %#v", report.GrandTotals)
+	}
+}
+
+// TestReportDecodesAppleRow decodes a campaign report row as Apple sends it,
+// including the boolean "other" flag.
+func TestReportDecodesAppleRow(t *testing.T) {
+	c := newTestClient(t, "123", func(req *http.Request) (*http.Response, error) {
+		return okJSON(`{"data":{"reportingDataResponse":{"row":[{"other":false,"total":{"impressions":4612,"taps":86,"installs":5,"newDownloads":4,"redownloads":1,"latOnInstalls":0,"latOffInstalls":5,"ttr":0.0186,"avgCPA":{"amount":"13.72","currency":"USD"},"avgCPT":{"amount":"0.8","currency":"USD"},"avgCPM":{"amount":"14.88","currency":"USD"},"localSpend":{"amount":"68.62","currency":"USD"},"conversionRate":0.0581},"metadata":{"campaignId":542370642,"campaignName":"US Search Campaign","deleted":false,"campaignStatus":"ENABLED","app":{"appName":"Example App","adamId":1234567890},"servingStatus":"RUNNING","servingStateReasons":null,"countriesOrRegions":["US"],"modificationTime":"2024-04-08T21:03:02.216","totalBudget":{"amount":"1000","currency":"USD"},"dailyBudget":{"amount":"100","currency":"USD"},"displayStatus":"RUNNING","supplySources":["APPSTORE_SEARCH_RESULTS"],"adChannelType":"SEARCH","orgId":40669820,"countryOrRegionServingStateReasons":{},"billingEvent":"TAPS"}}],"grandTotals":{"other":false,"total":{"impressions":4612,"taps":86,"installs":5,"newDownloads":4,"redownloads":1,"latOnInstalls":0,"latOffInstalls":5,"ttr":0.0186,"avgCPA":{"amount":"13.72","currency":"USD"},"avgCPT":{"amount":"0.8","currency":"USD"},"avgCPM":{"amount":"14.88","currency":"USD"},"localSpend":{"amount":"68.62","currency":"USD"},"conversionRate":0.0581}}}},"pagination":{"totalResults":1,"startIndex":0,"itemsPerPage":1},"error":null}`), nil
+	})
+
+	report, err := c.Reports().Campaigns(context.Background(), &types.ReportingRequest{})
+	if err != nil {
+		t.Fatalf("campaigns: %v", err)
+	}
+	if len(report.Row) != 1 {
+		t.Fatalf("got %d rows, want 1", len(report.Row))
+	}
+	row := report.Row[0]
+	if row.Other || row.Metadata["campaignName"] != "US Search Campaign" || row.Total == nil || row.Total.Installs != 5 || row.Total.LocalSpend.Amount != "68.62" {
+		t.Fatalf("unexpected row: %#v", row)
+	}
+	if report.GrandTotals == nil || report.GrandTotals.Other || report.GrandTotals.Total.Taps != 86 {
+		t.Fatalf("unexpected grand totals: %#v", report.GrandTotals)
+	}
+}
+
+func TestImpressionShareDownload(t *testing.T) {
+	c := newTestClient(t, "123", func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host != "reports.example.com" {
+			t.Fatalf("unexpected request to %s", req.URL)
+		}
+		if v := req.Header.Get("Authorization"); v != "" {
+			t.Fatalf("API credentials sent to download host: %q", v)
+		}
+		return okJSON("\ufeffdate,adamId,searchTerm,lowImpressionShare,highImpressionShare,rank,searchPopularity,extra\n" +
+			"2025-01-01,42,photo editor,0.1,0.2,ONE,4,x\n" +
+			"2025-01-02,42,photo app,,,GREATER_THAN_FIVE,,\n"), nil
+	})
+
+	rows, err := c.ImpressionShare().Download(context.Background(), "https://reports.example.com/r/1.csv?sig=abc")
+	if err != nil {
+		t.Fatalf("download: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	r := rows[0]
+	if r.Date != "2025-01-01" || r.AdamID != 42 || r.SearchTerm != "photo editor" || *r.LowImpressionShare != 0.1 ||
+		*r.HighImpressionShare != 0.2 || r.Rank != "ONE" || *r.SearchPopularity != 4 || r.Other["extra"] != "x" {
+		t.Fatalf("unexpected row: %+v", r)
+	}
+	if r := rows[1]; r.LowImpressionShare != nil || r.SearchPopularity != nil || r.Rank != "GREATER_THAN_FIVE" {
+		t.Fatalf("empty cells not left nil: %+v", r)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,9 +16,9 @@ func (c *Client) ProductPages() *ProductPageService {
 	return &ProductPageService{client: c}
 }
 
-func (s *ProductPageService) List(adamID int64) ([]types.ProductPageDetail, error) {
+func (s *ProductPageService) List(ctx context.Context, adamID int64) ([]types.ProductPageDetail, error) {
 	path := fmt.Sprintf("/apps/%d/product-pages", adamID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -28,9 +29,9 @@ func (s *ProductPageService) List(adamID int64) ([]types.ProductPageDetail, erro
 	return resp.Data, nil
 }
 
-func (s *ProductPageService) Get(productPageID string, adamID int64) (*types.ProductPageDetail, error) {
+func (s *ProductPageService) Get(ctx context.Context, productPageID string, adamID int64) (*types.ProductPageDetail, error) {
 	path := fmt.Sprintf("/apps/%d/product-pages/%s", adamID, productPageID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -41,9 +42,9 @@ func (s *ProductPageService) Get(productPageID string, adamID int64) (*types.Pro
 	return resp.Data, nil
 }
 
-func (s *ProductPageService) Locales(productPageID string, adamID int64) ([]types.ProductPageLocaleDetail, error) {
+func (s *ProductPageService) Locales(ctx context.Context, productPageID string, adamID int64) ([]types.ProductPageLocaleDetail, error) {
 	path := fmt.Sprintf("/apps/%d/product-pages/%s/locale-details", adamID, productPageID)
-	body, err := s.client.Get(path)
+	body, err := s.client.Get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +55,8 @@ func (s *ProductPageService) Locales(productPageID string, adamID int64) ([]type
 	return resp.Data, nil
 }
 
-func (s *ProductPageService) Countries() ([]types.CountryOrRegion, error) {
-	body, err := s.client.Get("/countries-or-regions")
+func (s *ProductPageService) Countries(ctx context.Context) ([]types.CountryOrRegion, error) {
+	body, err := s.client.Get(ctx, "/countries-or-regions")
 	if err != nil {
 		return nil, err
 	}
@@ -66,8 +67,8 @@ func (s *ProductPageService) Countries() ([]types.CountryOrRegion, error) {
 	return resp.Data, nil
 }
 
-func (s *ProductPageService) DeviceSizes() (json.RawMessage, error) {
-	body, err := s.client.Get("/creativeappmappings/devices")
+func (s *ProductPageService) DeviceSizes(ctx context.Context) (json.RawMessage, error) {
+	body, err := s.client.Get(ctx, "/creativeappmappings/devices")
 	if err != nil {
 		return nil, err
 	}
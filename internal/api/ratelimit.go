@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by every request a Client makes,
+// including retries. Tokens refill at rate per second up to burst.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newRateLimiter(rps float64) *rateLimiter {
+	burst := math.Max(1, math.Floor(rps))
+	return &rateLimiter{rate: rps, burst: burst, tokens: burst, now: time.Now}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	// Reserve a token now, even if that takes the bucket negative, so
+	// concurrent callers queue up in order instead of racing on refill.
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if err := SleepContext(ctx, wait); err != nil {
+		l.mu.Lock()
+		l.tokens++ // give the unused reservation back
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
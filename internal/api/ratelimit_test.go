@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSpacesRequestsAfterBurst(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newRateLimiter(2)
+	l.now = func() time.Time { return now }
+
+	// The bucket starts full (burst 2), so two requests go straight through.
+	for i := 0; i < 2; i++ {
+		start := time.Now()
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if time.Since(start) > 100*time.Millisecond {
+			t.Fatalf("request %d waited with tokens available", i)
+		}
+	}
+
+	// The third must wait for a refill; a context that expires first aborts
+	// the wait and returns the reservation.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	if l.tokens != 0 {
+		t.Fatalf("tokens = %v after cancelled wait, want 0", l.tokens)
+	}
+
+	// Half a second later one token has refilled.
+	now = now.Add(500 * time.Millisecond)
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) > 100*time.Millisecond {
+		t.Fatal("waited after refill")
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// ReportCache stores report responses on disk, keyed by a hash of the org,
+// API base URL, report path and request body.
+//
+// Entries expire after TTL, except for settled reports, which are kept until
+// the cache is cleared. A report is settled once SettleDays full days have
+// passed since its end date ended in the report's time zone: UTC for
+// timeZone UTC, OrgLocation otherwise (ORTZ, Apple's default). Apple no
+// longer revises those days. When OrgLocation is nil, the end date is taken
+// to end in UTC-12, the last zone where a day ends.
+type ReportCache struct {
+	Dir         string
+	TTL         time.Duration
+	SettleDays  int
+	OrgLocation *time.Location
+}
+
+// latestZone is the last time zone in which any given day ends.
+var latestZone = time.FixedZone("UTC-12", -12*60*60)
+
+// cachedReport is the on-disk form of a cached report response.
+type cachedReport struct {
+	Path     string    `json:"path"`
+	StoredAt time.Time `json:"storedAt"`
+	// ExpiresAt is zero for settled reports, which never expire.
+	ExpiresAt time.Time                    `json:"expiresAt,omitempty"`
+	Data      *types.ReportingDataResponse `json:"data"`
+}
+
+// ReportCacheDir returns ~/.aads/cache/reports.
+func ReportCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".aads", "cache", "reports"), nil
+}
+
+// ClearReportCache deletes every cached report in dir and returns how many
+// were removed. A missing directory is not an error.
+func ClearReportCache(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// SetReportCache enables the report cache. A nil cache disables it.
+func (c *Client) SetReportCache(rc *ReportCache) {
+	c.reportCache = rc
+}
+
+func (rc *ReportCache) path(orgID, baseURL, apiPath string, body []byte) string {
+	sum := sha256.Sum256([]byte(orgID + "\n" + baseURL + "\n" + apiPath + "\n" + string(body)))
+	return filepath.Join(rc.Dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// get returns the cached response at path, or nil when there is none or it
+// expired. Unreadable entries are treated as misses.
+func (rc *ReportCache) get(path string) *types.ReportingDataResponse {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var e cachedReport
+	if err := json.Unmarshal(b, &e); err != nil || e.Data == nil {
+		return nil
+	}
+	if !e.ExpiresAt.IsZero() && time.Now().After(e.ExpiresAt) {
+		return nil
+	}
+	return e.Data
+}
+
+func (rc *ReportCache) put(path, apiPath string, req *types.ReportingRequest, data *types.ReportingDataResponse) error {
+	now := time.Now()
+	e := cachedReport{Path: apiPath, StoredAt: now, Data: data}
+	if !rc.settled(req, now) {
+		e.ExpiresAt = now.Add(rc.TTL)
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, b)
+}
+
+// settled reports whether every day req covers ended more than SettleDays
+// days ago in the report's time zone.
+func (rc *ReportCache) settled(req *types.ReportingRequest, now time.Time) bool {
+	end, err := time.Parse("2006-01-02", req.EndTime)
+	if err != nil {
+		return false
+	}
+	loc := time.UTC
+	if !strings.EqualFold(req.TimeZone, "UTC") {
+		loc = rc.OrgLocation
+		if loc == nil {
+			loc = latestZone
+		}
+	}
+	settledAt := time.Date(end.Year(), end.Month(), end.Day()+1+rc.SettleDays, 0, 0, 0, 0, loc)
+	return !now.Before(settledAt)
+}
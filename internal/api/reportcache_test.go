@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func TestReportCache(t *testing.T) {
+	calls := 0
+	c := newTestClient(t, "123", func(req *http.Request) (*http.Response, error) {
+		calls++
+		return okJSON(`{"data":{"reportingDataResponse":{"row":[{"metadata":{"campaignId":7},"total":{"taps":10}}]}}}`), nil
+	})
+	dir := t.TempDir()
+	c.SetReportCache(&ReportCache{Dir: dir, TTL: -time.Second, SettleDays: 3})
+
+	ctx := context.Background()
+	old := &types.ReportingRequest{StartTime: "2024-01-01", EndTime: "2024-01-31"}
+	today := time.Now().UTC().Format("2006-01-02")
+	recent := &types.ReportingRequest{StartTime: today, EndTime: today}
+
+	for range 2 {
+		report, err := c.Reports().Campaigns(ctx, old)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(report.Row) != 1 || report.Row[0].Total.Taps != 10 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+		// The negative TTL expires recent entries immediately.
+		if _, err := c.Reports().Campaigns(ctx, recent); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("got %d API calls, want 3 (settled range cached, recent range refetched)", calls)
+	}
+
+	// A different org must not share entries.
+	c.SetOrgID("456")
+	if _, err := c.Reports().Campaigns(ctx, old); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 4 {
+		t.Fatalf("got %d API calls after org switch, want 4", calls)
+	}
+
+	n, err := ClearReportCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("cleared %d entries, want 3", n)
+	}
+	if left, _ := filepath.Glob(filepath.Join(dir, "*")); len(left) != 0 {
+		t.Fatalf("files left after clear: %v", left)
+	}
+	if n, err := ClearReportCache(filepath.Join(dir, "missing")); err != nil || n != 0 {
+		t.Fatalf("clear missing dir = %d, %v", n, err)
+	}
+}
+
+func TestReportCacheSettledInReportTimeZone(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	losAngeles := time.FixedZone("PDT", -7*60*60)
+	// 2024-03-10 01:00 UTC is 10:00 on 03-10 in Tokyo and 18:00 on 03-09
+	// in Los Angeles.
+	now := time.Date(2024, 3, 10, 1, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		end      string
+		timeZone string
+		org      *time.Location
+		want     bool
+	}{
+		{"UTC day ended at midnight", "2024-03-09", "UTC", losAngeles, true},
+		{"UTC day still open", "2024-03-10", "UTC", tokyo, false},
+		{"ORTZ ended east of UTC", "2024-03-09", "ORTZ", tokyo, true},
+		{"ORTZ still open west of UTC", "2024-03-09", "ORTZ", losAngeles, false},
+		{"ORTZ ended west of UTC", "2024-03-08", "ORTZ", losAngeles, true},
+		{"default is ORTZ", "2024-03-09", "", losAngeles, false},
+		{"unknown org zone ends last", "2024-03-09", "ORTZ", nil, false},
+		{"unknown org zone ended", "2024-03-08", "ORTZ", nil, true},
+	}
+	for _, tt := range tests {
+		rc := &ReportCache{SettleDays: 0, OrgLocation: tt.org}
+		req := &types.ReportingRequest{StartTime: "2024-03-01", EndTime: tt.end, TimeZone: tt.timeZone}
+		if got := rc.settled(req, now); got != tt.want {
+			t.Errorf("%s: settled = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// The settle window counts whole days after the end date.
+	rc := &ReportCache{SettleDays: 2, OrgLocation: tokyo}
+	req := &types.ReportingRequest{EndTime: "2024-03-07", TimeZone: "ORTZ"}
+	if !rc.settled(req, now) {
+		t.Error("03-07 should be settled two days after it ended in Tokyo")
+	}
+	req.EndTime = "2024-03-08"
+	if rc.settled(req, now) {
+		t.Error("03-08 should not be settled yet")
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,34 +16,81 @@ func (c *Client) Reports() *ReportService {
 	return &ReportService{client: c}
 }
 
-func (s *ReportService) Campaigns(req *types.ReportingRequest) (json.RawMessage, error) {
-	return s.client.Post("/reports/campaigns", req)
+func (s *ReportService) Campaigns(ctx context.Context, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+	return s.fetch(ctx, "/reports/campaigns", req)
 }
 
-func (s *ReportService) AdGroups(campaignID int64, req *types.ReportingRequest) (json.RawMessage, error) {
-	return s.client.Post(fmt.Sprintf("/reports/campaigns/%d/adgroups", campaignID), req)
+func (s *ReportService) AdGroups(ctx context.Context, campaignID int64, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+	return s.fetch(ctx, fmt.Sprintf("/reports/campaigns/%d/adgroups", campaignID), req)
 }
 
-func (s *ReportService) Keywords(campaignID int64, adGroupID *int64, req *types.ReportingRequest) (json.RawMessage, error) {
+func (s *ReportService) Keywords(ctx context.Context, campaignID int64, adGroupID *int64, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
 	var path string
 	if adGroupID != nil {
 		path = fmt.Sprintf("/reports/campaigns/%d/adgroups/%d/keywords", campaignID, *adGroupID)
 	} else {
 		path = fmt.Sprintf("/reports/campaigns/%d/keywords", campaignID)
 	}
-	return s.client.Post(path, req)
+	return s.fetch(ctx, path, req)
 }
 
-func (s *ReportService) SearchTerms(campaignID int64, adGroupID *int64, req *types.ReportingRequest) (json.RawMessage, error) {
+func (s *ReportService) SearchTerms(ctx context.Context, campaignID int64, adGroupID *int64, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
 	var path string
 	if adGroupID != nil {
 		path = fmt.Sprintf("/reports/campaigns/%d/adgroups/%d/searchterms", campaignID, *adGroupID)
 	} else {
 		path = fmt.Sprintf("/reports/campaigns/%d/searchterms", campaignID)
 	}
-	return s.client.Post(path, req)
+	return s.fetch(ctx, path, req)
 }
 
-func (s *ReportService) Ads(campaignID int64, req *types.ReportingRequest) (json.RawMessage, error) {
-	return s.client.Post(fmt.Sprintf("/reports/campaigns/%d/ads", campaignID), req)
+func (s *ReportService) Ads(ctx context.Context, campaignID int64, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+	return s.fetch(ctx, fmt.Sprintf("/reports/campaigns/%d/ads", campaignID), req)
+}
+
+// fetch posts a reporting request and unwraps the
+// {"data":{"reportingDataResponse":{...}}} envelope. With a report cache set,
+// cached responses are returned without calling the API.
+func (s *ReportService) fetch(ctx context.Context, path string, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+	rc := s.client.reportCache
+	if rc == nil {
+		return s.post(ctx, path, req)
+	}
+
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request body: %w", err)
+	}
+	cachePath := rc.path(s.client.orgID, s.client.baseURL, path, reqBody)
+	if data := rc.get(cachePath); data != nil {
+		if s.client.verbose {
+			fmt.Printf("POST %s (cached)\n", path)
+		}
+		return data, nil
+	}
+
+	data, err := s.post(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := rc.put(cachePath, path, req, data); err != nil && s.client.verbose {
+		// The cache is only an optimization.
+		fmt.Printf("Report cache write failed: %v\n", err)
+	}
+	return data, nil
+}
+
+func (s *ReportService) post(ctx context.Context, path string, req *types.ReportingRequest) (*types.ReportingDataResponse, error) {
+	body, err := s.client.Post(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+	var resp types.APIResponse[types.ReportingResponse]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if resp.Data == nil || resp.Data.ReportingDataResponse == nil {
+		return &types.ReportingDataResponse{}, nil
+	}
+	return resp.Data.ReportingDataResponse, nil
 }
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cachedToken is the on-disk form of an access token shared between aads
+// processes that use the same client_id. With a token_url override the
+// client ID is suffixed with the endpoint.
+type cachedToken struct {
+	ClientID    string    `json:"client_id"`
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func tokenCacheEnabled() bool {
+	switch strings.TrimSpace(strings.ToLower(os.Getenv("AADS_TOKEN_CACHE"))) {
+	case "0", "false", "no", "off":
+		return false
+	default:
+		return true
+	}
+}
+
+// tokenCachePath returns ~/.aads/tokens/<hash>.json for clientID. The client
+// ID is hashed so the file name is always safe and doesn't leak it.
+func tokenCachePath(clientID string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(clientID))
+	return filepath.Join(home, ".aads", "tokens", hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+func readTokenCache(path, clientID string) (*cachedToken, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c cachedToken
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	if c.ClientID != clientID || c.AccessToken == "" {
+		return nil, fmt.Errorf("token cache %s doesn't match client", path)
+	}
+	return &c, nil
+}
+
+// writeTokenCache writes the token through a temp file and rename so readers
+// never see a partial file.
+func writeTokenCache(path string, c *cachedToken) error {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, b)
+}
+
+// writeFileAtomic writes data to path with 0600 permissions through a temp
+// file in the same directory and a rename, creating the directory if needed.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// lockTokenCache takes an exclusive lock next to the cache file so only one
+// process refreshes the token at a time. Call the returned func to release it.
+func lockTokenCache(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	return lockFile(path + ".lock")
+}
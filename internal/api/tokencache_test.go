@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/config"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/mockserver"
+)
+
+func TestTokenSourceUsesDiskCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens", "client.json")
+	cfg := &config.Config{}
+	cfg.ClientID = "SEARCHADS.client"
+
+	if err := writeTokenCache(path, &cachedToken{
+		ClientID:    cfg.ClientID,
+		AccessToken: "from-disk",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("write cache: %v", err)
+	}
+	if fi, err := os.Stat(path); err != nil {
+		t.Fatalf("stat cache: %v", err)
+	} else if perm := fi.Mode().Perm(); perm != 0600 {
+		t.Fatalf("cache perm=%o, want 600", perm)
+	}
+
+	// No private key: any attempt to refresh would fail, so a token proves the cache was used.
+	ts := &TokenSource{cfg: cfg, cachePath: path}
+	tok, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("token: %v", err)
+	}
+	if tok != "from-disk" {
+		t.Fatalf("token=%q, want from-disk", tok)
+	}
+
+	ts.Invalidate()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected cache file removed, stat err=%v", err)
+	}
+}
+
+func TestTokenSourceIgnoresOtherClientCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "client.json")
+	if err := writeTokenCache(path, &cachedToken{
+		ClientID:    "SEARCHADS.other",
+		AccessToken: "not-ours",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("write cache: %v", err)
+	}
+
+	if _, err := readTokenCache(path, "SEARCHADS.client"); err == nil {
+		t.Fatalf("expected mismatch error for another client's token")
+	}
+}
+
+func TestTokenSourceUsesTokenURLOverride(t *testing.T) {
+	srv := httptest.NewServer(mockserver.New(mockserver.Options{}))
+	defer srv.Close()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{}
+	cfg.ClientID = "SEARCHADS.client"
+	cfg.TokenURL = srv.URL + mockserver.TokenPath
+
+	path := filepath.Join(t.TempDir(), "client.json")
+	ts := &TokenSource{cfg: cfg, privateKey: key, cachePath: path}
+	if _, err := ts.Token(context.Background()); err != nil {
+		t.Fatalf("token: %v", err)
+	}
+
+	// The cached token is tied to the override, so Apple's endpoint won't reuse it.
+	if _, err := readTokenCache(path, cfg.ClientID); err == nil {
+		t.Fatal("token from an overridden endpoint was cached under the bare client ID")
+	}
+	if _, err := readTokenCache(path, ts.cacheKey()); err != nil {
+		t.Fatalf("read cache: %v", err)
+	}
+}
@@ -0,0 +1,434 @@
+// Package bidrules computes keyword bid changes. It evaluates the rules for
+// `aads bids optimize`: a rule matches a keyword when every condition holds
+// for its report totals over the rule's lookback, and then moves the bid by a
+// percentage or an amount, within floors, ceilings and per-change caps. The
+// same caps limit how far `aads keywords apply-suggested-bids` moves a bid
+// toward Apple's suggestion.
+package bidrules
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLookbackDays is used for rules without lookbackDays.
+const DefaultLookbackDays = 14
+
+// Metrics lists the report metrics a condition can test. Rates and averages
+// are recomputed from the totals.
+var Metrics = []string{
+	"impressions", "taps", "installs", "newDownloads", "redownloads",
+	"localSpend", "avgCPA", "avgCPT", "ttr", "conversionRate",
+}
+
+// RuleSet is a rules file. The first rule that matches a keyword decides its
+// change.
+type RuleSet struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule is one bid adjustment. Amounts are in the keyword's bid currency.
+type Rule struct {
+	Name         string      `yaml:"name"`
+	LookbackDays int         `yaml:"lookbackDays,omitempty"`
+	When         []Condition `yaml:"when"`
+	// Adjust is a percentage ("-10%", "+15%") or an amount ("-0.10").
+	Adjust    string `yaml:"adjust"`
+	Floor     string `yaml:"floor,omitempty"`
+	Ceiling   string `yaml:"ceiling,omitempty"`
+	MaxChange string `yaml:"maxChange,omitempty"` // largest absolute change
+
+	adjust                    *big.Rat
+	adjustPct                 bool
+	floor, ceiling, maxChange *big.Rat
+}
+
+// Condition compares a metric with a value. With By, the value is a target
+// and the threshold sits that far beyond it: `avgCPA > 2.00 by 20%` matches
+// above 2.40, `avgCPA < 2.00 by 20%` below 1.60.
+type Condition struct {
+	Metric string `yaml:"metric"`
+	Op     string `yaml:"op"` // >, >=, <, <=, ==
+	Value  string `yaml:"value"`
+	By     string `yaml:"by,omitempty"`
+
+	threshold *big.Rat
+}
+
+// Load reads and validates a YAML (or JSON) rules file.
+func Load(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read rules: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a YAML (or JSON) rules file. Decoding goes
+// straight through yaml.v3 so numbers can be written unquoted.
+func Parse(data []byte) (*RuleSet, error) {
+	var rs RuleSet
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&rs); err != nil {
+		return nil, fmt.Errorf("parse rules: %w", err)
+	}
+	if len(rs.Rules) == 0 {
+		return nil, fmt.Errorf("rules: no rules defined")
+	}
+	for i := range rs.Rules {
+		r := &rs.Rules[i]
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if err := r.compile(); err != nil {
+			return nil, fmt.Errorf("rules: %s: %w", r.Name, err)
+		}
+	}
+	return &rs, nil
+}
+
+func (r *Rule) compile() error {
+	if r.LookbackDays == 0 {
+		r.LookbackDays = DefaultLookbackDays
+	}
+	if r.LookbackDays < 1 || r.LookbackDays > 90 {
+		return fmt.Errorf("lookbackDays must be between 1 and 90")
+	}
+	if len(r.When) == 0 {
+		return fmt.Errorf("at least one condition is required")
+	}
+	for i := range r.When {
+		if err := r.When[i].compile(); err != nil {
+			return fmt.Errorf("condition %d: %w", i+1, err)
+		}
+	}
+
+	v, pct, err := parseNumber(r.Adjust)
+	if err != nil || v.Sign() == 0 {
+		return fmt.Errorf("invalid adjust %q (want e.g. -10%% or +0.05)", r.Adjust)
+	}
+	if pct && v.Cmp(big.NewRat(-1, 1)) <= 0 {
+		return fmt.Errorf("adjust %q would remove the whole bid", r.Adjust)
+	}
+	r.adjust, r.adjustPct = v, pct
+
+	for _, f := range []struct {
+		name string
+		raw  string
+		dst  **big.Rat
+	}{{"floor", r.Floor, &r.floor}, {"ceiling", r.Ceiling, &r.ceiling}, {"maxChange", r.MaxChange, &r.maxChange}} {
+		if f.raw == "" {
+			continue
+		}
+		v, pct, err := parseNumber(f.raw)
+		if err != nil || pct || v.Sign() <= 0 {
+			return fmt.Errorf("invalid %s %q (want a positive amount)", f.name, f.raw)
+		}
+		*f.dst = v
+	}
+	if r.floor != nil && r.ceiling != nil && r.floor.Cmp(r.ceiling) > 0 {
+		return fmt.Errorf("floor %s is above ceiling %s", r.Floor, r.Ceiling)
+	}
+	return nil
+}
+
+func (c *Condition) compile() error {
+	if !knownMetric(c.Metric) {
+		return fmt.Errorf("unknown metric %q (want one of: %s)", c.Metric, strings.Join(Metrics, ", "))
+	}
+	switch c.Op {
+	case ">", ">=", "<", "<=", "==":
+	default:
+		return fmt.Errorf("invalid op %q (want >, >=, <, <= or ==)", c.Op)
+	}
+	v, _, err := parseNumber(c.Value)
+	if err != nil {
+		return fmt.Errorf("invalid value %q", c.Value)
+	}
+	if c.By != "" {
+		by, pct, err := parseNumber(c.By)
+		if err != nil || !pct || by.Sign() < 0 {
+			return fmt.Errorf("invalid by %q (want a percentage such as 20%%)", c.By)
+		}
+		switch c.Op {
+		case ">", ">=":
+			by.Add(big.NewRat(1, 1), by)
+		case "<", "<=":
+			by.Sub(big.NewRat(1, 1), by)
+		default:
+			return fmt.Errorf("by cannot be used with ==")
+		}
+		v.Mul(v, by)
+	}
+	c.threshold = v
+	return nil
+}
+
+func knownMetric(name string) bool {
+	for _, m := range Metrics {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNumber parses a signed decimal, optionally followed by % (returned as
+// a fraction with pct set).
+func parseNumber(s string) (v *big.Rat, pct bool, err error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "%") {
+		s, pct = strings.TrimSpace(strings.TrimSuffix(s, "%")), true
+	}
+	v, ok := new(big.Rat).SetString(strings.TrimPrefix(s, "+"))
+	if !ok || s == "" {
+		return nil, false, fmt.Errorf("invalid number %q", s)
+	}
+	if pct {
+		v.Quo(v, big.NewRat(100, 1))
+	}
+	return v, pct, nil
+}
+
+// Lookbacks returns the distinct lookback windows the rules use, in order of
+// first use.
+func (rs *RuleSet) Lookbacks() []int {
+	var out []int
+	seen := map[int]bool{}
+	for _, r := range rs.Rules {
+		if !seen[r.LookbackDays] {
+			seen[r.LookbackDays] = true
+			out = append(out, r.LookbackDays)
+		}
+	}
+	return out
+}
+
+// Match reports whether every condition holds for row. A condition on a rate
+// or average whose denominator is zero (avgCPA without installs) never
+// holds.
+func (r *Rule) Match(row *types.SpendRow) bool {
+	if row == nil {
+		return false
+	}
+	for _, c := range r.When {
+		v := metric(row, c.Metric)
+		if v == nil {
+			return false
+		}
+		cmp := v.Cmp(c.threshold)
+		var ok bool
+		switch c.Op {
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case "==":
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func metric(row *types.SpendRow, name string) *big.Rat {
+	count := func(n int64) *big.Rat { return new(big.Rat).SetInt64(n) }
+	ratio := func(num *big.Rat, den int64) *big.Rat {
+		if num == nil || den == 0 {
+			return nil
+		}
+		return num.Quo(num, count(den))
+	}
+	switch name {
+	case "impressions":
+		return count(row.Impressions)
+	case "taps":
+		return count(row.Taps)
+	case "installs":
+		return count(row.Installs)
+	case "newDownloads":
+		return count(row.NewDownloads)
+	case "redownloads":
+		return count(row.Redownloads)
+	case "localSpend":
+		return spend(row)
+	case "avgCPA":
+		return ratio(spend(row), row.Installs)
+	case "avgCPT":
+		return ratio(spend(row), row.Taps)
+	case "ttr":
+		return ratio(count(row.Taps), row.Impressions)
+	case "conversionRate":
+		return ratio(count(row.Installs), row.Taps)
+	}
+	return nil
+}
+
+func spend(row *types.SpendRow) *big.Rat {
+	if row.LocalSpend == nil || row.LocalSpend.Amount == "" {
+		return new(big.Rat)
+	}
+	v, ok := new(big.Rat).SetString(row.LocalSpend.Amount)
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+// Limits on a single change that hold across all rules. A nil field means no
+// limit.
+type Limits struct {
+	MaxIncreasePct *big.Rat // fraction of the current bid, e.g. 0.25
+	MaxDecreasePct *big.Rat
+}
+
+// Apply returns the bid r sets for a keyword currently bidding bid, with the
+// same currency and decimal places. limit names the bound that shaped the
+// result (maxChange, maxIncrease, maxDecrease, floor or ceiling), if any.
+// Floors and ceilings only hold a bid back; they never move a bid that is
+// already beyond them in the rule's direction.
+func (r *Rule) Apply(bid *types.Money, lim Limits) (next *types.Money, limit string, err error) {
+	old, scale, err := parseBid(bid)
+	if err != nil {
+		return nil, "", err
+	}
+
+	delta := new(big.Rat).Set(r.adjust)
+	if r.adjustPct {
+		delta.Mul(delta, old)
+	}
+	if capAbs(delta, r.maxChange) {
+		limit = "maxChange"
+	}
+	if l := lim.clamp(old, delta); l != "" {
+		limit = l
+	}
+
+	// Bounds are checked on the bid as it will be sent.
+	v := roundBid(new(big.Rat).Add(old, delta), scale)
+	if r.adjust.Sign() < 0 && r.floor != nil && v.Cmp(r.floor) < 0 {
+		v.Set(r.floor)
+		if v.Cmp(old) > 0 {
+			v.Set(old)
+		}
+		limit = "floor"
+	}
+	if r.adjust.Sign() > 0 && r.ceiling != nil && v.Cmp(r.ceiling) > 0 {
+		v.Set(r.ceiling)
+		if v.Cmp(old) < 0 {
+			v.Set(old)
+		}
+		limit = "ceiling"
+	}
+	amount := v.FloatString(scale)
+	if roundBid(v, scale).Sign() <= 0 {
+		return nil, "", fmt.Errorf("rule would set a bid of %s", amount)
+	}
+	return &types.Money{Amount: amount, Currency: bid.Currency}, limit, nil
+}
+
+// Toward returns the bid that moves bid to target as far as lim allows, with
+// the currency and decimal places of bid. limit is maxIncrease or
+// maxDecrease when a cap held the bid back.
+func Toward(bid, target *types.Money, lim Limits) (next *types.Money, limit string, err error) {
+	old, scale, err := parseBid(bid)
+	if err != nil {
+		return nil, "", err
+	}
+	if target == nil || target.Amount == "" {
+		return nil, "", fmt.Errorf("no target bid")
+	}
+	if target.Currency != "" && bid.Currency != "" && !strings.EqualFold(target.Currency, bid.Currency) {
+		return nil, "", fmt.Errorf("target bid is in %s, current bid in %s", target.Currency, bid.Currency)
+	}
+	v, ok := new(big.Rat).SetString(target.Amount)
+	if !ok || v.Sign() <= 0 {
+		return nil, "", fmt.Errorf("invalid target bid %q", target.Amount)
+	}
+	delta := v.Sub(v, old)
+	limit = lim.clamp(old, delta)
+	v = roundBid(delta.Add(delta, old), scale)
+	amount := v.FloatString(scale)
+	if v.Sign() <= 0 {
+		return nil, "", fmt.Errorf("target would set a bid of %s", amount)
+	}
+	return &types.Money{Amount: amount, Currency: bid.Currency}, limit, nil
+}
+
+// roundBid rounds v to scale decimal places, as FloatString does.
+func roundBid(v *big.Rat, scale int) *big.Rat {
+	r, _ := new(big.Rat).SetString(v.FloatString(scale))
+	return r
+}
+
+// clamp shortens delta, a change to old, to the percentage caps in lim and
+// returns the name of the cap it hit.
+func (lim Limits) clamp(old, delta *big.Rat) string {
+	switch {
+	case delta.Sign() > 0 && lim.MaxIncreasePct != nil:
+		if capAbs(delta, new(big.Rat).Mul(old, lim.MaxIncreasePct)) {
+			return "maxIncrease"
+		}
+	case delta.Sign() < 0 && lim.MaxDecreasePct != nil:
+		if capAbs(delta, new(big.Rat).Mul(old, lim.MaxDecreasePct)) {
+			return "maxDecrease"
+		}
+	}
+	return ""
+}
+
+// capAbs limits the magnitude of delta to max, keeping its sign, and reports
+// whether it had to. A nil max is no limit.
+func capAbs(delta, max *big.Rat) bool {
+	if max == nil || new(big.Rat).Abs(delta).Cmp(max) <= 0 {
+		return false
+	}
+	if delta.Sign() < 0 {
+		delta.Neg(max)
+	} else {
+		delta.Set(max)
+	}
+	return true
+}
+
+// parseBid parses a current bid and the decimal places a new bid keeps.
+func parseBid(bid *types.Money) (*big.Rat, int, error) {
+	if bid == nil || bid.Amount == "" {
+		return nil, 0, fmt.Errorf("no current bid")
+	}
+	v, ok := new(big.Rat).SetString(bid.Amount)
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid current bid %q", bid.Amount)
+	}
+	return v, bidScale(bid), nil
+}
+
+// zeroDecimal lists bid currencies without minor units.
+var zeroDecimal = map[string]bool{"CLP": true, "ISK": true, "JPY": true, "KRW": true, "VND": true}
+
+// bidScale returns the decimal places for a new bid: those of the current
+// bid, but at least two for currencies with minor units, so a bid of "1"
+// can still move by cents.
+func bidScale(bid *types.Money) int {
+	scale := 0
+	if i := strings.IndexByte(bid.Amount, '.'); i >= 0 {
+		scale = len(bid.Amount) - i - 1
+	}
+	if !zeroDecimal[strings.ToUpper(bid.Currency)] {
+		scale = max(scale, 2)
+	}
+	return scale
+}
@@ -0,0 +1,180 @@
+package bidrules
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+const testRules = `
+rules:
+  - name: cut expensive
+    lookbackDays: 14
+    when:
+      - {metric: avgCPA, op: ">", value: 2.00, by: 20%}
+      - {metric: taps, op: ">=", value: 10}
+    adjust: -10%
+    floor: 0.50
+  - name: push converters
+    lookbackDays: 7
+    when:
+      - {metric: conversionRate, op: ">=", value: 50%}
+    adjust: +0.40
+    ceiling: 2.00
+    maxChange: 0.25
+`
+
+func TestMatchAndApply(t *testing.T) {
+	rs, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rs.Lookbacks(); len(got) != 2 || got[0] != 14 || got[1] != 7 {
+		t.Fatalf("Lookbacks = %v", got)
+	}
+	cut, push := &rs.Rules[0], &rs.Rules[1]
+
+	row := func(taps, installs int64, spend string) *types.SpendRow {
+		return &types.SpendRow{Taps: taps, Installs: installs, LocalSpend: &types.Money{Amount: spend, Currency: "USD"}}
+	}
+	// CPA 2.40 is exactly the threshold, 2.41 is above it.
+	if cut.Match(row(20, 10, "24.00")) {
+		t.Fatal("cut matched CPA 2.40")
+	}
+	if !cut.Match(row(20, 10, "24.10")) {
+		t.Fatal("cut did not match CPA 2.41")
+	}
+	if cut.Match(row(20, 0, "24.10")) {
+		t.Fatal("cut matched a keyword without installs")
+	}
+	if !push.Match(row(10, 5, "1.00")) || push.Match(row(10, 4, "1.00")) {
+		t.Fatal("push conversion rate threshold")
+	}
+
+	usd := func(a string) *types.Money { return &types.Money{Amount: a, Currency: "USD"} }
+	for _, tc := range []struct {
+		rule  *Rule
+		bid   string
+		lim   Limits
+		want  string
+		limit string
+	}{
+		{cut, "1.50", Limits{}, "1.35", ""},
+		{cut, "1", Limits{}, "0.90", ""},
+		{cut, "0.52", Limits{}, "0.50", "floor"},
+		{cut, "0.40", Limits{}, "0.40", "floor"}, // already below the floor: left alone
+		{cut, "2.00", Limits{MaxDecreasePct: big.NewRat(5, 100)}, "1.90", "maxDecrease"},
+		{push, "1.00", Limits{}, "1.25", "maxChange"},
+		{push, "1.90", Limits{}, "2.00", "ceiling"},
+		{push, "1.00", Limits{MaxIncreasePct: big.NewRat(10, 100)}, "1.10", "maxIncrease"},
+	} {
+		got, limit, err := tc.rule.Apply(usd(tc.bid), tc.lim)
+		if err != nil {
+			t.Fatalf("%s on %s: %v", tc.rule.Name, tc.bid, err)
+		}
+		if got.Amount != tc.want || got.Currency != "USD" || limit != tc.limit {
+			t.Errorf("%s on %s = %s (%s), want %s (%s)", tc.rule.Name, tc.bid, got.Amount, limit, tc.want, tc.limit)
+		}
+	}
+
+	jpy, _, err := cut.Apply(&types.Money{Amount: "105", Currency: "JPY"}, Limits{})
+	if err != nil || jpy.Amount != "95" {
+		t.Fatalf("JPY bid = %+v, %v", jpy, err)
+	}
+}
+
+// TestApplyAmountBeyondBid covers an amount adjust larger than the bid: it
+// is an error without a floor, since Apple needs a positive bid.
+func TestApplyAmountBeyondBid(t *testing.T) {
+	rs, err := Parse([]byte(`
+rules:
+  - name: cut
+    when: [{metric: taps, op: ">=", value: 1}]
+    adjust: -0.50
+  - name: cut with floor
+    when: [{metric: taps, op: ">=", value: 1}]
+    adjust: -0.50
+    floor: 0.10
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bid := &types.Money{Amount: "0.40", Currency: "USD"}
+	if got, _, err := rs.Rules[0].Apply(bid, Limits{}); err == nil || !strings.Contains(err.Error(), "-0.10") {
+		t.Fatalf("Apply = %v, %v; want an error for a bid of -0.10", got, err)
+	}
+	got, limit, err := rs.Rules[1].Apply(bid, Limits{})
+	if err != nil || got.Amount != "0.10" || limit != "floor" {
+		t.Fatalf("Apply with floor = %v (%s), %v", got, limit, err)
+	}
+}
+
+func TestApplyRejectsBidsThatRoundToZero(t *testing.T) {
+	rs, err := Parse([]byte(`
+rules:
+  - name: cut
+    when: [{metric: taps, op: ">=", value: 1}]
+    adjust: -90%
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, bid := range []*types.Money{
+		{Amount: "0.04", Currency: "USD"}, // 0.004
+		{Amount: "3", Currency: "JPY"},    // 0.3
+	} {
+		if got, _, err := rs.Rules[0].Apply(bid, Limits{}); err == nil {
+			t.Errorf("Apply(%s %s) = %v, want an error for a bid that rounds to zero", bid.Amount, bid.Currency, got)
+		}
+	}
+	if got, _, err := rs.Rules[0].Apply(&types.Money{Amount: "0.05", Currency: "USD"}, Limits{}); err != nil || got.Amount != "0.01" {
+		t.Errorf("Apply(0.05) = %v, %v; want 0.01", got, err)
+	}
+}
+
+func TestToward(t *testing.T) {
+	usd := func(a string) *types.Money { return &types.Money{Amount: a, Currency: "USD"} }
+	lim := Limits{MaxIncreasePct: big.NewRat(20, 100), MaxDecreasePct: big.NewRat(10, 100)}
+	for _, tc := range []struct{ bid, target, want, limit string }{
+		{"1.00", "1.15", "1.15", ""},
+		{"1.00", "1.50", "1.20", "maxIncrease"},
+		{"1.00", "0.95", "0.95", ""},
+		{"1.00", "0.50", "0.90", "maxDecrease"},
+		{"1.5", "1.333", "1.35", "maxDecrease"},
+	} {
+		got, limit, err := Toward(usd(tc.bid), usd(tc.target), lim)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Amount != tc.want || limit != tc.limit {
+			t.Errorf("Toward(%s, %s) = %s (%s), want %s (%s)", tc.bid, tc.target, got.Amount, limit, tc.want, tc.limit)
+		}
+	}
+	if _, _, err := Toward(usd("1.00"), &types.Money{Amount: "1.20", Currency: "EUR"}, lim); err == nil {
+		t.Fatal("Toward accepted a target in another currency")
+	}
+	if got, _, err := Toward(usd("0.01"), usd("0.004"), Limits{}); err == nil {
+		t.Fatalf("Toward(0.01, 0.004) = %v, want an error for a bid that rounds to zero", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct{ doc, want string }{
+		{`rules: []`, "no rules"},
+		{`rules: [{when: [{metric: cpa, op: ">", value: 1}], adjust: -10%}]`, "unknown metric"},
+		{`rules: [{when: [{metric: taps, op: "!=", value: 1}], adjust: -10%}]`, "invalid op"},
+		{`rules: [{when: [{metric: taps, op: "==", value: 1, by: 10%}], adjust: -10%}]`, "by cannot"},
+		{`rules: [{when: [{metric: taps, op: ">", value: 1}], adjust: 0}]`, "invalid adjust"},
+		{`rules: [{when: [{metric: taps, op: ">", value: 1}], adjust: -100%}]`, "whole bid"},
+		{`rules: [{when: [{metric: taps, op: ">", value: 1}], adjust: -10%, floor: 2, ceiling: 1}]`, "above ceiling"},
+		{`rules: [{adjust: -10%}]`, "condition is required"},
+		{`rules: [{when: [{metric: taps, op: ">", value: 1}], adjust: -10%, typo: 1}]`, "typo"},
+	} {
+		_, err := Parse([]byte(tc.doc))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Parse(%s) error = %v, want %q", tc.doc, err, tc.want)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"bufio"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -15,17 +17,38 @@ const (
 	configFile = "config.yaml"
 )
 
-type Config struct {
-	ClientID       string `yaml:"client_id"`
-	TeamID         string `yaml:"team_id"`
-	KeyID          string `yaml:"key_id"`
-	OrgID          string `yaml:"org_id"`
-	PrivateKeyPath string `yaml:"private_key_path"`
+// Credentials holds one API user's settings. The top level of config.yaml is
+// the default set; named profiles under "profiles:" hold additional sets.
+type Credentials struct {
+	ClientID       string `yaml:"client_id,omitempty"`
+	TeamID         string `yaml:"team_id,omitempty"`
+	KeyID          string `yaml:"key_id,omitempty"`
+	OrgID          string `yaml:"org_id,omitempty"`
+	PrivateKeyPath string `yaml:"private_key_path,omitempty"`
 	// DefaultCurrency is used for Money fields when the CLI builds requests from flags.
 	// If empty, the CLI attempts to infer it from GET /acls.
 	DefaultCurrency string `yaml:"default_currency,omitempty"`
+	// APIBaseURL and TokenURL replace Apple's endpoints, e.g. to go through
+	// a proxy or to target `aads mock-server`. Empty means Apple's.
+	APIBaseURL string `yaml:"api_base_url,omitempty"`
+	TokenURL   string `yaml:"token_url,omitempty"`
 }
 
+type Config struct {
+	Credentials `yaml:",inline"`
+
+	// ActiveProfile is used when neither --profile nor AADS_PROFILE is set.
+	ActiveProfile string                  `yaml:"active_profile,omitempty"`
+	Profiles      map[string]*Credentials `yaml:"profiles,omitempty"`
+
+	// Profile is the profile Load resolved ("" for the top-level credentials).
+	Profile string `yaml:"-"`
+}
+
+// DefaultProfile names the top-level credentials in profile listings and
+// `aads profiles use`.
+const DefaultProfile = "default"
+
 func expandHome(path string) string {
 	if path == "" {
 		return path
@@ -49,7 +72,10 @@ func configPath() (string, error) {
 	return filepath.Join(home, configDir, configFile), nil
 }
 
-func Load() (*Config, error) {
+// LoadFile reads ~/.aads/config.yaml as stored on disk, without applying a
+// profile or env var overrides. Use it when the result will be written back
+// with Save.
+func LoadFile() (*Config, error) {
 	path, err := configPath()
 	if err != nil {
 		return nil, err
@@ -70,6 +96,50 @@ func Load() (*Config, error) {
 			return nil, fmt.Errorf("parse config: %w", err)
 		}
 	}
+	return &cfg, nil
+}
+
+// Load returns the effective config for the profile selected by AADS_PROFILE
+// or active_profile.
+func Load() (*Config, error) {
+	return LoadProfile("")
+}
+
+// ProfileName resolves the profile LoadProfile would use for name: name
+// itself, then AADS_PROFILE, then active_profile, then DefaultProfile.
+func (c *Config) ProfileName(name string) string {
+	if name == "" {
+		name = os.Getenv("AADS_PROFILE")
+	}
+	if name == "" {
+		name = c.ActiveProfile
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	return name
+}
+
+// LoadProfile returns the effective config for the named profile. An empty
+// name falls back to AADS_PROFILE, then active_profile, then the top-level
+// credentials. Profile fields override the top-level ones they set, and env
+// vars override both.
+func LoadProfile(name string) (*Config, error) {
+	cfg, err := LoadFile()
+	if err != nil {
+		return nil, err
+	}
+
+	name = cfg.ProfileName(name)
+	if name != DefaultProfile {
+		p, ok := cfg.Profiles[name]
+		if !ok || p == nil {
+			path, _ := configPath()
+			return nil, fmt.Errorf("profile %q not found in %s", name, path)
+		}
+		cfg.Credentials = mergeCredentials(cfg.Credentials, *p)
+		cfg.Profile = name
+	}
 
 	// Env var overrides
 	if v := os.Getenv("AADS_CLIENT_ID"); v != "" {
@@ -94,10 +164,45 @@ func Load() (*Config, error) {
 	if v := os.Getenv("AADS_CURRENCY"); v != "" {
 		cfg.DefaultCurrency = v
 	}
+	if v := os.Getenv("AADS_API_BASE_URL"); v != "" {
+		cfg.APIBaseURL = v
+	}
+	if v := os.Getenv("AADS_TOKEN_URL"); v != "" {
+		cfg.TokenURL = v
+	}
 
 	cfg.PrivateKeyPath = expandHome(cfg.PrivateKeyPath)
 
-	return &cfg, nil
+	return cfg, nil
+}
+
+// mergeCredentials overlays the non-empty fields of p onto base.
+func mergeCredentials(base, p Credentials) Credentials {
+	if p.ClientID != "" {
+		base.ClientID = p.ClientID
+	}
+	if p.TeamID != "" {
+		base.TeamID = p.TeamID
+	}
+	if p.KeyID != "" {
+		base.KeyID = p.KeyID
+	}
+	if p.OrgID != "" {
+		base.OrgID = p.OrgID
+	}
+	if p.PrivateKeyPath != "" {
+		base.PrivateKeyPath = p.PrivateKeyPath
+	}
+	if p.DefaultCurrency != "" {
+		base.DefaultCurrency = p.DefaultCurrency
+	}
+	if p.APIBaseURL != "" {
+		base.APIBaseURL = p.APIBaseURL
+	}
+	if p.TokenURL != "" {
+		base.TokenURL = p.TokenURL
+	}
+	return base
 }
 
 func (c *Config) ValidateAuth() error {
@@ -116,7 +221,44 @@ func (c *Config) ValidateAuth() error {
 	if _, err := os.Stat(c.PrivateKeyPath); err != nil {
 		return fmt.Errorf("private key not found at %s: %w", c.PrivateKeyPath, err)
 	}
-	return nil
+	return c.ValidateEndpoints()
+}
+
+// ValidateEndpoints checks the api_base_url and token_url overrides. Both
+// carry credentials, so plain http is only allowed for loopback hosts.
+func (c *Config) ValidateEndpoints() error {
+	if err := validateEndpoint("api_base_url", c.APIBaseURL); err != nil {
+		return err
+	}
+	return validateEndpoint("token_url", c.TokenURL)
+}
+
+func validateEndpoint(name, raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%s %q: want an absolute URL like https://host/path", name, raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%s %q: query strings and fragments are not allowed", name, raw)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host == "localhost" || ip != nil && ip.IsLoopback() {
+			return nil
+		}
+		return fmt.Errorf("%s %q: http is only allowed for localhost; use https", name, raw)
+	default:
+		return fmt.Errorf("%s %q: scheme must be https (or http for localhost)", name, raw)
+	}
 }
 
 func (c *Config) Validate() error {
@@ -151,39 +293,77 @@ func Save(cfg *Config) error {
 	return nil
 }
 
-func RunInteractiveSetup() error {
+// RunInteractiveSetup prompts for credentials and saves them as the top-level
+// config, or into profiles[profile] when profile is set. An empty profile
+// falls back to AADS_PROFILE, like LoadProfile; active_profile is not used, so
+// a plain `aads configure` still edits the top-level credentials. Other
+// profiles in the file are kept as-is.
+func RunInteractiveSetup(profile string) error {
 	reader := bufio.NewReader(os.Stdin)
 
-	// Try loading existing config for defaults
-	existing, _ := Load()
-	if existing == nil {
-		existing = &Config{}
+	if profile == "" {
+		profile = os.Getenv("AADS_PROFILE")
+	}
+	if profile == DefaultProfile {
+		profile = ""
+	}
+
+	file, err := LoadFile()
+	if err != nil {
+		return err
+	}
+
+	// Use the existing entry for defaults
+	existing := file.Credentials
+	if profile != "" {
+		existing = Credentials{}
+		if p := file.Profiles[profile]; p != nil {
+			existing = *p
+		}
 	}
 
-	cfg := &Config{}
+	creds := Credentials{}
 
-	cfg.ClientID = prompt(reader, "Client ID", existing.ClientID)
-	cfg.TeamID = prompt(reader, "Team ID", existing.TeamID)
-	cfg.KeyID = prompt(reader, "Key ID", existing.KeyID)
-	cfg.OrgID = prompt(reader, "Org ID", existing.OrgID)
-	cfg.PrivateKeyPath = prompt(reader, "Private Key Path", existing.PrivateKeyPath)
-	cfg.DefaultCurrency = prompt(reader, "Default Currency (optional, e.g. USD)", existing.DefaultCurrency)
+	creds.ClientID = prompt(reader, "Client ID", existing.ClientID)
+	creds.TeamID = prompt(reader, "Team ID", existing.TeamID)
+	creds.KeyID = prompt(reader, "Key ID", existing.KeyID)
+	creds.OrgID = prompt(reader, "Org ID", existing.OrgID)
+	creds.PrivateKeyPath = prompt(reader, "Private Key Path", existing.PrivateKeyPath)
+	creds.DefaultCurrency = prompt(reader, "Default Currency (optional, e.g. USD)", existing.DefaultCurrency)
 
 	// Expand ~ in path
-	if strings.HasPrefix(cfg.PrivateKeyPath, "~/") {
+	if strings.HasPrefix(creds.PrivateKeyPath, "~/") {
 		home, _ := os.UserHomeDir()
-		cfg.PrivateKeyPath = filepath.Join(home, cfg.PrivateKeyPath[2:])
+		creds.PrivateKeyPath = filepath.Join(home, creds.PrivateKeyPath[2:])
 	}
 
-	if err := cfg.Validate(); err != nil {
+	// Profiles inherit unset fields from the top level, so validate the merged result.
+	effective := &Config{Credentials: creds}
+	if profile != "" {
+		effective.Credentials = mergeCredentials(file.Credentials, creds)
+	}
+	if err := effective.Validate(); err != nil {
 		return fmt.Errorf("invalid config: %w", err)
 	}
 
-	if err := Save(cfg); err != nil {
+	if profile == "" {
+		file.Credentials = creds
+	} else {
+		if file.Profiles == nil {
+			file.Profiles = make(map[string]*Credentials)
+		}
+		file.Profiles[profile] = &creds
+	}
+
+	if err := Save(file); err != nil {
 		return err
 	}
 
 	path, _ := configPath()
+	if profile != "" {
+		fmt.Printf("Profile %q saved to %s\n", profile, path)
+		return nil
+	}
 	fmt.Printf("Config saved to %s\n", path)
 	return nil
 }
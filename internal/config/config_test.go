@@ -0,0 +1,265 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testConfig = `client_id: SEARCHADS.top
+team_id: SEARCHADS.team
+key_id: top-key
+org_id: "100"
+private_key_path: /keys/top.pem
+default_currency: USD
+active_profile: emea
+profiles:
+  emea:
+    org_id: "200"
+    default_currency: EUR
+  apac:
+    client_id: SEARCHADS.apac
+    key_id: apac-key
+    org_id: "300"
+    private_key_path: /keys/apac.pem
+`
+
+// useConfig points HOME at a temp dir holding data as ~/.aads/config.yaml
+// and clears the env vars LoadProfile reads.
+func useConfig(t *testing.T, data string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, k := range []string{
+		"AADS_PROFILE", "AADS_CLIENT_ID", "AADS_TEAM_ID", "AADS_KEY_ID", "AADS_ORG_ID",
+		"AADS_PRIVATE_KEY_PATH", "AADS_DEFAULT_CURRENCY", "AADS_CURRENCY",
+		"AADS_API_BASE_URL", "AADS_TOKEN_URL",
+	} {
+		t.Setenv(k, "")
+	}
+	if data != "" {
+		dir := filepath.Join(home, configDir)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, configFile), []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return home
+}
+
+func TestLoadProfileSelection(t *testing.T) {
+	tests := []struct {
+		name        string
+		flag, env   string
+		active      bool
+		wantProfile string
+		wantOrgID   string
+	}{
+		{name: "active_profile", active: true, wantProfile: "emea", wantOrgID: "200"},
+		{name: "no active_profile", wantProfile: "", wantOrgID: "100"},
+		{name: "env over active_profile", env: "apac", active: true, wantProfile: "apac", wantOrgID: "300"},
+		{name: "flag over env", flag: "emea", env: "apac", active: true, wantProfile: "emea", wantOrgID: "200"},
+		{name: "default flag over env", flag: DefaultProfile, env: "apac", active: true, wantProfile: "", wantOrgID: "100"},
+		{name: "default env over active_profile", env: DefaultProfile, active: true, wantProfile: "", wantOrgID: "100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := testConfig
+			if !tt.active {
+				data = strings.Replace(data, "active_profile: emea\n", "", 1)
+			}
+			useConfig(t, data)
+			t.Setenv("AADS_PROFILE", tt.env)
+
+			cfg, err := LoadProfile(tt.flag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Profile != tt.wantProfile || cfg.OrgID != tt.wantOrgID {
+				t.Errorf("profile %q org %q, want %q org %q", cfg.Profile, cfg.OrgID, tt.wantProfile, tt.wantOrgID)
+			}
+		})
+	}
+}
+
+func TestLoadProfileMergesCredentials(t *testing.T) {
+	useConfig(t, testConfig)
+
+	cfg, err := LoadProfile("emea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Credentials{
+		ClientID:        "SEARCHADS.top",
+		TeamID:          "SEARCHADS.team",
+		KeyID:           "top-key",
+		OrgID:           "200",
+		PrivateKeyPath:  "/keys/top.pem",
+		DefaultCurrency: "EUR",
+	}
+	if cfg.Credentials != want {
+		t.Errorf("emea credentials = %+v, want %+v", cfg.Credentials, want)
+	}
+
+	cfg, err = LoadProfile("apac")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = Credentials{
+		ClientID:        "SEARCHADS.apac",
+		TeamID:          "SEARCHADS.team",
+		KeyID:           "apac-key",
+		OrgID:           "300",
+		PrivateKeyPath:  "/keys/apac.pem",
+		DefaultCurrency: "USD",
+	}
+	if cfg.Credentials != want {
+		t.Errorf("apac credentials = %+v, want %+v", cfg.Credentials, want)
+	}
+
+	// Env vars override the selected profile.
+	t.Setenv("AADS_ORG_ID", "999")
+	t.Setenv("AADS_CURRENCY", "GBP")
+	cfg, err = LoadProfile("emea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.OrgID != "999" || cfg.DefaultCurrency != "GBP" || cfg.KeyID != "top-key" {
+		t.Errorf("with env overrides got %+v", cfg.Credentials)
+	}
+}
+
+func TestLoadProfileNotFound(t *testing.T) {
+	useConfig(t, testConfig)
+
+	if _, err := LoadProfile("missing"); err == nil || !strings.Contains(err.Error(), `profile "missing" not found`) {
+		t.Errorf("LoadProfile(missing) error = %v", err)
+	}
+
+	t.Setenv("AADS_PROFILE", "missing")
+	if _, err := LoadProfile(""); err == nil {
+		t.Error("LoadProfile with AADS_PROFILE=missing: want error")
+	}
+}
+
+func TestLoadWithoutConfigFile(t *testing.T) {
+	useConfig(t, "")
+	t.Setenv("AADS_CLIENT_ID", "SEARCHADS.env")
+	t.Setenv("AADS_ORG_ID", "42")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Profile != "" || cfg.ClientID != "SEARCHADS.env" || cfg.OrgID != "42" {
+		t.Errorf("env-only config = %+v", cfg)
+	}
+}
+
+func TestRunInteractiveSetupUsesEnvProfile(t *testing.T) {
+	home := useConfig(t, testConfig)
+	t.Setenv("AADS_PROFILE", "apac")
+
+	key := filepath.Join(home, "apac.pem")
+	if err := os.WriteFile(key, []byte("key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// Client ID, Team ID, Key ID, Org ID, Private Key Path, Default Currency;
+	// empty answers keep the existing value.
+	answers := "\n\n\n301\n" + key + "\n\n"
+	withStdio(t, answers, func() {
+		if err := RunInteractiveSetup(""); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	cfg, err := LoadFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.OrgID != "100" {
+		t.Errorf("top-level org_id = %q, want it unchanged", cfg.OrgID)
+	}
+	p := cfg.Profiles["apac"]
+	if p == nil || p.OrgID != "301" || p.ClientID != "SEARCHADS.apac" || p.PrivateKeyPath != key {
+		t.Errorf("apac profile = %+v", p)
+	}
+	if cfg.Profiles["emea"] == nil || cfg.ActiveProfile != "emea" {
+		t.Errorf("other profiles or active_profile changed: %+v", cfg)
+	}
+}
+
+// withStdio runs fn with input on stdin and stdout discarded.
+func withStdio(t *testing.T, input string, fn func()) {
+	t.Helper()
+	in, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	if _, err := in.WriteString(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := in.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	oldIn, oldOut := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = in, out
+	defer func() { os.Stdin, os.Stdout = oldIn, oldOut }()
+	fn()
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	tests := []struct {
+		raw     string
+		wantErr string
+	}{
+		{raw: ""},
+		{raw: "https://api.searchads.apple.com/api/v5"},
+		{raw: "https://proxy.example.com:8443/apple"},
+		{raw: "http://localhost:8080/api/v5"},
+		{raw: "http://127.0.0.1:8080/api/v5"},
+		{raw: "http://[::1]:8080/api/v5"},
+		{raw: "http://proxy.example.com/api/v5", wantErr: "http is only allowed for localhost"},
+		{raw: "http://localhost.example.com/", wantErr: "http is only allowed for localhost"},
+		{raw: "ftp://localhost/", wantErr: "scheme must be https"},
+		{raw: "api.searchads.apple.com/api/v5", wantErr: "want an absolute URL"},
+		{raw: "https://api.example.com/v5?key=1", wantErr: "query strings and fragments"},
+		{raw: "https://api.example.com/v5#frag", wantErr: "query strings and fragments"},
+		{raw: "https://bad host/", wantErr: "invalid character"},
+	}
+	for _, tt := range tests {
+		err := validateEndpoint("api_base_url", tt.raw)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("validateEndpoint(%q) = %v, want nil", tt.raw, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("validateEndpoint(%q) = %v, want error containing %q", tt.raw, err, tt.wantErr)
+		case err != nil && !strings.HasPrefix(err.Error(), "api_base_url"):
+			t.Errorf("validateEndpoint(%q) = %v, want the key name first", tt.raw, err)
+		}
+	}
+}
+
+func TestValidateAuthChecksEndpoints(t *testing.T) {
+	key := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(key, []byte("key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{Credentials: Credentials{
+		ClientID: "c", TeamID: "t", KeyID: "k", PrivateKeyPath: key,
+		TokenURL: "http://auth.example.com/token",
+	}}
+	if err := cfg.ValidateAuth(); err == nil || !strings.HasPrefix(err.Error(), "token_url") {
+		t.Errorf("ValidateAuth() = %v, want a token_url error", err)
+	}
+}
@@ -0,0 +1,92 @@
+// Package harvest picks search terms worth promoting to exact-match keywords
+// from a search terms report, for `aads harvest`.
+package harvest
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/keywords"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/report"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// Thresholds a search term must meet to be harvested. MaxCPA is optional.
+type Thresholds struct {
+	MinInstalls int64
+	MinTaps     int64
+	MaxCPA      *big.Rat
+}
+
+// Term is a search term with its metrics summed over every report row it
+// appeared in.
+type Term struct {
+	Text     string       `json:"searchTerm"`
+	Taps     int64        `json:"taps"`
+	Installs int64        `json:"installs"`
+	Spend    *types.Money `json:"localSpend,omitempty"`
+	AvgCPA   *types.Money `json:"avgCPA,omitempty"`
+}
+
+// Candidates returns the search terms in data that meet th, most installs
+// first. Rows for the same term (from different keywords or ad groups) are
+// combined before the thresholds are applied. Rows without search term text,
+// which Apple uses for low-volume terms, are ignored.
+func Candidates(data *types.ReportingDataResponse, th Thresholds) []Term {
+	type agg struct {
+		text string
+		sum  report.Sum
+	}
+	var order []string
+	byText := map[string]*agg{}
+	for _, r := range data.Row {
+		text, _ := r.Metadata["searchTermText"].(string)
+		key := keywords.Normalize(text)
+		if key == "" || r.Total == nil {
+			continue
+		}
+		a := byText[key]
+		if a == nil {
+			a = &agg{text: strings.TrimSpace(text)}
+			byText[key] = a
+			order = append(order, key)
+		}
+		a.sum.Add(r.Total)
+	}
+
+	var out []Term
+	for _, key := range order {
+		a := byText[key]
+		row := a.sum.Row()
+		if row.Installs < th.MinInstalls || row.Taps < th.MinTaps {
+			continue
+		}
+		if th.MaxCPA != nil && !withinCPA(row, th.MaxCPA) {
+			continue
+		}
+		t := Term{Text: a.text, Taps: row.Taps, Installs: row.Installs, Spend: row.LocalSpend}
+		if row.Installs > 0 {
+			t.AvgCPA = row.AvgCPA
+		}
+		out = append(out, t)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Installs > out[j].Installs })
+	return out
+}
+
+// withinCPA compares spend/installs with max exactly. Terms without installs
+// have no CPA and never qualify.
+func withinCPA(row *types.SpendRow, max *big.Rat) bool {
+	if row.Installs == 0 {
+		return false
+	}
+	spend := new(big.Rat)
+	if row.LocalSpend != nil && row.LocalSpend.Amount != "" {
+		if _, ok := spend.SetString(row.LocalSpend.Amount); !ok {
+			return false
+		}
+	}
+	cpa := spend.Quo(spend, new(big.Rat).SetInt64(row.Installs))
+	return cpa.Cmp(max) <= 0
+}
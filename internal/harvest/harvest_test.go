@@ -0,0 +1,39 @@
+package harvest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func TestCandidates(t *testing.T) {
+	row := func(term string, taps, installs int64, spend string) types.ReportRow {
+		return types.ReportRow{
+			Metadata: map[string]any{"searchTermText": term},
+			Total:    &types.SpendRow{Taps: taps, Installs: installs, LocalSpend: &types.Money{Amount: spend, Currency: "USD"}},
+		}
+	}
+	data := &types.ReportingDataResponse{Row: []types.ReportRow{
+		row("photo editor", 20, 4, "8.00"),
+		row("Photo  Editor", 10, 2, "4.00"), // same term from another ad group
+		row("cheap app", 30, 3, "30.00"),
+		row("rare term", 2, 1, "0.50"),
+		row("", 100, 50, "10.00"), // low-volume bucket
+	}}
+
+	got := Candidates(data, Thresholds{MinInstalls: 2, MinTaps: 5, MaxCPA: big.NewRat(5, 1)})
+	if len(got) != 1 {
+		t.Fatalf("got %d candidates %+v, want 1", len(got), got)
+	}
+	c := got[0]
+	if c.Text != "photo editor" || c.Taps != 30 || c.Installs != 6 || c.Spend.Amount != "12.00" || c.AvgCPA.Amount != "2.00" {
+		t.Fatalf("unexpected candidate: %+v", c)
+	}
+
+	// Without a CPA cap the expensive term qualifies too, ranked by installs.
+	got = Candidates(data, Thresholds{MinInstalls: 2})
+	if len(got) != 2 || got[0].Text != "photo editor" || got[1].Text != "cheap app" {
+		t.Fatalf("unexpected candidates: %+v", got)
+	}
+}
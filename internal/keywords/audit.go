@@ -0,0 +1,245 @@
+package keywords
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/account"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// Finding kinds reported by Audit, in report order.
+const (
+	KindDuplicate = "duplicate"
+	KindBlocked   = "blocked"
+	KindOverlap   = "overlap"
+	KindNormalize = "normalize"
+)
+
+// Fix actions a Finding may carry.
+const (
+	FixPauseKeyword   = "pause-keyword"
+	FixDeleteNegative = "delete-negative"
+	FixAddNegative    = "add-negative"
+)
+
+// Finding is one problem found by Audit. KeywordID is set for findings about
+// a targeting keyword and NegativeID for findings about, or caused by, a
+// negative keyword.
+type Finding struct {
+	Kind       string `json:"kind"`
+	CampaignID int64  `json:"campaignId"`
+	AdGroupID  int64  `json:"adGroupId,omitempty"`
+	KeywordID  int64  `json:"keywordId,omitempty"`
+	NegativeID int64  `json:"negativeId,omitempty"`
+	Text       string `json:"text"`
+	MatchType  string `json:"matchType"`
+	Detail     string `json:"detail"`
+	Fix        *Fix   `json:"fix,omitempty"`
+	Status     string `json:"status,omitempty"` // fixed or error, once the fix ran
+	Error      string `json:"error,omitempty"`
+}
+
+// Fix is the change that resolves a Finding. ID is the keyword to pause or
+// the negative to delete; Text and MatchType are the negative to add.
+// AdGroupID is 0 for campaign-level negatives.
+type Fix struct {
+	Action    string `json:"action"`
+	AdGroupID int64  `json:"adGroupId,omitempty"`
+	ID        int64  `json:"id,omitempty"`
+	Text      string `json:"text,omitempty"`
+	MatchType string `json:"matchType,omitempty"`
+}
+
+// Audit checks the keywords and negatives of one campaign for:
+//
+//   - duplicate: an active keyword with the same match type and normalized
+//     text as an older active keyword, in any ad group. Fixed by pausing it.
+//   - blocked: an active keyword whose own text is excluded by a campaign or
+//     ad group negative (see blocks). Fixed by deleting the negative when it
+//     has the same text; broader negatives are only reported.
+//   - overlap: an active BROAD keyword with the same text as an active EXACT
+//     keyword that is not a duplicate, so both can serve the same search.
+//     Fixed by adding an EXACT negative to the BROAD keyword's ad group,
+//     unless both are in the same ad group.
+//   - normalize: keyword or negative text that is not trimmed, lower case and
+//     single-spaced. The API can't change keyword text, so there is no fix.
+//
+// Paused keywords are only checked for normalization.
+func Audit(cs account.CampaignState) []Finding {
+	cid := cs.Campaign.ID
+	var kws []types.Keyword
+	adGroupNegatives := map[int64][]types.NegativeKeyword{}
+	for _, g := range cs.AdGroups {
+		for _, k := range g.Keywords {
+			if k.Deleted {
+				continue
+			}
+			k.AdGroupID = g.AdGroup.ID
+			kws = append(kws, k)
+		}
+		for _, n := range g.Negatives {
+			if !n.Deleted {
+				n.AdGroupID = g.AdGroup.ID
+				adGroupNegatives[g.AdGroup.ID] = append(adGroupNegatives[g.AdGroup.ID], n)
+			}
+		}
+	}
+	var campaignNegatives []types.NegativeKeyword
+	for _, n := range cs.Negatives {
+		if !n.Deleted {
+			n.AdGroupID = 0
+			campaignNegatives = append(campaignNegatives, n)
+		}
+	}
+
+	newFinding := func(kind string, k types.Keyword, detail string) Finding {
+		return Finding{Kind: kind, CampaignID: cid, AdGroupID: k.AdGroupID, KeywordID: k.ID, Text: k.Text, MatchType: k.MatchType, Detail: detail}
+	}
+	var out []Finding
+
+	// Duplicates: the oldest keyword (lowest ID) of each group is kept.
+	duplicate := map[int64]bool{}
+	groups := map[string][]types.Keyword{}
+	var order []string
+	for _, k := range kws {
+		if !active(k) {
+			continue
+		}
+		key := Key(0, k.MatchType, k.Text)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], k)
+	}
+	for _, key := range order {
+		g := groups[key]
+		if len(g) < 2 {
+			continue
+		}
+		sort.SliceStable(g, func(i, j int) bool { return g[i].ID < g[j].ID })
+		keep := g[0]
+		for _, k := range g[1:] {
+			f := newFinding(KindDuplicate, k, fmt.Sprintf("duplicates keyword %d in ad group %d", keep.ID, keep.AdGroupID))
+			f.Fix = &Fix{Action: FixPauseKeyword, AdGroupID: k.AdGroupID, ID: k.ID}
+			out = append(out, f)
+			duplicate[k.ID] = true
+		}
+	}
+
+	// Keywords blocked by their own negatives.
+	for _, k := range kws {
+		if !active(k) {
+			continue
+		}
+		negs := append(append([]types.NegativeKeyword(nil), campaignNegatives...), adGroupNegatives[k.AdGroupID]...)
+		for _, n := range negs {
+			if !blocks(n, k) {
+				continue
+			}
+			level := "campaign"
+			if n.AdGroupID != 0 {
+				level = "ad group"
+			}
+			f := newFinding(KindBlocked, k, fmt.Sprintf("blocked by %s %s negative %q", level, strings.ToUpper(n.MatchType), n.Text))
+			f.NegativeID = n.ID
+			if Normalize(n.Text) == Normalize(k.Text) {
+				f.Fix = &Fix{Action: FixDeleteNegative, AdGroupID: n.AdGroupID, ID: n.ID}
+			}
+			out = append(out, f)
+		}
+	}
+
+	// BROAD keywords that overlap an EXACT keyword with the same text.
+	// Duplicates are left out since their fix pauses them.
+	exact := map[string][]types.Keyword{}
+	for _, k := range kws {
+		if active(k) && !duplicate[k.ID] && strings.EqualFold(k.MatchType, "EXACT") {
+			exact[Normalize(k.Text)] = append(exact[Normalize(k.Text)], k)
+		}
+	}
+	for _, k := range kws {
+		text := Normalize(k.Text)
+		if !active(k) || !strings.EqualFold(k.MatchType, "BROAD") || len(exact[text]) == 0 {
+			continue
+		}
+		if hasExactNegative(campaignNegatives, text) || hasExactNegative(adGroupNegatives[k.AdGroupID], text) {
+			continue
+		}
+		var other, same *types.Keyword
+		for i, e := range exact[text] {
+			if e.AdGroupID == k.AdGroupID {
+				same = &exact[text][i]
+			} else if other == nil {
+				other = &exact[text][i]
+			}
+		}
+		// An EXACT negative in the same ad group would block its EXACT
+		// keyword too.
+		if same != nil {
+			out = append(out, newFinding(KindOverlap, k, fmt.Sprintf("EXACT keyword %d in the same ad group has the same text", same.ID)))
+			continue
+		}
+		f := newFinding(KindOverlap, k, fmt.Sprintf("EXACT keyword %d in ad group %d has the same text", other.ID, other.AdGroupID))
+		f.Fix = &Fix{Action: FixAddNegative, AdGroupID: k.AdGroupID, Text: text, MatchType: "EXACT"}
+		out = append(out, f)
+	}
+
+	// Text that needs normalizing.
+	for _, k := range kws {
+		if n := Normalize(k.Text); n != k.Text {
+			out = append(out, newFinding(KindNormalize, k, fmt.Sprintf("normalized text is %q", n)))
+		}
+	}
+	negs := append([]types.NegativeKeyword(nil), campaignNegatives...)
+	for _, g := range cs.AdGroups {
+		negs = append(negs, adGroupNegatives[g.AdGroup.ID]...)
+	}
+	for _, neg := range negs {
+		if n := Normalize(neg.Text); n != neg.Text {
+			out = append(out, Finding{
+				Kind: KindNormalize, CampaignID: cid, AdGroupID: neg.AdGroupID, NegativeID: neg.ID,
+				Text: neg.Text, MatchType: neg.MatchType, Detail: fmt.Sprintf("negative; normalized text is %q", n),
+			})
+		}
+	}
+	return out
+}
+
+func active(k types.Keyword) bool {
+	return k.Status == "" || k.Status == "ACTIVE"
+}
+
+// blocks reports whether negative n excludes the search for k's own text: a
+// BROAD negative when the text contains all of its words, an EXACT one when
+// the texts are equal and k is EXACT too. An EXACT negative leaves a BROAD
+// keyword serving other searches; it is how overlaps are avoided.
+func blocks(n types.NegativeKeyword, k types.Keyword) bool {
+	negText, text := Normalize(n.Text), Normalize(k.Text)
+	if negText == "" {
+		return false
+	}
+	if strings.EqualFold(n.MatchType, "EXACT") {
+		return negText == text && strings.EqualFold(k.MatchType, "EXACT")
+	}
+	words := map[string]bool{}
+	for _, w := range strings.Fields(text) {
+		words[w] = true
+	}
+	for _, w := range strings.Fields(negText) {
+		if !words[w] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasExactNegative(negs []types.NegativeKeyword, text string) bool {
+	for _, n := range negs {
+		if strings.EqualFold(n.MatchType, "EXACT") && Normalize(n.Text) == text {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package keywords
+
+import (
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/account"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func TestAudit(t *testing.T) {
+	kw := func(id int64, text, matchType, status string) types.Keyword {
+		return types.Keyword{ID: id, Text: text, MatchType: matchType, Status: status}
+	}
+	neg := func(id int64, text, matchType string) types.NegativeKeyword {
+		return types.NegativeKeyword{ID: id, Text: text, MatchType: matchType}
+	}
+	cs := account.CampaignState{
+		Campaign:  types.Campaign{ID: 1},
+		Negatives: []types.NegativeKeyword{neg(50, "free", "BROAD")},
+		AdGroups: []account.AdGroupState{
+			{
+				AdGroup: types.AdGroup{ID: 10},
+				Keywords: []types.Keyword{
+					kw(100, "photo editor", "EXACT", "ACTIVE"),
+					kw(101, "collage maker", "EXACT", "ACTIVE"),
+					kw(102, "free photo editor", "EXACT", "ACTIVE"),
+					kw(103, "Retro Filters", "EXACT", "PAUSED"),
+				},
+			},
+			{
+				AdGroup: types.AdGroup{ID: 20},
+				Keywords: []types.Keyword{
+					kw(201, "photo editor", "BROAD", "ACTIVE"),  // overlaps 100
+					kw(202, "collage maker", "BROAD", "ACTIVE"), // isolated by negative 60
+					kw(203, "stickers", "BROAD", "ACTIVE"),
+					kw(204, "stickers", "EXACT", "ACTIVE"), // same ad group as 203
+				},
+				Negatives: []types.NegativeKeyword{
+					neg(60, "collage maker", "EXACT"),
+					neg(61, "Stickers", "EXACT"),
+				},
+			},
+			{
+				AdGroup:  types.AdGroup{ID: 30},
+				Keywords: []types.Keyword{kw(300, "photo  editor", "EXACT", "ACTIVE")}, // duplicate of 100
+			},
+		},
+	}
+
+	got := Audit(cs)
+	want := []struct {
+		kind      string
+		keywordID int64
+		fix       string
+	}{
+		{KindDuplicate, 300, FixPauseKeyword},
+		{KindBlocked, 102, ""},                // broad campaign negative "free"
+		{KindBlocked, 204, FixDeleteNegative}, // same text as negative 61
+		{KindOverlap, 201, FixAddNegative},
+		{KindNormalize, 103, ""},
+		{KindNormalize, 300, ""},
+		{KindNormalize, 0, ""}, // negative 61
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d findings %+v, want %d", len(got), got, len(want))
+	}
+	for i, w := range want {
+		f := got[i]
+		fix := ""
+		if f.Fix != nil {
+			fix = f.Fix.Action
+		}
+		if f.Kind != w.kind || f.KeywordID != w.keywordID || fix != w.fix {
+			t.Errorf("finding %d = %+v, want %+v", i, f, w)
+		}
+	}
+	if f := got[3].Fix; f.AdGroupID != 20 || f.Text != "photo editor" || f.MatchType != "EXACT" {
+		t.Errorf("overlap fix = %+v", *f)
+	}
+	if f := got[2]; f.NegativeID != 61 || f.Fix.ID != 61 || f.Fix.AdGroupID != 20 {
+		t.Errorf("blocked finding = %+v", f)
+	}
+	if f := got[6]; f.NegativeID != 61 || f.AdGroupID != 20 {
+		t.Errorf("negative normalize finding = %+v", f)
+	}
+}
@@ -0,0 +1,228 @@
+package keywords
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fields a CSV column can map to.
+const (
+	FieldText      = "text"
+	FieldMatchType = "matchType"
+	FieldBid       = "bid"
+	FieldAdGroup   = "adgroup"
+)
+
+// headerAliases are the header names recognized for each field when no
+// column is mapped explicitly. They are compared case-insensitively with
+// spaces, dashes and underscores removed.
+var headerAliases = map[string][]string{
+	FieldText:      {"text", "keyword", "keywordtext", "negativekeyword"},
+	FieldMatchType: {"matchtype", "match"},
+	FieldBid:       {"bid", "bidamount", "maxcpt", "cptbid"},
+	FieldAdGroup:   {"adgroupid", "adgroup"},
+}
+
+// CSVOptions configures ParseCSV.
+type CSVOptions struct {
+	// Fields the target accepts, e.g. no bid for negatives. Text is always
+	// accepted.
+	Fields []string
+	// Columns maps a field to its header name, overriding the aliases.
+	Columns map[string]string
+	// DefaultMatchType and DefaultAdGroupID are used for rows that leave
+	// the match type or ad group empty.
+	DefaultMatchType string
+	DefaultAdGroupID int64
+}
+
+// Row is one CSV data row and, once imported, its outcome.
+type Row struct {
+	Line      int    `json:"line"`
+	Text      string `json:"text"`
+	MatchType string `json:"matchType"`
+	Bid       string `json:"bid,omitempty"`
+	AdGroupID int64  `json:"adGroupId,omitempty"`
+	ID        int64  `json:"id,omitempty"`
+	Status    string `json:"status"` // planned, invalid, duplicate, exists, created, error
+	Error     string `json:"error,omitempty"`
+}
+
+// ParseColumns parses field=Header mappings, as given to --csv-column.
+func ParseColumns(specs []string) (map[string]string, error) {
+	cols := map[string]string{}
+	for _, spec := range specs {
+		field, header, ok := strings.Cut(spec, "=")
+		field, header = strings.TrimSpace(field), strings.TrimSpace(header)
+		if !ok || header == "" {
+			return nil, fmt.Errorf("invalid column mapping %q (want field=Header)", spec)
+		}
+		canon := ""
+		for f := range headerAliases {
+			if strings.EqualFold(f, field) {
+				canon = f
+			}
+		}
+		if canon == "" {
+			return nil, fmt.Errorf("unknown column field %q (want one of: %s)", field, strings.Join(fieldNames(), ", "))
+		}
+		cols[canon] = header
+	}
+	return cols, nil
+}
+
+func fieldNames() []string {
+	names := make([]string, 0, len(headerAliases))
+	for f := range headerAliases {
+		names = append(names, f)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseCSV reads keyword rows from a CSV file with a header line. Rows that
+// fail validation are returned with status invalid, and repeats of an
+// earlier row (same ad group, match type and normalized text) with status
+// duplicate; the rest are planned. Errors are returned only for unreadable
+// input or unusable column mappings.
+func ParseCSV(r io.Reader, opts CSVOptions) ([]*Row, error) {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(3); err == nil && string(b) == "\ufeff" {
+		br.Discard(3)
+	}
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse CSV header: %w", err)
+	}
+	index, err := columnIndex(header, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*Row
+	first := map[string]int{}
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		row := &Row{Line: line, Status: "planned"}
+		get := func(field string) string {
+			i, ok := index[field]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+		if err := row.fill(get, opts); err != nil {
+			row.Status, row.Error = "invalid", err.Error()
+		} else {
+			key := Key(row.AdGroupID, row.MatchType, row.Text)
+			if l, ok := first[key]; ok {
+				row.Status, row.Error = "duplicate", fmt.Sprintf("same keyword as line %d", l)
+			} else {
+				first[key] = row.Line
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+func (row *Row) fill(get func(string) string, opts CSVOptions) error {
+	row.Text = strings.Join(strings.Fields(get(FieldText)), " ")
+	row.MatchType = strings.ToUpper(get(FieldMatchType))
+	row.Bid = get(FieldBid)
+	if row.Text == "" {
+		return fmt.Errorf("text is empty")
+	}
+	if row.MatchType == "" {
+		row.MatchType = strings.ToUpper(opts.DefaultMatchType)
+	}
+	if row.MatchType != "BROAD" && row.MatchType != "EXACT" {
+		return fmt.Errorf("invalid match type %q (want BROAD or EXACT)", row.MatchType)
+	}
+	if row.Bid != "" {
+		v, ok := new(big.Rat).SetString(row.Bid)
+		if !ok || v.Sign() <= 0 {
+			return fmt.Errorf("invalid bid %q (want a positive amount)", row.Bid)
+		}
+	}
+	row.AdGroupID = opts.DefaultAdGroupID
+	if v := get(FieldAdGroup); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid ad group ID %q", v)
+		}
+		row.AdGroupID = id
+	}
+	return nil
+}
+
+// columnIndex maps fields to column positions. Explicit mappings must match
+// a header and an accepted field. Detected columns for fields the target
+// doesn't accept are ignored, except an ad group column: dropping it would
+// widen every row to the whole campaign.
+func columnIndex(header []string, opts CSVOptions) (map[string]int, error) {
+	accepted := map[string]bool{FieldText: true}
+	for _, f := range opts.Fields {
+		accepted[f] = true
+	}
+	squash := strings.NewReplacer(" ", "", "_", "", "-", "")
+	index := map[string]int{}
+	for field, name := range opts.Columns {
+		if !accepted[field] {
+			return nil, fmt.Errorf("column %s is not supported here", field)
+		}
+		found := false
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), name) {
+				index[field], found = i, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("CSV has no column %q for %s", name, field)
+		}
+	}
+	for field, aliases := range headerAliases {
+		if _, ok := index[field]; ok {
+			continue
+		}
+		for i, h := range header {
+			name := strings.ToLower(squash.Replace(strings.TrimSpace(h)))
+			if !slices.Contains(aliases, name) {
+				continue
+			}
+			if !accepted[field] {
+				if field == FieldAdGroup {
+					return nil, fmt.Errorf("CSV column %q sets an ad group, but these keywords apply to the whole campaign; remove the column or create them per ad group", h)
+				}
+				break
+			}
+			index[field] = i
+			break
+		}
+	}
+	if _, ok := index[FieldText]; !ok {
+		return nil, fmt.Errorf("CSV has no keyword text column (name it text or keyword, or map one with text=Header)")
+	}
+	return index, nil
+}
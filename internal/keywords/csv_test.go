@@ -0,0 +1,110 @@
+package keywords
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	const in = "\ufeffKeyword,Match Type,Max CPT,Ad Group ID,Volume\n" +
+		"photo editor,exact,1.20,,50\n" +
+		"Photo  Editor,EXACT,,,40\n" + // duplicate of line 2 once normalized
+		"photo editor,broad,,,40\n" +
+		"collage maker,,0.80,222,30\n" +
+		",EXACT,,,10\n" +
+		"filters,PHRASE,,,10\n" +
+		"stickers,,-1,,10\n" +
+		"frames,,,abc,10\n"
+
+	rows, err := ParseCSV(strings.NewReader(in), CSVOptions{
+		Fields:           []string{FieldMatchType, FieldBid, FieldAdGroup},
+		DefaultMatchType: "BROAD",
+		DefaultAdGroupID: 111,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		line      int
+		status    string
+		matchType string
+		adGroupID int64
+	}{
+		{2, "planned", "EXACT", 111},
+		{3, "duplicate", "EXACT", 111},
+		{4, "planned", "BROAD", 111},
+		{5, "planned", "BROAD", 222},
+		{6, "invalid", "EXACT", 0},
+		{7, "invalid", "PHRASE", 0},
+		{8, "invalid", "BROAD", 0},
+		{9, "invalid", "BROAD", 111},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i, w := range want {
+		r := rows[i]
+		if r.Line != w.line || r.Status != w.status || r.MatchType != w.matchType || r.AdGroupID != w.adGroupID {
+			t.Errorf("row %d = %+v, want %+v", i, *r, w)
+		}
+	}
+	if rows[0].Bid != "1.20" || rows[3].Bid != "0.80" || rows[1].Error != "same keyword as line 2" {
+		t.Fatalf("unexpected rows: %+v %+v %+v", *rows[0], *rows[1], *rows[3])
+	}
+}
+
+func TestParseCSVColumns(t *testing.T) {
+	const in = "Term,Kind,Group\nphoto editor,EXACT,5\n"
+
+	cols, err := ParseColumns([]string{"text=Term", "matchtype=Kind"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Negatives take no bid or ad group column, so Group is never read.
+	rows, err := ParseCSV(strings.NewReader(in), CSVOptions{Fields: []string{FieldMatchType}, Columns: cols})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Text != "photo editor" || rows[0].MatchType != "EXACT" || rows[0].AdGroupID != 0 {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+
+	for _, tc := range []struct {
+		cols []string
+		opts CSVOptions
+		want string
+	}{
+		{[]string{"text=Missing"}, CSVOptions{}, `no column "Missing"`},
+		{[]string{"bid=Kind"}, CSVOptions{}, "not supported"},
+		{nil, CSVOptions{}, "no keyword text column"},
+	} {
+		cols, err := ParseColumns(tc.cols)
+		if err == nil {
+			tc.opts.Columns = cols
+			_, err = ParseCSV(strings.NewReader(in), tc.opts)
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("columns %v: error %v, want %q", tc.cols, err, tc.want)
+		}
+	}
+	if _, err := ParseColumns([]string{"volume=Volume"}); err == nil {
+		t.Fatal("ParseColumns accepted an unknown field")
+	}
+
+	// A detected bid column is ignored where bids don't apply, but an ad
+	// group column isn't: its rows would otherwise apply campaign-wide.
+	rows, err = ParseCSV(strings.NewReader("Keyword,Bid\nphoto editor,1.00\n"), CSVOptions{Fields: []string{FieldMatchType}})
+	if err != nil || len(rows) != 1 || rows[0].Bid != "" {
+		t.Fatalf("bid column for negatives: rows %+v, error %v", rows, err)
+	}
+	_, err = ParseCSV(strings.NewReader("Keyword,Ad Group ID\nphoto editor,5\n"), CSVOptions{Fields: []string{FieldMatchType}})
+	if err == nil || !strings.Contains(err.Error(), `CSV column "Ad Group ID" sets an ad group`) {
+		t.Fatalf("ad group column for campaign negatives: error %v", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize("  Photo   EDITOR "); got != "photo editor" {
+		t.Fatalf("Normalize = %q", got)
+	}
+}
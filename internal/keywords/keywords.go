@@ -0,0 +1,20 @@
+// Package keywords holds keyword list handling shared by several commands:
+// text normalization, CSV import and the keyword audit.
+package keywords
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Normalize returns the form used to compare keyword and search term text:
+// trimmed, lower case and with single spaces.
+func Normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+// Key identifies a keyword within a campaign for duplicate checks. Texts are
+// compared after Normalize; adGroupID is 0 for campaign-level negatives.
+func Key(adGroupID int64, matchType, text string) string {
+	return strconv.FormatInt(adGroupID, 10) + "|" + strings.ToUpper(matchType) + "|" + Normalize(text)
+}
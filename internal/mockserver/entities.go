@@ -0,0 +1,834 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func (s *Server) routes() {
+	h := func(pattern string, fn http.HandlerFunc) {
+		method, path, _ := strings.Cut(pattern, " ")
+		s.mux.HandleFunc(method+" "+APIPrefix+path, fn)
+	}
+
+	h("GET /acls", s.acls)
+	h("GET /me", s.me)
+
+	h("POST /campaigns", s.createCampaign)
+	h("GET /campaigns", s.listCampaigns)
+	h("POST /campaigns/find", s.findCampaigns)
+	h("GET /campaigns/{cid}", s.getCampaign)
+	h("PUT /campaigns/{cid}", s.updateCampaign)
+	h("DELETE /campaigns/{cid}", s.deleteCampaign)
+
+	h("POST /campaigns/{cid}/adgroups", s.createAdGroup)
+	h("GET /campaigns/{cid}/adgroups", s.listAdGroups)
+	h("POST /campaigns/{cid}/adgroups/find", s.findAdGroups)
+	h("POST /adgroups/find", s.findAllAdGroups)
+	h("GET /campaigns/{cid}/adgroups/{aid}", s.getAdGroup)
+	h("PUT /campaigns/{cid}/adgroups/{aid}", s.updateAdGroup)
+	h("DELETE /campaigns/{cid}/adgroups/{aid}", s.deleteAdGroup)
+
+	kw := "/campaigns/{cid}/adgroups/{aid}/targetingkeywords"
+	h("POST "+kw+"/bulk", s.createKeywords)
+	h("PUT "+kw+"/bulk", s.updateKeywords)
+	h("GET "+kw, s.listKeywords)
+	h("POST "+kw+"/find", s.findKeywords)
+	h("POST /campaigns/{cid}/targetingkeywords/find", s.findCampaignKeywords)
+	h("GET "+kw+"/{kid}", s.getKeyword)
+	h("POST "+kw+"/delete/bulk", s.deleteKeywords)
+	h("DELETE "+kw+"/{kid}", s.deleteKeyword)
+
+	for _, prefix := range []string{"/campaigns/{cid}/negativekeywords", "/campaigns/{cid}/adgroups/{aid}/negativekeywords"} {
+		h("POST "+prefix+"/bulk", s.createNegatives)
+		h("PUT "+prefix+"/bulk", s.updateNegatives)
+		h("GET "+prefix, s.listNegatives)
+		h("POST "+prefix+"/find", s.findNegatives)
+		h("GET "+prefix+"/{kid}", s.getNegative)
+		h("POST "+prefix+"/delete/bulk", s.deleteNegatives)
+		h("DELETE "+prefix+"/{kid}", s.deleteNegative)
+	}
+
+	ads := "/campaigns/{cid}/adgroups/{aid}/ads"
+	h("POST "+ads, s.createAd)
+	h("GET "+ads, s.listAds)
+	h("POST "+ads+"/find", s.findAds)
+	h("POST /ads/find", s.findAllAds)
+	h("GET "+ads+"/{adid}", s.getAd)
+	h("PUT "+ads+"/{adid}", s.updateAd)
+	h("DELETE "+ads+"/{adid}", s.deleteAd)
+
+	h("POST /creatives", s.createCreative)
+	h("GET /creatives", s.listCreatives)
+	h("POST /creatives/find", s.findCreatives)
+	h("GET /creatives/{id}", s.getCreative)
+
+	h("POST /budgetorders", s.createBudgetOrder)
+	h("GET /budgetorders", s.listBudgetOrders)
+	h("GET /budgetorders/{id}", s.getBudgetOrder)
+	h("PUT /budgetorders/{id}", s.updateBudgetOrder)
+
+	h("POST /reports/campaigns", s.report(levelCampaigns))
+	h("POST /reports/campaigns/{cid}/adgroups", s.report(levelAdGroups))
+	h("POST /reports/campaigns/{cid}/keywords", s.report(levelKeywords))
+	h("POST /reports/campaigns/{cid}/adgroups/{aid}/keywords", s.report(levelKeywords))
+	h("POST /reports/campaigns/{cid}/searchterms", s.report(levelSearchTerms))
+	h("POST /reports/campaigns/{cid}/adgroups/{aid}/searchterms", s.report(levelSearchTerms))
+	h("POST /reports/campaigns/{cid}/ads", s.report(levelAds))
+
+	h("POST /custom-reports", s.createCustomReport)
+	h("GET /custom-reports", s.listCustomReports)
+	h("GET /custom-reports/{id}", s.getCustomReport)
+}
+
+func (s *Server) acls(w http.ResponseWriter, r *http.Request) {
+	writeData(w, http.StatusOK, []types.UserACL{{
+		OrgID:        s.opts.OrgID,
+		OrgName:      s.opts.OrgName,
+		Currency:     s.opts.Currency,
+		PaymentModel: "PAYG",
+		RoleNames:    []string{"API Campaign Manager"},
+		TimeZone:     s.opts.TimeZone,
+	}}, nil)
+}
+
+func (s *Server) me(w http.ResponseWriter, r *http.Request) {
+	writeData(w, http.StatusOK, types.MeDetail{UserID: 1, ParentOrgID: s.opts.OrgID}, nil)
+}
+
+// Shared list/find/get helpers.
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, rows []record) {
+	offset, limit := queryPage(r)
+	out, page := paginate(rows, offset, limit, defaultLimit)
+	writeData(w, http.StatusOK, project(out, queryFields(r)), page)
+}
+
+func (s *Server) find(w http.ResponseWriter, r *http.Request, rows []record) {
+	var sel types.Selector
+	if r.ContentLength != 0 && !decodeBody(w, r, &sel) {
+		return
+	}
+	var matched []record
+	for _, row := range rows {
+		ok, err := matchSelector(row, sel.Conditions)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_SELECTOR", err.Error(), "conditions")
+			return
+		}
+		if ok {
+			matched = append(matched, row)
+		}
+	}
+	sortRecords(matched, sel.OrderBy)
+
+	offset, limit := 0, 0
+	if sel.Pagination != nil {
+		offset, limit = sel.Pagination.Offset, sel.Pagination.Limit
+	}
+	out, page := paginate(matched, offset, limit, defaultLimit)
+	writeData(w, http.StatusOK, project(out, sel.Fields), page)
+}
+
+// lookupCampaign resolves {cid}, writing a 404 when it doesn't exist.
+func (s *Server) lookupCampaign(w http.ResponseWriter, r *http.Request) (record, bool) {
+	id := pathID(r, "cid")
+	c := s.campaigns.get(id, nil)
+	if c == nil {
+		notFound(w, "campaign", id)
+		return nil, false
+	}
+	return c, true
+}
+
+// lookupAdGroup resolves {cid} and {aid}.
+func (s *Server) lookupAdGroup(w http.ResponseWriter, r *http.Request) (record, bool) {
+	if _, ok := s.lookupCampaign(w, r); !ok {
+		return nil, false
+	}
+	id := pathID(r, "aid")
+	g := s.adGroups.get(id, belongsTo(pathID(r, "cid"), -1))
+	if g == nil {
+		notFound(w, "ad group", id)
+		return nil, false
+	}
+	return g, true
+}
+
+// merge applies a partial update, leaving identity fields alone.
+func merge(dst, patch record) {
+	for k, v := range patch {
+		switch k {
+		case "id", "orgId", "campaignId", "adGroupId":
+			continue
+		}
+		dst[k] = v
+	}
+	dst["modificationTime"] = now()
+}
+
+func required(w http.ResponseWriter, rec record, fields ...string) bool {
+	for _, f := range fields {
+		v, ok := rec[f]
+		if !ok || v == nil || v == "" {
+			writeError(w, http.StatusBadRequest, "INVALID_ATTRIBUTE_TYPE", f+" is required", f)
+			return false
+		}
+		if a, ok := v.([]any); ok && len(a) == 0 {
+			writeError(w, http.StatusBadRequest, "INVALID_ATTRIBUTE_TYPE", f+" must not be empty", f)
+			return false
+		}
+	}
+	return true
+}
+
+func setDefault(rec record, field string, v any) {
+	if x, ok := rec[field]; !ok || x == nil || x == "" {
+		rec[field] = v
+	}
+}
+
+func servingStatus(status string) string {
+	if status == "PAUSED" {
+		return "NOT_RUNNING"
+	}
+	return "RUNNING"
+}
+
+// Campaigns.
+
+func (s *Server) createCampaign(w http.ResponseWriter, r *http.Request) {
+	var c record
+	if !decodeBody(w, r, &c) || !required(w, c, "name", "adamId", "countriesOrRegions") {
+		return
+	}
+	name := stringField(c, "name")
+	if len(s.campaigns.filter(func(x record) bool { return stringField(x, "name") == name })) > 0 {
+		writeError(w, http.StatusBadRequest, "DUPLICATE_CAMPAIGN_NAME", fmt.Sprintf("campaign name %q already exists", name), "name")
+		return
+	}
+	s.insertCampaign(c)
+	writeData(w, http.StatusOK, c, nil)
+}
+
+func (s *Server) insertCampaign(c record) {
+	c["id"] = s.newID()
+	c["orgId"] = s.opts.OrgID
+	c["adamId"] = int64Field(c, "adamId")
+	setDefault(c, "status", "ENABLED")
+	setDefault(c, "supplySources", []any{"APPSTORE_SEARCH_RESULTS"})
+	setDefault(c, "adChannelType", "SEARCH")
+	setDefault(c, "billingEvent", "TAPS")
+	c["servingStatus"] = servingStatus(stringField(c, "status"))
+	c["displayStatus"] = stringField(c, "servingStatus")
+	c["deleted"] = false
+	c["modificationTime"] = now()
+	s.campaigns.add(c)
+}
+
+func (s *Server) listCampaigns(w http.ResponseWriter, r *http.Request) {
+	s.list(w, r, s.campaigns.rows)
+}
+
+func (s *Server) findCampaigns(w http.ResponseWriter, r *http.Request) {
+	s.find(w, r, s.campaigns.rows)
+}
+
+func (s *Server) getCampaign(w http.ResponseWriter, r *http.Request) {
+	if c, ok := s.lookupCampaign(w, r); ok {
+		writeData(w, http.StatusOK, project([]record{c}, queryFields(r))[0], nil)
+	}
+}
+
+func (s *Server) updateCampaign(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookupCampaign(w, r)
+	if !ok {
+		return
+	}
+	var body record
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	// PUT /campaigns/{id} wraps the fields in {"campaign": {...}}.
+	patch, _ := body["campaign"].(map[string]any)
+	if patch == nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "campaign is required", "campaign")
+		return
+	}
+	merge(c, patch)
+	c["servingStatus"] = servingStatus(stringField(c, "status"))
+	c["displayStatus"] = c["servingStatus"]
+	writeData(w, http.StatusOK, c, nil)
+}
+
+func (s *Server) deleteCampaign(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookupCampaign(w, r)
+	if !ok {
+		return
+	}
+	id := idOf(c)
+	s.campaigns.remove(func(x record) bool { return idOf(x) == id })
+	inCampaign := belongsTo(id, -1)
+	for _, t := range []*table{&s.adGroups, &s.keywords, &s.campaignNegatives, &s.adGroupNegatives, &s.ads} {
+		t.remove(inCampaign)
+	}
+	writeData(w, http.StatusOK, nil, nil)
+}
+
+// Ad groups.
+
+func (s *Server) createAdGroup(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookupCampaign(w, r)
+	if !ok {
+		return
+	}
+	var g record
+	if !decodeBody(w, r, &g) || !required(w, g, "name", "defaultBidAmount") {
+		return
+	}
+	name := stringField(g, "name")
+	if len(s.adGroups.filter(func(x record) bool {
+		return int64Field(x, "campaignId") == idOf(c) && stringField(x, "name") == name
+	})) > 0 {
+		writeError(w, http.StatusBadRequest, "DUPLICATE_ADGROUP_NAME", fmt.Sprintf("ad group name %q already exists", name), "name")
+		return
+	}
+	s.insertAdGroup(c, g)
+	writeData(w, http.StatusOK, g, nil)
+}
+
+func (s *Server) insertAdGroup(c, g record) {
+	g["id"] = s.newID()
+	g["campaignId"] = idOf(c)
+	g["orgId"] = s.opts.OrgID
+	setDefault(g, "status", "ENABLED")
+	setDefault(g, "automatedKeywordsOptIn", false)
+	setDefault(g, "startTime", now())
+	g["servingStatus"] = servingStatus(stringField(g, "status"))
+	g["displayStatus"] = g["servingStatus"]
+	g["deleted"] = false
+	g["modificationTime"] = now()
+	s.adGroups.add(g)
+}
+
+func (s *Server) listAdGroups(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupCampaign(w, r); ok {
+		s.list(w, r, s.adGroups.filter(belongsTo(pathID(r, "cid"), -1)))
+	}
+}
+
+func (s *Server) findAdGroups(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupCampaign(w, r); ok {
+		s.find(w, r, s.adGroups.filter(belongsTo(pathID(r, "cid"), -1)))
+	}
+}
+
+func (s *Server) findAllAdGroups(w http.ResponseWriter, r *http.Request) {
+	s.find(w, r, s.adGroups.rows)
+}
+
+func (s *Server) getAdGroup(w http.ResponseWriter, r *http.Request) {
+	if g, ok := s.lookupAdGroup(w, r); ok {
+		writeData(w, http.StatusOK, project([]record{g}, queryFields(r))[0], nil)
+	}
+}
+
+func (s *Server) updateAdGroup(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupAdGroup(w, r)
+	if !ok {
+		return
+	}
+	var patch record
+	if !decodeBody(w, r, &patch) {
+		return
+	}
+	merge(g, patch)
+	g["servingStatus"] = servingStatus(stringField(g, "status"))
+	g["displayStatus"] = g["servingStatus"]
+	writeData(w, http.StatusOK, g, nil)
+}
+
+func (s *Server) deleteAdGroup(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupAdGroup(w, r)
+	if !ok {
+		return
+	}
+	id := idOf(g)
+	s.adGroups.remove(func(x record) bool { return idOf(x) == id })
+	inAdGroup := belongsTo(int64Field(g, "campaignId"), id)
+	for _, t := range []*table{&s.keywords, &s.adGroupNegatives, &s.ads} {
+		t.remove(inAdGroup)
+	}
+	writeData(w, http.StatusOK, nil, nil)
+}
+
+// Targeting keywords.
+
+func (s *Server) createKeywords(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupAdGroup(w, r)
+	if !ok {
+		return
+	}
+	var in []record
+	if !decodeBody(w, r, &in) {
+		return
+	}
+	scope := belongsTo(int64Field(g, "campaignId"), idOf(g))
+	out, ok := s.insertKeywords(w, &s.keywords, scope, in, "BROAD", func(k record) {
+		k["campaignId"] = int64Field(g, "campaignId")
+		k["adGroupId"] = idOf(g)
+	})
+	if ok {
+		writeData(w, http.StatusOK, out, nil)
+	}
+}
+
+// insertKeywords validates and stores targeting or negative keywords. Text
+// and match type must be unique within scope, as the API enforces.
+func (s *Server) insertKeywords(w http.ResponseWriter, t *table, scope func(record) bool, in []record, defaultMatch string, parent func(record)) ([]record, bool) {
+	seen := map[string]bool{}
+	for _, x := range t.filter(scope) {
+		seen[keywordKey(x)] = true
+	}
+	for i, k := range in {
+		if !required(w, k, "text") {
+			return nil, false
+		}
+		setDefault(k, "matchType", defaultMatch)
+		k["matchType"] = strings.ToUpper(stringField(k, "matchType"))
+		if m := stringField(k, "matchType"); m != "BROAD" && m != "EXACT" {
+			writeError(w, http.StatusBadRequest, "INVALID_ATTRIBUTE_TYPE", fmt.Sprintf("keyword[%d]: invalid matchType %q", i, m), "matchType")
+			return nil, false
+		}
+		key := keywordKey(k)
+		if seen[key] {
+			writeError(w, http.StatusBadRequest, "DUPLICATE_KEYWORD", fmt.Sprintf("keyword[%d]: %q (%s) already exists", i, k["text"], k["matchType"]), "text")
+			return nil, false
+		}
+		seen[key] = true
+	}
+	for _, k := range in {
+		k["id"] = s.newID()
+		parent(k)
+		setDefault(k, "status", "ACTIVE")
+		k["deleted"] = false
+		k["modificationTime"] = now()
+		t.add(k)
+	}
+	if in == nil {
+		in = []record{}
+	}
+	return in, true
+}
+
+func keywordKey(k record) string {
+	return strings.ToLower(strings.TrimSpace(stringField(k, "text"))) + "|" + strings.ToUpper(stringField(k, "matchType"))
+}
+
+// updateBulk applies a list of {"id":...} patches to records in scope.
+func (s *Server) updateBulk(w http.ResponseWriter, r *http.Request, t *table, scope func(record) bool, what string) {
+	var in []record
+	if !decodeBody(w, r, &in) {
+		return
+	}
+	out := make([]record, 0, len(in))
+	for _, patch := range in {
+		id := idOf(patch)
+		rec := t.get(id, scope)
+		if rec == nil {
+			notFound(w, what, id)
+			return
+		}
+		out = append(out, rec)
+	}
+	for i, rec := range out {
+		merge(rec, in[i])
+	}
+	writeData(w, http.StatusOK, out, nil)
+}
+
+// deleteBulk removes the records whose IDs are listed in the body.
+func (s *Server) deleteBulk(w http.ResponseWriter, r *http.Request, t *table, scope func(record) bool, what string) {
+	var ids []json.Number
+	if !decodeBody(w, r, &ids) {
+		return
+	}
+	want := map[int64]bool{}
+	for _, n := range ids {
+		id, _ := n.Int64()
+		if t.get(id, scope) == nil {
+			notFound(w, what, id)
+			return
+		}
+		want[id] = true
+	}
+	t.remove(func(x record) bool { return scope(x) && want[idOf(x)] })
+	writeData(w, http.StatusOK, nil, nil)
+}
+
+func (s *Server) getFrom(w http.ResponseWriter, t *table, id int64, scope func(record) bool, what string) {
+	if rec := t.get(id, scope); rec != nil {
+		writeData(w, http.StatusOK, rec, nil)
+		return
+	}
+	notFound(w, what, id)
+}
+
+func (s *Server) deleteFrom(w http.ResponseWriter, t *table, id int64, scope func(record) bool, what string) {
+	if t.remove(func(x record) bool { return idOf(x) == id && scope(x) }) == 0 {
+		notFound(w, what, id)
+		return
+	}
+	writeData(w, http.StatusOK, nil, nil)
+}
+
+func adGroupScope(r *http.Request) func(record) bool {
+	return belongsTo(pathID(r, "cid"), pathID(r, "aid"))
+}
+
+func (s *Server) updateKeywords(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); ok {
+		s.updateBulk(w, r, &s.keywords, adGroupScope(r), "keyword")
+	}
+}
+
+func (s *Server) listKeywords(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); ok {
+		s.list(w, r, s.keywords.filter(adGroupScope(r)))
+	}
+}
+
+func (s *Server) findKeywords(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); ok {
+		s.find(w, r, s.keywords.filter(adGroupScope(r)))
+	}
+}
+
+func (s *Server) findCampaignKeywords(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupCampaign(w, r); ok {
+		s.find(w, r, s.keywords.filter(belongsTo(pathID(r, "cid"), -1)))
+	}
+}
+
+func (s *Server) getKeyword(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); ok {
+		s.getFrom(w, &s.keywords, pathID(r, "kid"), adGroupScope(r), "keyword")
+	}
+}
+
+func (s *Server) deleteKeywords(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); ok {
+		s.deleteBulk(w, r, &s.keywords, adGroupScope(r), "keyword")
+	}
+}
+
+func (s *Server) deleteKeyword(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); ok {
+		s.deleteFrom(w, &s.keywords, pathID(r, "kid"), adGroupScope(r), "keyword")
+	}
+}
+
+// Negative keywords. The same handlers serve campaign and ad group
+// negatives; the presence of {aid} selects the level.
+
+func (s *Server) negativeScope(w http.ResponseWriter, r *http.Request) (*table, func(record) bool, bool) {
+	if r.PathValue("aid") == "" {
+		if _, ok := s.lookupCampaign(w, r); !ok {
+			return nil, nil, false
+		}
+		return &s.campaignNegatives, belongsTo(pathID(r, "cid"), -1), true
+	}
+	if _, ok := s.lookupAdGroup(w, r); !ok {
+		return nil, nil, false
+	}
+	return &s.adGroupNegatives, adGroupScope(r), true
+}
+
+func (s *Server) createNegatives(w http.ResponseWriter, r *http.Request) {
+	t, scope, ok := s.negativeScope(w, r)
+	if !ok {
+		return
+	}
+	var in []record
+	if !decodeBody(w, r, &in) {
+		return
+	}
+	cid, aid := pathID(r, "cid"), r.PathValue("aid")
+	out, ok := s.insertKeywords(w, t, scope, in, "EXACT", func(k record) {
+		k["campaignId"] = cid
+		if aid != "" {
+			k["adGroupId"] = pathID(r, "aid")
+		}
+	})
+	if ok {
+		writeData(w, http.StatusOK, out, nil)
+	}
+}
+
+func (s *Server) updateNegatives(w http.ResponseWriter, r *http.Request) {
+	if t, scope, ok := s.negativeScope(w, r); ok {
+		s.updateBulk(w, r, t, scope, "negative keyword")
+	}
+}
+
+func (s *Server) listNegatives(w http.ResponseWriter, r *http.Request) {
+	if t, scope, ok := s.negativeScope(w, r); ok {
+		s.list(w, r, t.filter(scope))
+	}
+}
+
+func (s *Server) findNegatives(w http.ResponseWriter, r *http.Request) {
+	if t, scope, ok := s.negativeScope(w, r); ok {
+		s.find(w, r, t.filter(scope))
+	}
+}
+
+func (s *Server) getNegative(w http.ResponseWriter, r *http.Request) {
+	if t, scope, ok := s.negativeScope(w, r); ok {
+		s.getFrom(w, t, pathID(r, "kid"), scope, "negative keyword")
+	}
+}
+
+func (s *Server) deleteNegatives(w http.ResponseWriter, r *http.Request) {
+	if t, scope, ok := s.negativeScope(w, r); ok {
+		s.deleteBulk(w, r, t, scope, "negative keyword")
+	}
+}
+
+func (s *Server) deleteNegative(w http.ResponseWriter, r *http.Request) {
+	if t, scope, ok := s.negativeScope(w, r); ok {
+		s.deleteFrom(w, t, pathID(r, "kid"), scope, "negative keyword")
+	}
+}
+
+// Ads.
+
+func (s *Server) createAd(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.lookupAdGroup(w, r)
+	if !ok {
+		return
+	}
+	var a record
+	if !decodeBody(w, r, &a) || !required(w, a, "creativeId") {
+		return
+	}
+	creativeID := int64Field(a, "creativeId")
+	cr := s.creatives.get(creativeID, nil)
+	if cr == nil {
+		notFound(w, "creative", creativeID)
+		return
+	}
+	for _, x := range s.ads.filter(adGroupScope(r)) {
+		if int64Field(x, "creativeId") == creativeID {
+			writeError(w, http.StatusBadRequest, "DUPLICATE_AD", fmt.Sprintf("creative %d already has an ad in this ad group", creativeID), "creativeId")
+			return
+		}
+	}
+	s.insertAd(g, cr, a)
+	writeData(w, http.StatusOK, a, nil)
+}
+
+func (s *Server) insertAd(g, cr, a record) {
+	a["id"] = s.newID()
+	a["orgId"] = s.opts.OrgID
+	a["campaignId"] = int64Field(g, "campaignId")
+	a["adGroupId"] = idOf(g)
+	a["creativeId"] = idOf(cr)
+	a["creativeType"] = stringField(cr, "type")
+	setDefault(a, "name", stringField(cr, "name"))
+	setDefault(a, "status", "ENABLED")
+	a["servingStatus"] = servingStatus(stringField(a, "status"))
+	a["deleted"] = false
+	a["creationTime"] = now()
+	a["modificationTime"] = now()
+	s.ads.add(a)
+}
+
+func (s *Server) listAds(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); ok {
+		s.list(w, r, s.ads.filter(adGroupScope(r)))
+	}
+}
+
+func (s *Server) findAds(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); ok {
+		s.find(w, r, s.ads.filter(adGroupScope(r)))
+	}
+}
+
+func (s *Server) findAllAds(w http.ResponseWriter, r *http.Request) {
+	s.find(w, r, s.ads.rows)
+}
+
+func (s *Server) getAd(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); ok {
+		s.getFrom(w, &s.ads, pathID(r, "adid"), adGroupScope(r), "ad")
+	}
+}
+
+func (s *Server) updateAd(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); !ok {
+		return
+	}
+	id := pathID(r, "adid")
+	a := s.ads.get(id, adGroupScope(r))
+	if a == nil {
+		notFound(w, "ad", id)
+		return
+	}
+	var patch record
+	if !decodeBody(w, r, &patch) {
+		return
+	}
+	merge(a, patch)
+	a["servingStatus"] = servingStatus(stringField(a, "status"))
+	writeData(w, http.StatusOK, a, nil)
+}
+
+func (s *Server) deleteAd(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupAdGroup(w, r); ok {
+		s.deleteFrom(w, &s.ads, pathID(r, "adid"), adGroupScope(r), "ad")
+	}
+}
+
+// Creatives.
+
+func (s *Server) createCreative(w http.ResponseWriter, r *http.Request) {
+	var c record
+	if !decodeBody(w, r, &c) || !required(w, c, "adamId") {
+		return
+	}
+	s.insertCreative(c)
+	writeData(w, http.StatusOK, c, nil)
+}
+
+func (s *Server) insertCreative(c record) {
+	c["id"] = s.newID()
+	c["orgId"] = s.opts.OrgID
+	c["adamId"] = int64Field(c, "adamId")
+	if stringField(c, "productPageId") != "" {
+		c["type"] = "CUSTOM_PRODUCT_PAGE"
+	} else {
+		c["type"] = "DEFAULT_PRODUCT_PAGE"
+	}
+	setDefault(c, "name", fmt.Sprintf("Creative %d", idOf(c)))
+	c["state"] = "VALID"
+	c["deleted"] = false
+	c["creationTime"] = now()
+	c["modificationTime"] = now()
+	s.creatives.add(c)
+}
+
+func (s *Server) listCreatives(w http.ResponseWriter, r *http.Request) {
+	s.list(w, r, s.creatives.rows)
+}
+
+func (s *Server) findCreatives(w http.ResponseWriter, r *http.Request) {
+	s.find(w, r, s.creatives.rows)
+}
+
+func (s *Server) getCreative(w http.ResponseWriter, r *http.Request) {
+	s.getFrom(w, &s.creatives, pathID(r, "id"), func(record) bool { return true }, "creative")
+}
+
+// Budget orders.
+
+func (s *Server) createBudgetOrder(w http.ResponseWriter, r *http.Request) {
+	var body record
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	// Apple's documented shape is {"orgIds": [...], "bo": {...}}; accept it
+	// as well as the bare order the CLI sends.
+	bo := body
+	if inner, ok := body["bo"].(map[string]any); ok {
+		bo = inner
+	}
+	if !required(w, bo, "name", "startDate", "budget") {
+		return
+	}
+	bo["id"] = s.newID()
+	bo["orgId"] = s.opts.OrgID
+	setDefault(bo, "status", "ACTIVE")
+	setDefault(bo, "supplySource", "APPSTORE_SEARCH_RESULTS")
+	s.budgetOrders.add(bo)
+	writeData(w, http.StatusOK, bo, nil)
+}
+
+func (s *Server) listBudgetOrders(w http.ResponseWriter, r *http.Request) {
+	s.list(w, r, s.budgetOrders.rows)
+}
+
+func (s *Server) getBudgetOrder(w http.ResponseWriter, r *http.Request) {
+	s.getFrom(w, &s.budgetOrders, pathID(r, "id"), func(record) bool { return true }, "budget order")
+}
+
+func (s *Server) updateBudgetOrder(w http.ResponseWriter, r *http.Request) {
+	id := pathID(r, "id")
+	bo := s.budgetOrders.get(id, nil)
+	if bo == nil {
+		notFound(w, "budget order", id)
+		return
+	}
+	var patch record
+	if !decodeBody(w, r, &patch) {
+		return
+	}
+	if inner, ok := patch["bo"].(map[string]any); ok {
+		patch = inner
+	}
+	merge(bo, patch)
+	delete(bo, "modificationTime")
+	writeData(w, http.StatusOK, bo, nil)
+}
+
+// seed loads a small demo account.
+func (s *Server) seed() {
+	const adamID = 900000001
+	cr := record{"adamId": adamID, "name": "Default product page"}
+	s.insertCreative(cr)
+
+	c := record{
+		"name":               "Demo - Brand",
+		"adamId":             adamID,
+		"countriesOrRegions": []any{"US", "GB"},
+		"budgetAmount":       s.money("5000.00"),
+		"dailyBudgetAmount":  s.money("100.00"),
+	}
+	s.insertCampaign(c)
+
+	groups := []struct {
+		name     string
+		bid      string
+		keywords []string
+	}{
+		{"Exact", "1.50", []string{"demo app", "demo", "best demo app"}},
+		{"Discovery", "0.90", []string{"productivity", "task manager"}},
+	}
+	for _, gs := range groups {
+		g := record{"name": gs.name, "defaultBidAmount": s.money(gs.bid)}
+		s.insertAdGroup(c, g)
+		kws := make([]record, len(gs.keywords))
+		for i, text := range gs.keywords {
+			kws[i] = record{"text": text, "matchType": "EXACT", "bidAmount": s.money(gs.bid)}
+		}
+		for _, k := range kws {
+			k["id"] = s.newID()
+			k["campaignId"] = idOf(c)
+			k["adGroupId"] = idOf(g)
+			k["status"] = "ACTIVE"
+			k["deleted"] = false
+			k["modificationTime"] = now()
+			s.keywords.add(k)
+		}
+		s.insertAd(g, cr, record{})
+	}
+	s.campaignNegatives.add(record{
+		"id": s.newID(), "campaignId": idOf(c), "text": "free", "matchType": "EXACT",
+		"status": "ACTIVE", "deleted": false, "modificationTime": now(),
+	})
+}
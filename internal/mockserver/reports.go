@@ -0,0 +1,660 @@
+package mockserver
+
+import (
+	"encoding/csv"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/report"
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+type reportLevel int
+
+const (
+	levelCampaigns reportLevel = iota
+	levelAdGroups
+	levelKeywords
+	levelSearchTerms
+	levelAds
+)
+
+const dateLayout = report.DateLayout
+
+// maxReportDays is the longest date range Apple accepts per granularity.
+// Requests without a granularity (row totals only) use the DAILY limit. It is
+// kept apart from the client's own table so that window splitting is checked
+// against Apple's documented limits.
+var maxReportDays = map[string]int{
+	"HOURLY":  30,
+	"DAILY":   90,
+	"WEEKLY":  365,
+	"MONTHLY": 731,
+}
+
+// groupByValues lists the synthetic values for each supported dimension.
+// countryOrRegion comes from the campaign instead.
+var groupByValues = map[string][]string{
+	"deviceClass": {"IPHONE", "IPAD"},
+	"ageRange":    {"18-24", "25-34", "35-44", "45-54", "55-64", "65+"},
+	"gender":      {"F", "M"},
+	"adminArea":   {"California", "New York"},
+	"locality":    {"Los Angeles", "New York City"},
+}
+
+// metrics are the additive report counters. Spend is kept in cents so sums
+// are exact.
+type metrics struct {
+	impressions, taps, installs, newDownloads, redownloads, latOn, latOff int64
+	spendCents                                                            int64
+}
+
+func (m *metrics) add(o metrics) {
+	m.impressions += o.impressions
+	m.taps += o.taps
+	m.installs += o.installs
+	m.newDownloads += o.newDownloads
+	m.redownloads += o.redownloads
+	m.latOn += o.latOn
+	m.latOff += o.latOff
+	m.spendCents += o.spendCents
+}
+
+// fields renders metrics the way a report's total or granularity entry does.
+func (s *Server) fields(m metrics, into record) record {
+	if into == nil {
+		into = record{}
+	}
+	ratio := func(a, b int64) float64 {
+		if b == 0 {
+			return 0
+		}
+		return float64(a*10000/b) / 10000
+	}
+	cents := func(c int64) record {
+		return s.money(fmt.Sprintf("%d.%02d", c/100, c%100))
+	}
+	per := func(n int64) record {
+		if n == 0 {
+			return cents(0)
+		}
+		return cents((m.spendCents + n/2) / n)
+	}
+	into["impressions"] = m.impressions
+	into["taps"] = m.taps
+	into["installs"] = m.installs
+	into["newDownloads"] = m.newDownloads
+	into["redownloads"] = m.redownloads
+	into["latOnInstalls"] = m.latOn
+	into["latOffInstalls"] = m.latOff
+	into["ttr"] = ratio(m.taps, m.impressions)
+	into["conversionRate"] = ratio(m.installs, m.taps)
+	into["avgCPT"] = per(m.taps)
+	into["avgCPA"] = per(m.installs)
+	into["localSpend"] = cents(m.spendCents)
+	return into
+}
+
+// generate returns deterministic metrics for one entity, dimension values
+// and time slot, so repeated requests return identical numbers.
+func generate(key string, slot string, hourly bool) metrics {
+	h := fnv.New64a()
+	h.Write([]byte(key + "|" + slot))
+	rnd := rand.New(rand.NewPCG(h.Sum64(), 0x9e3779b97f4a7c15))
+
+	var m metrics
+	m.impressions = int64(100 + rnd.IntN(900))
+	if hourly {
+		m.impressions = m.impressions/24 + int64(rnd.IntN(10))
+	}
+	m.taps = m.impressions * int64(3+rnd.IntN(8)) / 100
+	m.installs = m.taps * int64(30+rnd.IntN(31)) / 100
+	m.newDownloads = m.installs * int64(70+rnd.IntN(21)) / 100
+	m.redownloads = m.installs - m.newDownloads
+	m.latOn = m.installs * int64(rnd.IntN(15)) / 100
+	m.latOff = m.installs - m.latOn
+	m.spendCents = m.taps * int64(50+rnd.IntN(200))
+	return m
+}
+
+// reportEntity is one row source before dimension expansion.
+type reportEntity struct {
+	key       string
+	metadata  record
+	countries []string
+	insights  record
+}
+
+func (s *Server) campaignCountries(campaignID int64) []string {
+	c := s.campaigns.get(campaignID, nil)
+	if c == nil {
+		return nil
+	}
+	v, _ := fieldStrings(c, "countriesOrRegions")
+	return v
+}
+
+// reportEntities collects the rows for a report level, resolving {cid} and
+// {aid} from the path.
+func (s *Server) reportEntities(w http.ResponseWriter, r *http.Request, level reportLevel) ([]reportEntity, bool) {
+	if level == levelCampaigns {
+		var out []reportEntity
+		for _, c := range s.campaigns.rows {
+			app, _ := c["app"].(map[string]any)
+			if app == nil {
+				app = record{"appName": fmt.Sprintf("App %d", int64Field(c, "adamId")), "adamId": int64Field(c, "adamId")}
+			}
+			countries, _ := fieldStrings(c, "countriesOrRegions")
+			out = append(out, reportEntity{
+				key:       fmt.Sprintf("c%d", idOf(c)),
+				countries: countries,
+				metadata: record{
+					"campaignId":         idOf(c),
+					"campaignName":       c["name"],
+					"campaignStatus":     c["status"],
+					"deleted":            c["deleted"],
+					"adamId":             c["adamId"],
+					"app":                app,
+					"countriesOrRegions": c["countriesOrRegions"],
+					"dailyBudget":        c["dailyBudgetAmount"],
+					"totalBudget":        c["budgetAmount"],
+					"servingStatus":      c["servingStatus"],
+					"displayStatus":      c["displayStatus"],
+					"supplySources":      c["supplySources"],
+					"adChannelType":      c["adChannelType"],
+					"orgId":              c["orgId"],
+					"modificationTime":   c["modificationTime"],
+				},
+			})
+		}
+		return out, true
+	}
+
+	c, ok := s.lookupCampaign(w, r)
+	if !ok {
+		return nil, false
+	}
+	cid := idOf(c)
+	countries := s.campaignCountries(cid)
+	scope := belongsTo(cid, -1)
+	if r.PathValue("aid") != "" {
+		if _, ok := s.lookupAdGroup(w, r); !ok {
+			return nil, false
+		}
+		scope = adGroupScope(r)
+	}
+	adGroupName := func(id int64) any {
+		if g := s.adGroups.get(id, nil); g != nil {
+			return g["name"]
+		}
+		return nil
+	}
+
+	var out []reportEntity
+	switch level {
+	case levelAdGroups:
+		for _, g := range s.adGroups.filter(scope) {
+			out = append(out, reportEntity{key: fmt.Sprintf("g%d", idOf(g)), countries: countries, metadata: record{
+				"campaignId":             cid,
+				"adGroupId":              idOf(g),
+				"adGroupName":            g["name"],
+				"adGroupStatus":          g["status"],
+				"adGroupServingStatus":   g["servingStatus"],
+				"adGroupDisplayStatus":   g["displayStatus"],
+				"defaultBidAmount":       g["defaultBidAmount"],
+				"cpaGoal":                g["cpaGoal"],
+				"automatedKeywordsOptIn": g["automatedKeywordsOptIn"],
+				"deleted":                g["deleted"],
+				"orgId":                  g["orgId"],
+				"modificationTime":       g["modificationTime"],
+			}})
+		}
+	case levelKeywords, levelSearchTerms:
+		for _, k := range s.keywords.filter(scope) {
+			meta := record{
+				"campaignId":    cid,
+				"adGroupId":     k["adGroupId"],
+				"adGroupName":   adGroupName(int64Field(k, "adGroupId")),
+				"keywordId":     idOf(k),
+				"keyword":       k["text"],
+				"keywordStatus": k["status"],
+				"matchType":     k["matchType"],
+				"bidAmount":     k["bidAmount"],
+				"deleted":       k["deleted"],
+			}
+			if level == levelKeywords {
+				meta["keywordDisplayStatus"] = "RUNNING"
+				meta["modificationTime"] = k["modificationTime"]
+				// Suggestions follow the ad group's default bid so they stay
+				// put when the keyword's own bid changes.
+				var bid any
+				if g := s.adGroups.get(int64Field(k, "adGroupId"), nil); g != nil {
+					bid = g["defaultBidAmount"]
+				}
+				out = append(out, reportEntity{key: fmt.Sprintf("k%d", idOf(k)), countries: countries, metadata: meta, insights: s.bidInsights(idOf(k), bid)})
+				continue
+			}
+			// Search terms: the keyword text itself, plus a longer query for
+			// broad match.
+			terms := []string{stringField(k, "text")}
+			if stringField(k, "matchType") == "BROAD" {
+				terms = append(terms, stringField(k, "text")+" app")
+			}
+			for _, term := range terms {
+				m := clone(meta)
+				m["searchTermText"] = term
+				m["searchTermSource"] = "TARGETED"
+				out = append(out, reportEntity{key: fmt.Sprintf("s%d|%s", idOf(k), term), countries: countries, metadata: m})
+			}
+		}
+	case levelAds:
+		for _, a := range s.ads.filter(scope) {
+			out = append(out, reportEntity{key: fmt.Sprintf("a%d", idOf(a)), countries: countries, metadata: record{
+				"campaignId":       cid,
+				"adGroupId":        a["adGroupId"],
+				"adGroupName":      adGroupName(int64Field(a, "adGroupId")),
+				"adId":             idOf(a),
+				"adName":           a["name"],
+				"creativeId":       a["creativeId"],
+				"creativeType":     a["creativeType"],
+				"adServingStatus":  a["servingStatus"],
+				"status":           a["status"],
+				"deleted":          a["deleted"],
+				"orgId":            a["orgId"],
+				"modificationTime": a["modificationTime"],
+			}})
+		}
+	}
+	return out, true
+}
+
+// bidInsights returns a keyword's bid recommendation: a deterministic 70% to
+// 140% of bid. Keywords without a parseable bid get none.
+func (s *Server) bidInsights(keywordID int64, bid any) record {
+	m, _ := bid.(map[string]any)
+	cents, err := parseCents(fmt.Sprint(m["amount"]))
+	if m == nil || err != nil || cents <= 0 {
+		return nil
+	}
+	h := fnv.New64a()
+	fmt.Fprintf(h, "bid|%d", keywordID)
+	pct := int64(70 + h.Sum64()%71)
+	suggested := (cents*pct + 50) / 100
+	return record{"bidRecommendation": record{
+		"suggestedBidAmount": s.money(fmt.Sprintf("%d.%02d", suggested/100, suggested%100)),
+	}}
+}
+
+// parseCents parses a decimal amount with up to two places into cents.
+func parseCents(amount string) (int64, error) {
+	whole, frac, _ := strings.Cut(amount, ".")
+	if len(frac) > 2 {
+		return 0, fmt.Errorf("too many decimals in %q", amount)
+	}
+	frac += strings.Repeat("0", 2-len(frac))
+	w, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return w*100 + f, nil
+}
+
+// expandGroupBy splits an entity into one row per combination of groupBy
+// dimension values.
+func expandGroupBy(e reportEntity, groupBy []string) ([]reportEntity, error) {
+	out := []reportEntity{e}
+	for _, dim := range groupBy {
+		values := groupByValues[dim]
+		switch dim {
+		case "countryOrRegion", "countryCode":
+			values = e.countries
+		case "deviceClass", "ageRange", "gender", "adminArea", "locality":
+		default:
+			return nil, fmt.Errorf("unsupported groupBy %q", dim)
+		}
+		var next []reportEntity
+		for _, base := range out {
+			for _, v := range values {
+				m := clone(base.metadata)
+				m[dim] = v
+				next = append(next, reportEntity{key: base.key + "|" + dim + "=" + v, metadata: m, countries: base.countries, insights: base.insights})
+			}
+		}
+		out = next
+	}
+	return out, nil
+}
+
+// bucketStart maps a day to the first day of its granularity bucket,
+// clamped to the report start.
+func bucketStart(day, start time.Time, granularity string) time.Time {
+	var b time.Time
+	switch granularity {
+	case "WEEKLY":
+		offset := (int(day.Weekday()) + 6) % 7 // weeks start on Monday
+		b = day.AddDate(0, 0, -offset)
+	case "MONTHLY":
+		b = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		b = day
+	}
+	if b.Before(start) {
+		return start
+	}
+	return b
+}
+
+func (s *Server) report(level reportLevel) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ReportingRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		start, err := time.Parse(dateLayout, req.StartTime)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_DATE_FORMAT", "startTime must be YYYY-MM-DD", "startTime")
+			return
+		}
+		end, err := time.Parse(dateLayout, req.EndTime)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_DATE_FORMAT", "endTime must be YYYY-MM-DD", "endTime")
+			return
+		}
+		if end.Before(start) {
+			writeError(w, http.StatusBadRequest, "INVALID_DATE_RANGE", "endTime is before startTime", "endTime")
+			return
+		}
+		granularity := strings.ToUpper(req.Granularity)
+		limit, ok := maxReportDays[granularity]
+		if granularity == "" {
+			limit = maxReportDays["DAILY"]
+		} else if !ok {
+			writeError(w, http.StatusBadRequest, "INVALID_GRANULARITY", fmt.Sprintf("invalid granularity %q", req.Granularity), "granularity")
+			return
+		}
+		if days := int(end.Sub(start).Hours()/24) + 1; days > limit {
+			writeError(w, http.StatusBadRequest, "INVALID_DATE_RANGE",
+				fmt.Sprintf("date range of %d days exceeds the %d-day limit for %s granularity", days, limit, orDefault(granularity, "DAILY")), "startTime")
+			return
+		}
+
+		entities, ok := s.reportEntities(w, r, level)
+		if !ok {
+			return
+		}
+		var expanded []reportEntity
+		for _, e := range entities {
+			rows, err := expandGroupBy(e, req.GroupBy)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "INVALID_GROUP_BY", err.Error(), "groupBy")
+				return
+			}
+			expanded = append(expanded, rows...)
+		}
+
+		type built struct {
+			row   record
+			flat  record
+			total metrics
+		}
+		var rows []built
+		for _, e := range expanded {
+			var total metrics
+			var buckets []string
+			byBucket := map[string]*metrics{}
+			addTo := func(bucket string, m metrics) {
+				total.add(m)
+				if granularity == "" {
+					return
+				}
+				b := byBucket[bucket]
+				if b == nil {
+					b = &metrics{}
+					byBucket[bucket] = b
+					buckets = append(buckets, bucket)
+				}
+				b.add(m)
+			}
+			for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+				if granularity == "HOURLY" {
+					for hour := range 24 {
+						slot := day.Add(time.Duration(hour) * time.Hour).Format("2006-01-02 15:04")
+						addTo(slot, generate(e.key, slot, true))
+					}
+					continue
+				}
+				addTo(bucketStart(day, start, granularity).Format(dateLayout), generate(e.key, day.Format(dateLayout), false))
+			}
+
+			row := record{"other": false, "metadata": e.metadata}
+			if e.insights != nil {
+				row["insights"] = e.insights
+			}
+			if req.ReturnRowTotals || granularity == "" {
+				row["total"] = s.fields(total, nil)
+			}
+			if granularity != "" {
+				gran := make([]record, len(buckets))
+				for i, b := range buckets {
+					gran[i] = s.fields(*byBucket[b], record{"date": b})
+				}
+				row["granularity"] = gran
+			}
+			flat := clone(e.metadata)
+			s.fields(total, flat)
+			rows = append(rows, built{row: row, flat: flat, total: total})
+		}
+
+		var conds []*types.Condition
+		var orderBy []*types.Sorting
+		var offset, pageLimit int
+		if req.Selector != nil {
+			conds, orderBy = req.Selector.Conditions, req.Selector.OrderBy
+			if req.Selector.Pagination != nil {
+				offset, pageLimit = req.Selector.Pagination.Offset, req.Selector.Pagination.Limit
+			}
+		}
+		var kept []built
+		for _, b := range rows {
+			ok, err := matchSelector(b.flat, conds)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "INVALID_SELECTOR", err.Error(), "selector")
+				return
+			}
+			if ok {
+				kept = append(kept, b)
+			}
+		}
+		// Sort through the flattened view so metrics and metadata both work
+		// as orderBy fields.
+		flats := make([]record, len(kept))
+		for i := range kept {
+			flats[i] = kept[i].flat
+			flats[i]["__row"] = i
+		}
+		sortRecords(flats, orderBy)
+
+		var grand metrics
+		ordered := make([]record, len(flats))
+		for i, f := range flats {
+			b := kept[int64Field(f, "__row")]
+			delete(f, "__row")
+			ordered[i] = b.row
+			grand.add(b.total)
+		}
+		out, page := paginate(ordered, offset, pageLimit, reportLimit)
+
+		data := record{"row": out}
+		if req.ReturnGrandTotals {
+			data["grandTotals"] = record{"other": false, "total": s.fields(grand, nil)}
+		}
+		writeData(w, http.StatusOK, record{"reportingDataResponse": data}, page)
+	}
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// Custom (impression share) reports.
+
+// customReportMaxDays bounds impression share report ranges per granularity.
+var customReportMaxDays = map[string]int{
+	"DAILY":  30,
+	"WEEKLY": 84,
+}
+
+func (s *Server) createCustomReport(w http.ResponseWriter, r *http.Request) {
+	var req record
+	if !decodeBody(w, r, &req) || !required(w, req, "startTime", "endTime") {
+		return
+	}
+	start, err1 := time.Parse(dateLayout, stringField(req, "startTime"))
+	end, err2 := time.Parse(dateLayout, stringField(req, "endTime"))
+	if err1 != nil || err2 != nil || end.Before(start) {
+		writeError(w, http.StatusBadRequest, "INVALID_DATE_RANGE", "startTime and endTime must be YYYY-MM-DD with startTime <= endTime", "startTime")
+		return
+	}
+	setDefault(req, "granularity", "DAILY")
+	granularity := strings.ToUpper(stringField(req, "granularity"))
+	limit, ok := customReportMaxDays[granularity]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "INVALID_GRANULARITY", "granularity must be DAILY or WEEKLY", "granularity")
+		return
+	}
+	if days := int(end.Sub(start).Hours()/24) + 1; days > limit {
+		writeError(w, http.StatusBadRequest, "INVALID_DATE_RANGE", fmt.Sprintf("%s impression share reports cover at most %d days", granularity, limit), "startTime")
+		return
+	}
+
+	id := s.newID()
+	rep := record{
+		"id":               id,
+		"name":             orDefault(stringField(req, "name"), fmt.Sprintf("impression-share-%d", id)),
+		"startTime":        req["startTime"],
+		"endTime":          req["endTime"],
+		"granularity":      granularity,
+		"dateRange":        "CUSTOM",
+		"state":            "QUEUED",
+		"creationTime":     now(),
+		"modificationTime": now(),
+	}
+	if v, ok := req["groupBy"]; ok {
+		rep["groupBy"] = v
+	}
+	if v, ok := req["selector"]; ok {
+		rep["selector"] = v
+	}
+	s.customReports.add(rep)
+	writeData(w, http.StatusOK, rep, nil)
+}
+
+func (s *Server) listCustomReports(w http.ResponseWriter, r *http.Request) {
+	s.list(w, r, s.customReports.rows)
+}
+
+// getCustomReport advances the report one state per poll:
+// QUEUED -> RUNNING -> COMPLETED.
+func (s *Server) getCustomReport(w http.ResponseWriter, r *http.Request) {
+	id := pathID(r, "id")
+	rep := s.customReports.get(id, nil)
+	if rep == nil {
+		notFound(w, "custom report", id)
+		return
+	}
+	switch rep["state"] {
+	case "QUEUED":
+		rep["state"] = "RUNNING"
+		rep["modificationTime"] = now()
+	case "RUNNING":
+		rep["state"] = "COMPLETED"
+		rep["modificationTime"] = now()
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		rep["downloadUri"] = fmt.Sprintf("%s://%s/__mock/downloads/%d.csv", scheme, r.Host, id)
+	}
+	writeData(w, http.StatusOK, rep, nil)
+}
+
+var impressionRanks = []string{"ONE", "TWO", "THREE", "FOUR", "FIVE", "GREATER_THAN_FIVE"}
+
+// downloadCustomReport serves a completed report's CSV. Terms come from the
+// account's keywords.
+func (s *Server) downloadCustomReport(w http.ResponseWriter, idStr string) {
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+	rep := s.customReports.get(id, nil)
+	if rep == nil || rep["state"] != "COMPLETED" {
+		http.Error(w, "report not found or not completed", http.StatusNotFound)
+		return
+	}
+
+	type term struct {
+		text      string
+		adamID    int64
+		countries []string
+	}
+	seen := map[string]bool{}
+	var terms []term
+	for _, k := range s.keywords.rows {
+		text := strings.ToLower(stringField(k, "text"))
+		if seen[text] {
+			continue
+		}
+		seen[text] = true
+		c := s.campaigns.get(int64Field(k, "campaignId"), nil)
+		if c == nil {
+			continue
+		}
+		terms = append(terms, term{text: text, adamID: int64Field(c, "adamId"), countries: s.campaignCountries(idOf(c))})
+	}
+	sort.Slice(terms, func(i, j int) bool { return terms[i].text < terms[j].text })
+
+	start, _ := time.Parse(dateLayout, stringField(rep, "startTime"))
+	end, _ := time.Parse(dateLayout, stringField(rep, "endTime"))
+	step := 1
+	if rep["granularity"] == "WEEKLY" {
+		step = 7
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"date", "appName", "adamId", "countryOrRegion", "searchTerm", "lowImpressionShare", "highImpressionShare", "rank", "searchPopularity"})
+	for day := start; !day.After(end); day = day.AddDate(0, 0, step) {
+		date := day.Format(dateLayout)
+		for _, t := range terms {
+			for _, country := range t.countries {
+				h := fnv.New64a()
+				h.Write([]byte(t.text + "|" + country + "|" + date))
+				rnd := rand.New(rand.NewPCG(h.Sum64(), 1))
+				low := 0.05 + float64(rnd.IntN(50))/100
+				_ = cw.Write([]string{
+					date,
+					fmt.Sprintf("App %d", t.adamID),
+					strconv.FormatInt(t.adamID, 10),
+					country,
+					t.text,
+					strconv.FormatFloat(low, 'f', 2, 64),
+					strconv.FormatFloat(low+0.1, 'f', 2, 64),
+					impressionRanks[rnd.IntN(len(impressionRanks))],
+					strconv.Itoa(1 + rnd.IntN(5)),
+				})
+			}
+		}
+	}
+	cw.Flush()
+}
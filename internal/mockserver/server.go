@@ -0,0 +1,330 @@
+// Package mockserver is an in-memory stand-in for the Apple Ads v5 API.
+//
+// It implements the endpoints the CLI calls (campaigns, ad groups, keywords,
+// negatives, ads, creatives, budget orders, reports, custom reports and ACLs)
+// closely enough to exercise automation end to end without an Apple account:
+// selectors filter, sort and paginate, list responses carry PageDetail
+// envelopes, and errors use Apple's error shape. Faults (429s and 5xx) can be
+// injected at random or queued for specific paths.
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+const (
+	// APIPrefix is the path the API is served under. Point the client's base
+	// URL at the server address plus this prefix.
+	APIPrefix = "/api/v5"
+	// TokenPath is the OAuth2 token endpoint.
+	TokenPath = "/auth/oauth2/token"
+
+	defaultLimit = 20
+	maxLimit     = 1000
+
+	// reportLimit is the default page size for reports, which the CLI does
+	// not paginate.
+	reportLimit = 1000
+)
+
+// Options configures a Server.
+type Options struct {
+	OrgID    int64
+	OrgName  string
+	Currency string
+	TimeZone string
+
+	// FaultRate is the probability (0-1) that an API request fails with one
+	// of FaultStatuses (default 429, 500 and 503).
+	FaultRate     float64
+	FaultStatuses []int
+	// RetryAfter is sent with injected 429s. Zero omits the header.
+	RetryAfter time.Duration
+
+	// Seed preloads a demo app with a campaign, ad groups, keywords and a
+	// creative.
+	Seed bool
+}
+
+// Fault is a queued failure. The next Count requests whose method and path
+// match fail with Status.
+type Fault struct {
+	Status int `json:"status"`
+	// Count defaults to 1.
+	Count int `json:"count,omitempty"`
+	// Method and Path (a substring of the path below the API prefix)
+	// restrict which requests fail. Empty matches every request.
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path,omitempty"`
+	// RetryAfter in seconds, sent as the Retry-After header.
+	RetryAfter int `json:"retryAfter,omitempty"`
+}
+
+// Server is the mock API. It is safe for concurrent use.
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+
+	mu     sync.Mutex
+	nextID int64
+	faults []Fault
+
+	campaigns         table
+	adGroups          table
+	keywords          table
+	campaignNegatives table
+	adGroupNegatives  table
+	ads               table
+	creatives         table
+	budgetOrders      table
+	customReports     table
+}
+
+// New returns a Server with defaults applied for empty options.
+func New(opts Options) *Server {
+	if opts.OrgID == 0 {
+		opts.OrgID = 1234567
+	}
+	if opts.OrgName == "" {
+		opts.OrgName = "Mock Org"
+	}
+	if opts.Currency == "" {
+		opts.Currency = "USD"
+	}
+	if opts.TimeZone == "" {
+		opts.TimeZone = "America/Los_Angeles"
+	}
+	if len(opts.FaultStatuses) == 0 {
+		opts.FaultStatuses = []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable}
+	}
+
+	s := &Server{opts: opts, mux: http.NewServeMux()}
+	s.routes()
+	s.Reset()
+	return s
+}
+
+// OrgID returns the org the server accepts in X-AP-Context.
+func (s *Server) OrgID() int64 {
+	return s.opts.OrgID
+}
+
+// Reset drops all state and queued faults, then reseeds if configured.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID = 1000
+	s.faults = nil
+	for _, t := range []*table{&s.campaigns, &s.adGroups, &s.keywords, &s.campaignNegatives, &s.adGroupNegatives, &s.ads, &s.creatives, &s.budgetOrders, &s.customReports} {
+		t.rows = nil
+	}
+	if s.opts.Seed {
+		s.seed()
+	}
+}
+
+// InjectFault queues a failure for matching requests.
+func (s *Server) InjectFault(f Fault) {
+	if f.Count <= 0 {
+		f.Count = 1
+	}
+	s.mu.Lock()
+	s.faults = append(s.faults, f)
+	s.mu.Unlock()
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == TokenPath:
+		s.token(w, r)
+		return
+	case strings.HasPrefix(r.URL.Path, "/__mock/"):
+		s.admin(w, r)
+		return
+	case !strings.HasPrefix(r.URL.Path, APIPrefix+"/"):
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "unknown path "+r.URL.Path, "")
+		return
+	}
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "missing bearer token", "")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, APIPrefix)
+	if f, ok := s.takeFault(r.Method, path); ok {
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(f.RetryAfter))
+		}
+		writeError(w, f.Status, "MOCK_FAULT", fmt.Sprintf("injected %d", f.Status), "")
+		return
+	}
+
+	if path != "/acls" && path != "/me" {
+		want := "orgId=" + strconv.FormatInt(s.opts.OrgID, 10)
+		if got := r.Header.Get("X-AP-Context"); got != want {
+			writeError(w, http.StatusForbidden, "FORBIDDEN", fmt.Sprintf("X-AP-Context must be %q", want), "")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mux.ServeHTTP(w, r)
+}
+
+// takeFault returns the fault for this request, if any: queued faults first,
+// then the random fault rate.
+func (s *Server) takeFault(method, path string) (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, method) {
+			continue
+		}
+		if f.Path != "" && !strings.Contains(path, f.Path) {
+			continue
+		}
+		s.faults[i].Count--
+		if s.faults[i].Count <= 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return f, true
+	}
+
+	if s.opts.FaultRate > 0 && rand.Float64() < s.opts.FaultRate {
+		f := Fault{Status: s.opts.FaultStatuses[rand.IntN(len(s.opts.FaultStatuses))]}
+		if f.Status == http.StatusTooManyRequests && s.opts.RetryAfter > 0 {
+			f.RetryAfter = max(1, int(s.opts.RetryAfter.Seconds()))
+		}
+		return f, true
+	}
+	return Fault{}, false
+}
+
+// token implements the client-credentials exchange. Any credentials are
+// accepted.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("client_id") == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_request", "error_description": "client_credentials grant with client_id required"})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "mock-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+// admin serves the /__mock/ control endpoints:
+//
+//	POST /__mock/faults     queue a Fault (JSON body)
+//	POST /__mock/reset      drop all state
+//	GET  /__mock/downloads/{id}.csv  custom report data
+func (s *Server) admin(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/__mock/faults":
+		var f Fault
+		if err := json.NewDecoder(r.Body).Decode(&f); err != nil || f.Status < 400 {
+			writeError(w, http.StatusBadRequest, "INVALID_JSON", "want {\"status\":429,...}", "status")
+			return
+		}
+		s.InjectFault(f)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && r.URL.Path == "/__mock/reset":
+		s.Reset()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/__mock/downloads/"):
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.downloadCustomReport(w, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/__mock/downloads/"), ".csv"))
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "unknown path "+r.URL.Path, "")
+	}
+}
+
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) money(amount string) record {
+	return record{"amount": amount, "currency": s.opts.Currency}
+}
+
+func now() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000")
+}
+
+// writeData writes the {"data":...,"pagination":...} envelope.
+func writeData(w http.ResponseWriter, status int, data any, page *types.PageDetail) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data, "pagination": page, "error": nil})
+}
+
+// writeError writes Apple's error envelope.
+func writeError(w http.ResponseWriter, status int, code, message, field string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	detail := types.APIErrorDetail{MessageCode: code, Message: message, Field: field}
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"data":       nil,
+		"pagination": nil,
+		"error":      types.APIError{Errors: []types.APIErrorDetail{detail}},
+	})
+}
+
+func notFound(w http.ResponseWriter, what string, id int64) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("%s %d not found", what, id), "")
+}
+
+// decodeBody decodes a JSON request body, keeping numbers exact.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body: "+err.Error(), "")
+		return false
+	}
+	return true
+}
+
+func pathID(r *http.Request, name string) int64 {
+	id, _ := strconv.ParseInt(r.PathValue(name), 10, 64)
+	return id
+}
+
+// queryPage reads limit and offset from a GET list request.
+func queryPage(r *http.Request) (offset, limit int) {
+	limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ = strconv.Atoi(r.URL.Query().Get("offset"))
+	return max(offset, 0), limit
+}
+
+// queryFields reads the comma-separated fields projection of a GET request.
+func queryFields(r *http.Request) []string {
+	v := r.URL.Query().Get("fields")
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
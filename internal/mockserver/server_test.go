@@ -0,0 +1,192 @@
+package mockserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/report"
+)
+
+type envelope struct {
+	Data       json.RawMessage `json:"data"`
+	Pagination *struct {
+		TotalResults int `json:"totalResults"`
+		StartIndex   int `json:"startIndex"`
+		ItemsPerPage int `json:"itemsPerPage"`
+	} `json:"pagination"`
+	Error *struct {
+		Errors []struct {
+			MessageCode string `json:"messageCode"`
+		} `json:"errors"`
+	} `json:"error"`
+}
+
+func call(t *testing.T, srv *httptest.Server, method, path, body string) (int, envelope) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+APIPrefix+path, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer test")
+	req.Header.Set("X-AP-Context", "orgId=42")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		t.Fatalf("%s %s: decode: %v", method, path, err)
+	}
+	return resp.StatusCode, env
+}
+
+func TestFindFiltersAndPaginates(t *testing.T) {
+	srv := httptest.NewServer(New(Options{OrgID: 42}))
+	defer srv.Close()
+
+	for _, name := range []string{"Brand US", "Brand GB", "Generic"} {
+		status, _ := call(t, srv, "POST", "/campaigns", `{"name":"`+name+`","adamId":1,"countriesOrRegions":["US"]}`)
+		if status != http.StatusOK {
+			t.Fatalf("create %s: status %d", name, status)
+		}
+	}
+	if status, env := call(t, srv, "POST", "/campaigns", `{"name":"Generic","adamId":1,"countriesOrRegions":["US"]}`); status != http.StatusBadRequest || env.Error == nil {
+		t.Fatalf("duplicate name: status %d", status)
+	}
+
+	_, env := call(t, srv, "POST", "/campaigns/find", `{
+		"conditions":[{"field":"name","operator":"STARTSWITH","values":["brand"]}],
+		"orderBy":[{"field":"name","sortOrder":"ASCENDING"}],
+		"pagination":{"offset":1,"limit":1}}`)
+	var got []struct{ Name string }
+	if err := json.Unmarshal(env.Data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name != "Brand US" {
+		t.Fatalf("page = %+v, want [Brand US]", got)
+	}
+	if p := env.Pagination; p == nil || p.TotalResults != 2 || p.StartIndex != 1 || p.ItemsPerPage != 1 {
+		t.Fatalf("pagination = %+v", env.Pagination)
+	}
+}
+
+func TestInjectedFaults(t *testing.T) {
+	s := New(Options{OrgID: 42})
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	s.InjectFault(Fault{Status: http.StatusTooManyRequests, Count: 2, Path: "/campaigns"})
+	for i := range 2 {
+		if status, _ := call(t, srv, "GET", "/campaigns", ""); status != http.StatusTooManyRequests {
+			t.Fatalf("request %d: status %d, want 429", i, status)
+		}
+	}
+	if status, _ := call(t, srv, "GET", "/campaigns", ""); status != http.StatusOK {
+		t.Fatalf("after faults: status %d, want 200", status)
+	}
+}
+
+func TestReportsAreDeterministicAndEnforceRangeLimits(t *testing.T) {
+	srv := httptest.NewServer(New(Options{OrgID: 42, Seed: true}))
+	defer srv.Close()
+
+	body := `{"startTime":"2025-01-01","endTime":"2025-01-14","granularity":"WEEKLY","returnRowTotals":true,"returnGrandTotals":true}`
+	_, first := call(t, srv, "POST", "/reports/campaigns", body)
+	_, second := call(t, srv, "POST", "/reports/campaigns", body)
+	if !bytes.Equal(first.Data, second.Data) {
+		t.Fatal("report output differs between identical requests")
+	}
+
+	var data struct {
+		ReportingDataResponse struct {
+			Row []struct {
+				Other       *bool                   `json:"other"`
+				Granularity []struct{ Date string } `json:"granularity"`
+			} `json:"row"`
+			GrandTotals *struct {
+				Other *bool `json:"other"`
+			} `json:"grandTotals"`
+		} `json:"reportingDataResponse"`
+	}
+	if err := json.Unmarshal(first.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	rows := data.ReportingDataResponse.Row
+	if len(rows) != 1 || len(rows[0].Granularity) != 3 || rows[0].Granularity[0].Date != "2025-01-01" || rows[0].Granularity[1].Date != "2025-01-06" {
+		t.Fatalf("unexpected weekly buckets: %+v", rows)
+	}
+	if data.ReportingDataResponse.GrandTotals == nil {
+		t.Fatal("missing grand totals")
+	}
+	// Apple sends "other" as a boolean on every row and on grand totals.
+	if rows[0].Other == nil || *rows[0].Other || data.ReportingDataResponse.GrandTotals.Other == nil {
+		t.Fatalf("rows and grand totals should carry other: false, got %s", first.Data)
+	}
+
+	status, env := call(t, srv, "POST", "/reports/campaigns", `{"startTime":"2025-01-01","endTime":"2025-02-15","granularity":"HOURLY"}`)
+	if status != http.StatusBadRequest || env.Error == nil || env.Error.Errors[0].MessageCode != "INVALID_DATE_RANGE" {
+		t.Fatalf("hourly over 30 days: status %d, error %+v", status, env.Error)
+	}
+}
+
+// TestSplitWindowsAreAccepted sends every window report.Split produces to the
+// mock, whose limits are kept separately from the client's.
+func TestSplitWindowsAreAccepted(t *testing.T) {
+	srv := httptest.NewServer(New(Options{OrgID: 42, Seed: true}))
+	defer srv.Close()
+
+	start, _ := time.Parse(report.DateLayout, "2023-01-15")
+	end, _ := time.Parse(report.DateLayout, "2026-06-30")
+	for _, g := range []string{"", "HOURLY", "DAILY", "WEEKLY", "MONTHLY"} {
+		for _, w := range report.Split(start, end, g) {
+			body := fmt.Sprintf(`{"startTime":%q,"endTime":%q,"granularity":%q}`, w.Start.Format(report.DateLayout), w.End.Format(report.DateLayout), g)
+			if status, env := call(t, srv, "POST", "/reports/campaigns", body); status != http.StatusOK {
+				t.Fatalf("%s window %s: status %d, error %+v", orDefault(g, "row totals"), body, status, env.Error)
+			}
+		}
+	}
+}
+
+func TestCustomReportLifecycle(t *testing.T) {
+	srv := httptest.NewServer(New(Options{OrgID: 42, Seed: true}))
+	defer srv.Close()
+
+	_, env := call(t, srv, "POST", "/custom-reports", `{"startTime":"2025-01-01","endTime":"2025-01-07"}`)
+	var rep struct {
+		ID          int64  `json:"id"`
+		State       string `json:"state"`
+		DownloadURI string `json:"downloadUri"`
+	}
+	if err := json.Unmarshal(env.Data, &rep); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"RUNNING", "COMPLETED"} {
+		_, env = call(t, srv, "GET", "/custom-reports/"+jsonInt(rep.ID), "")
+		if err := json.Unmarshal(env.Data, &rep); err != nil {
+			t.Fatal(err)
+		}
+		if rep.State != want {
+			t.Fatalf("state = %s, want %s", rep.State, want)
+		}
+	}
+
+	resp, err := srv.Client().Get(rep.DownloadURI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/csv" {
+		t.Fatalf("download: status %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+}
+
+func jsonInt(n int64) string {
+	b, _ := json.Marshal(n)
+	return string(b)
+}
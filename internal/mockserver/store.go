@@ -0,0 +1,288 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// record is one stored entity in its JSON form. Keeping entities as maps lets
+// selectors filter and sort on any field the API exposes.
+type record = map[string]any
+
+// table is an insertion-ordered collection of records.
+type table struct {
+	rows []record
+}
+
+func (t *table) add(r record) {
+	t.rows = append(t.rows, r)
+}
+
+func (t *table) get(id int64, match func(record) bool) record {
+	for _, r := range t.rows {
+		if idOf(r) == id && (match == nil || match(r)) {
+			return r
+		}
+	}
+	return nil
+}
+
+func (t *table) filter(match func(record) bool) []record {
+	var out []record
+	for _, r := range t.rows {
+		if match == nil || match(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// remove deletes every record matching match and returns how many were removed.
+func (t *table) remove(match func(record) bool) int {
+	kept := t.rows[:0]
+	n := 0
+	for _, r := range t.rows {
+		if match(r) {
+			n++
+			continue
+		}
+		kept = append(kept, r)
+	}
+	t.rows = kept
+	return n
+}
+
+func idOf(r record) int64 {
+	return int64Field(r, "id")
+}
+
+func int64Field(r record, field string) int64 {
+	switch v := r[field].(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case json.Number:
+		n, _ := v.Int64()
+		return n
+	case string:
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	}
+	return 0
+}
+
+func stringField(r record, field string) string {
+	s, _ := r[field].(string)
+	return s
+}
+
+func belongsTo(campaignID, adGroupID int64) func(record) bool {
+	return func(r record) bool {
+		if int64Field(r, "campaignId") != campaignID {
+			return false
+		}
+		if adGroupID >= 0 && int64Field(r, "adGroupId") != adGroupID {
+			return false
+		}
+		return true
+	}
+}
+
+// fieldStrings returns the value of field as strings: one element for
+// scalars, one per element for arrays. Money objects compare on amount.
+func fieldStrings(r record, field string) ([]string, bool) {
+	v, ok := r[field]
+	if !ok || v == nil {
+		return nil, false
+	}
+	switch x := v.(type) {
+	case []any:
+		out := make([]string, len(x))
+		for i, e := range x {
+			out[i] = fmt.Sprint(e)
+		}
+		return out, true
+	case []string:
+		return x, true
+	case map[string]any:
+		if amt, ok := x["amount"]; ok {
+			return []string{fmt.Sprint(amt)}, true
+		}
+		b, _ := json.Marshal(x)
+		return []string{string(b)}, true
+	default:
+		return []string{fmt.Sprint(x)}, true
+	}
+}
+
+// matchSelector reports whether r satisfies every condition.
+func matchSelector(r record, conds []*types.Condition) (bool, error) {
+	for _, c := range conds {
+		ok, err := matchCondition(r, c)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchCondition(r record, c *types.Condition) (bool, error) {
+	have, _ := fieldStrings(r, c.Field)
+	op := strings.ToUpper(c.Operator)
+
+	anyHave := func(pred func(h, v string) bool) bool {
+		for _, h := range have {
+			for _, v := range c.Values {
+				if pred(h, v) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	eq := func(h, v string) bool { return strings.EqualFold(h, v) }
+
+	switch op {
+	case "EQUALS", "IN":
+		return anyHave(eq), nil
+	case "NOT_EQUALS", "NOT_IN":
+		return !anyHave(eq), nil
+	case "CONTAINS", "CONTAINS_ANY":
+		if op == "CONTAINS" && len(have) == 1 {
+			return anyHave(func(h, v string) bool { return strings.Contains(strings.ToLower(h), strings.ToLower(v)) }), nil
+		}
+		return anyHave(eq), nil
+	case "CONTAINS_ALL":
+		for _, v := range c.Values {
+			found := false
+			for _, h := range have {
+				if eq(h, v) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false, nil
+			}
+		}
+		return true, nil
+	case "STARTSWITH":
+		return anyHave(func(h, v string) bool { return strings.HasPrefix(strings.ToLower(h), strings.ToLower(v)) }), nil
+	case "ENDSWITH":
+		return anyHave(func(h, v string) bool { return strings.HasSuffix(strings.ToLower(h), strings.ToLower(v)) }), nil
+	case "GREATER_THAN", "LESS_THAN":
+		return anyHave(func(h, v string) bool {
+			hf, err1 := strconv.ParseFloat(h, 64)
+			vf, err2 := strconv.ParseFloat(v, 64)
+			if err1 != nil || err2 != nil {
+				return false
+			}
+			if op == "GREATER_THAN" {
+				return hf > vf
+			}
+			return hf < vf
+		}), nil
+	default:
+		return false, fmt.Errorf("unsupported operator %q", c.Operator)
+	}
+}
+
+// sortRecords orders rows by the selector's orderBy, comparing numerically
+// when both values are numbers.
+func sortRecords(rows []record, orderBy []*types.Sorting) {
+	if len(orderBy) == 0 {
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, o := range orderBy {
+			a, _ := fieldStrings(rows[i], o.Field)
+			b, _ := fieldStrings(rows[j], o.Field)
+			c := compareValues(first(a), first(b))
+			if c == 0 {
+				continue
+			}
+			if strings.EqualFold(o.SortOrder, "DESCENDING") {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+func first(v []string) string {
+	if len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+func compareValues(a, b string) int {
+	af, err1 := strconv.ParseFloat(a, 64)
+	bf, err2 := strconv.ParseFloat(b, 64)
+	if err1 == nil && err2 == nil {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// project keeps only the requested fields (plus id).
+func project(rows []record, fields []string) []record {
+	if len(fields) == 0 {
+		return rows
+	}
+	out := make([]record, len(rows))
+	for i, r := range rows {
+		p := record{"id": r["id"]}
+		for _, f := range fields {
+			if v, ok := r[f]; ok {
+				p[f] = v
+			}
+		}
+		out[i] = p
+	}
+	return out
+}
+
+// paginate slices rows and builds the PageDetail envelope. A limit <= 0
+// means def.
+func paginate(rows []record, offset, limit, def int) ([]record, *types.PageDetail) {
+	if limit <= 0 {
+		limit = def
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	total := len(rows)
+	if offset > total {
+		offset = total
+	}
+	end := min(offset+limit, total)
+	out := rows[offset:end]
+	if out == nil {
+		out = []record{}
+	}
+	return out, &types.PageDetail{TotalResults: total, StartIndex: offset, ItemsPerPage: len(out)}
+}
+
+func clone(r record) record {
+	out := make(record, len(r))
+	for k, v := range r {
+		out[k] = v
+	}
+	return out
+}
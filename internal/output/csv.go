@@ -0,0 +1,76 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// printDelimited writes rows as delimited text with a header line: RFC 4180
+// CSV with CRLF line endings for a comma, LF-terminated lines for TSV. Nested
+// objects are flattened into dotted columns and arrays are joined. Data that
+// isn't rows falls back to JSON, as in table output.
+func printDelimited(w io.Writer, data any, comma rune) error {
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice && v.Len() == 0 {
+		return nil
+	}
+
+	rows, err := toRows(data)
+	if err != nil {
+		return printJSON(w, data)
+	}
+	for i, row := range rows {
+		rows[i] = flattenMap(row, "")
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	cw.UseCRLF = comma == ','
+	if len(rows) == 0 {
+		cw.Flush()
+		return cw.Error()
+	}
+
+	headers := rowHeaders(rows)
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(headers))
+		for i, h := range headers {
+			record[i] = cellString(row[h])
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// cellString renders a single flattened value for delimited output.
+func cellString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []any:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			parts = append(parts, cellString(item))
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(b)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
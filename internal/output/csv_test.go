@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestPrintDelimitedFlattensAndQuotes(t *testing.T) {
+	type money struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	type item struct {
+		ID        int64    `json:"id"`
+		Name      string   `json:"name"`
+		Countries []string `json:"countries"`
+		Budget    *money   `json:"budget,omitempty"`
+	}
+	data := []item{
+		{ID: 1, Name: `Brand, "US"`, Countries: []string{"US", "CA"}, Budget: &money{Amount: "10", Currency: "USD"}},
+		{ID: 2, Name: "Generic"},
+	}
+
+	var buf bytes.Buffer
+	if err := Print(&buf, FormatCSV, data); err != nil {
+		t.Fatalf("print csv: %v", err)
+	}
+	want := "budget.amount,budget.currency,countries,id,name\r\n" +
+		"10,USD,\"US,CA\",1,\"Brand, \"\"US\"\"\"\r\n" +
+		",,,2,Generic\r\n"
+	if buf.String() != want {
+		t.Fatalf("csv output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := Print(&buf, FormatTSV, data[1:]); err != nil {
+		t.Fatalf("print tsv: %v", err)
+	}
+	if want := "countries\tid\tname\n\t2\tGeneric\n"; buf.String() != want {
+		t.Fatalf("tsv output:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestPrintDelimitedFallsBackToJSON(t *testing.T) {
+	for _, format := range []Format{FormatCSV, FormatTSV} {
+		var buf bytes.Buffer
+		if err := Print(&buf, format, []string{"a", "b"}); err != nil {
+			t.Fatalf("print %s: %v", format, err)
+		}
+		var got []string
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil || len(got) != 2 || got[0] != "a" {
+			t.Errorf("%s output %q, want the JSON array", format, buf.String())
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+)
+
+// printNDJSON writes one compact JSON document per line. Slices emit one line
+// per element; any other value is written as a single line.
+func printNDJSON(w io.Writer, data any) error {
+	enc := json.NewEncoder(w)
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		return enc.Encode(data)
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := enc.Encode(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintNDJSONWritesOneLinePerElement(t *testing.T) {
+	type item struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+
+	var buf bytes.Buffer
+	if err := Print(&buf, FormatNDJSON, []item{{1, "Brand"}, {2, "Generic\nUS"}}); err != nil {
+		t.Fatalf("print ndjson: %v", err)
+	}
+	want := `{"id":1,"name":"Brand"}` + "\n" + `{"id":2,"name":"Generic\nUS"}` + "\n"
+	if buf.String() != want {
+		t.Fatalf("ndjson output:\n%q\nwant:\n%q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := Print(&buf, FormatNDJSON, item{3, "Single"}); err != nil {
+		t.Fatalf("print ndjson: %v", err)
+	}
+	if want := `{"id":3,"name":"Single"}` + "\n"; buf.String() != want {
+		t.Fatalf("ndjson output:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
@@ -8,11 +8,20 @@ import (
 type Format string
 
 const (
-	FormatJSON  Format = "json"
-	FormatTable Format = "table"
-	FormatYAML  Format = "yaml"
+	FormatJSON   Format = "json"
+	FormatTable  Format = "table"
+	FormatYAML   Format = "yaml"
+	FormatCSV    Format = "csv"
+	FormatTSV    Format = "tsv"
+	FormatNDJSON Format = "ndjson"
 )
 
+// IsTabular reports whether the format renders rows and columns rather than
+// the document structure.
+func (f Format) IsTabular() bool {
+	return f == FormatTable || f == FormatCSV || f == FormatTSV
+}
+
 // Print outputs data in the specified format.
 func Print(w io.Writer, format Format, data any) error {
 	switch format {
@@ -22,6 +31,12 @@ func Print(w io.Writer, format Format, data any) error {
 		return printTable(w, data)
 	case FormatYAML:
 		return printYAML(w, data)
+	case FormatCSV:
+		return printDelimited(w, data, ',')
+	case FormatTSV:
+		return printDelimited(w, data, '\t')
+	case FormatNDJSON:
+		return printNDJSON(w, data)
 	default:
 		return fmt.Errorf("unknown output format: %s", format)
 	}
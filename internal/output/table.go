@@ -22,18 +22,7 @@ func printTable(w io.Writer, data any) error {
 		return nil
 	}
 
-	// Collect all keys maintaining a stable order
-	keySet := make(map[string]bool)
-	for _, row := range rows {
-		for k := range row {
-			keySet[k] = true
-		}
-	}
-	var headers []string
-	for k := range keySet {
-		headers = append(headers, k)
-	}
-	sort.Strings(headers)
+	headers := rowHeaders(rows)
 
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
 
@@ -58,6 +47,22 @@ func printTable(w io.Writer, data any) error {
 	return tw.Flush()
 }
 
+// rowHeaders collects the union of keys across rows in a stable, sorted order.
+func rowHeaders(rows []map[string]any) []string {
+	keySet := make(map[string]bool)
+	for _, row := range rows {
+		for k := range row {
+			keySet[k] = true
+		}
+	}
+	var headers []string
+	for k := range keySet {
+		headers = append(headers, k)
+	}
+	sort.Strings(headers)
+	return headers
+}
+
 // unmarshalWithNumbers uses json.Decoder with UseNumber() to preserve integer precision.
 func unmarshalWithNumbers(data []byte, v any) error {
 	dec := json.NewDecoder(bytes.NewReader(data))
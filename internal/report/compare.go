@@ -0,0 +1,132 @@
+package report
+
+import (
+	"math"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// Period is the date range one side of a comparison covers.
+type Period struct {
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+// Change is the difference between the current and previous value of a
+// metric.
+type Change struct {
+	Abs float64 `json:"abs"`
+	// Pct is the change in percent of the previous value. It is nil when
+	// the previous value is zero.
+	Pct *float64 `json:"pct,omitempty"`
+}
+
+// ComparedRow pairs the row totals of one entity across two periods. A side
+// is nil when the entity has no row in that period; its metrics count as
+// zero in Change.
+type ComparedRow struct {
+	Metadata map[string]any    `json:"metadata,omitempty"`
+	Current  *types.SpendRow   `json:"current,omitempty"`
+	Previous *types.SpendRow   `json:"previous,omitempty"`
+	Change   map[string]Change `json:"change"`
+}
+
+// Comparison is a period-over-period report.
+type Comparison struct {
+	Current     Period        `json:"current"`
+	Previous    Period        `json:"previous"`
+	Row         []ComparedRow `json:"row"`
+	GrandTotals *ComparedRow  `json:"grandTotals,omitempty"`
+}
+
+// PreviousPeriod returns the range of the same length that ends the day
+// before w starts.
+func PreviousPeriod(w Window) Window {
+	end := w.Start.AddDate(0, 0, -1)
+	return Window{Start: end.AddDate(0, 0, 1-w.Days()), End: end}
+}
+
+// Compare joins the row totals of cur and prev with RowKey. Rows keep the
+// order of cur; rows only present in prev follow in their own order.
+func Compare(cur, prev *types.ReportingDataResponse) *Comparison {
+	out := &Comparison{Row: []ComparedRow{}}
+	var curRows, prevRows []types.ReportRow
+	if cur != nil {
+		curRows = cur.Row
+	}
+	if prev != nil {
+		prevRows = prev.Row
+	}
+
+	prevByKey := make(map[string]*types.ReportRow, len(prevRows))
+	for i := range prevRows {
+		prevByKey[RowKey(prevRows[i])] = &prevRows[i]
+	}
+	matched := map[string]bool{}
+	for _, r := range curRows {
+		key := RowKey(r)
+		var p *types.SpendRow
+		if pr := prevByKey[key]; pr != nil {
+			p = pr.Total
+			matched[key] = true
+		}
+		out.Row = append(out.Row, compareRow(r.Metadata, r.Total, p))
+	}
+	for _, r := range prevRows {
+		if !matched[RowKey(r)] {
+			out.Row = append(out.Row, compareRow(r.Metadata, nil, r.Total))
+		}
+	}
+
+	if cur != nil && prev != nil && cur.GrandTotals != nil && prev.GrandTotals != nil {
+		g := compareRow(nil, cur.GrandTotals.Total, prev.GrandTotals.Total)
+		out.GrandTotals = &g
+	}
+	return out
+}
+
+func compareRow(meta map[string]any, cur, prev *types.SpendRow) ComparedRow {
+	row := ComparedRow{Metadata: meta, Current: cur, Previous: prev, Change: map[string]Change{}}
+	for _, name := range Metrics {
+		c, cok := Metric(cur, name)
+		p, pok := Metric(prev, name)
+		if !cok && !pok {
+			continue
+		}
+		ch := Change{Abs: roundTo(c-p, metricScale(cur, prev, name))}
+		if p != 0 {
+			pct := roundTo((c-p)/p*100, 2)
+			ch.Pct = &pct
+		}
+		row.Change[name] = ch
+	}
+	return row
+}
+
+// metricScale is the number of decimals a change in the named metric is
+// reported with: the precision of the money amounts, four for rates and
+// none for counters.
+func metricScale(cur, prev *types.SpendRow, name string) int {
+	switch name {
+	case "ttr", "conversionRate":
+		return 4
+	case "avgCPA", "avgCPT", "localSpend":
+		scale := 0
+		for _, r := range []*types.SpendRow{cur, prev} {
+			if r == nil {
+				continue
+			}
+			m := map[string]*types.Money{"avgCPA": r.AvgCPA, "avgCPT": r.AvgCPT, "localSpend": r.LocalSpend}[name]
+			if _, s, ok := amount(m); ok {
+				scale = max(scale, s)
+			}
+		}
+		return scale
+	}
+	return 0
+}
+
+func roundTo(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
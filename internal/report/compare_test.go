@@ -0,0 +1,60 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func TestPreviousPeriod(t *testing.T) {
+	p := PreviousPeriod(Window{Start: day("2025-03-01"), End: day("2025-03-31")})
+	if got := p.Start.Format(DateLayout) + ".." + p.End.Format(DateLayout); got != "2025-01-29..2025-02-28" {
+		t.Fatalf("previous period = %s", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	row := func(id int64, s types.SpendRow) types.ReportRow {
+		return types.ReportRow{Metadata: map[string]any{"campaignId": id}, Total: &s}
+	}
+	a, aPrev := spend(1200, 60, 30, "150"), spend(1000, 50, 20, "100")
+	a.ConversionRate, aPrev.ConversionRate = 0.5, 0.4
+	cur := &types.ReportingDataResponse{
+		Row:         []types.ReportRow{row(1, a), row(2, spend(10, 1, 1, "5"))},
+		GrandTotals: &types.ReportRow{Total: &a},
+	}
+	prev := &types.ReportingDataResponse{
+		Row:         []types.ReportRow{row(3, spend(5, 0, 0, "0")), row(1, aPrev)},
+		GrandTotals: &types.ReportRow{Total: &aPrev},
+	}
+
+	got := Compare(cur, prev)
+	if len(got.Row) != 3 {
+		t.Fatalf("got %d rows, want 3", len(got.Row))
+	}
+
+	r := got.Row[0]
+	if r.Previous == nil || r.Previous.Impressions != 1000 {
+		t.Fatalf("row 1 not joined: %+v", r)
+	}
+	if c := r.Change["impressions"]; c.Abs != 200 || *c.Pct != 20 {
+		t.Errorf("impressions change = %+v", c)
+	}
+	if c := r.Change["localSpend"]; c.Abs != 50 || *c.Pct != 50 {
+		t.Errorf("spend change = %+v", c)
+	}
+	if c := r.Change["conversionRate"]; c.Abs != 0.1 || *c.Pct != 25 {
+		t.Errorf("conversion rate change = %+v", c)
+	}
+
+	// New and dropped entities compare against zero.
+	if r := got.Row[1]; r.Previous != nil || r.Change["installs"].Abs != 1 || r.Change["installs"].Pct != nil {
+		t.Errorf("new row = %+v", r)
+	}
+	if r := got.Row[2]; r.Current != nil || r.Metadata["campaignId"] != int64(3) || r.Change["impressions"].Abs != -5 {
+		t.Errorf("dropped row = %+v", r)
+	}
+	if got.GrandTotals == nil || got.GrandTotals.Change["taps"].Abs != 10 {
+		t.Errorf("grand totals = %+v", got.GrandTotals)
+	}
+}
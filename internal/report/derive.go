@@ -0,0 +1,93 @@
+package report
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// Derive computes DerivedMetrics for every row total, granularity bucket and
+// the grand totals of data.
+//
+// Spend share compares a row total with the spend of all rows, and a bucket
+// with the spend of all rows on the same date. No shares are computed when
+// the rows are in more than one currency.
+func Derive(data *types.ReportingDataResponse) {
+	if data == nil {
+		return
+	}
+
+	var all money
+	byDate := map[string]*money{}
+	for _, r := range data.Row {
+		all.add(spendOf(r.Total))
+		for _, g := range r.Granularity {
+			m := byDate[g.Date]
+			if m == nil {
+				m = &money{}
+				byDate[g.Date] = m
+			}
+			m.add(g.LocalSpend)
+		}
+	}
+
+	for i := range data.Row {
+		r := &data.Row[i]
+		if r.Total != nil {
+			r.Total.Derived = derive(r.Total, &all)
+		}
+		for j := range r.Granularity {
+			g := &r.Granularity[j]
+			g.Derived = derive(&g.SpendRow, byDate[g.Date])
+		}
+	}
+	if data.GrandTotals != nil && data.GrandTotals.Total != nil {
+		data.GrandTotals.Total.Derived = derive(data.GrandTotals.Total, nil)
+	}
+}
+
+func spendOf(r *types.SpendRow) *types.Money {
+	if r == nil {
+		return nil
+	}
+	return r.LocalSpend
+}
+
+// derive computes the KPIs of r. spendShare is taken against total, when
+// given.
+func derive(r *types.SpendRow, total *money) *types.DerivedMetrics {
+	d := &types.DerivedMetrics{
+		TTR:             ratio(r.Taps, r.Impressions),
+		ConversionRate:  ratio(r.Installs, r.Taps),
+		RedownloadShare: ratio(r.Redownloads, r.Installs),
+	}
+
+	var spend money
+	spend.add(r.LocalSpend)
+	if r.Taps != 0 {
+		d.CPT = spend.per(r.Taps)
+	}
+	if r.Installs != 0 {
+		d.CPI = spend.per(r.Installs)
+	}
+	if r.NewDownloads != 0 {
+		d.CostPerNewDownload = spend.per(r.NewDownloads)
+	}
+
+	if total != nil && !total.mixed && total.sum != nil && total.sum.Sign() != 0 && spend.sum != nil {
+		f, _ := new(big.Rat).Quo(spend.sum, total.sum).Float64()
+		f = math.Round(f*10000) / 10000
+		d.SpendShare = &f
+	}
+	return d
+}
+
+// ratio is a/b rounded to four decimals, or nil when b is zero.
+func ratio(a, b int64) *float64 {
+	if b == 0 {
+		return nil
+	}
+	f := rate(a, b)
+	return &f
+}
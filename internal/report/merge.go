@@ -0,0 +1,270 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// Metrics lists the SpendRow fields by their JSON names.
+var Metrics = []string{
+	"impressions", "taps", "installs", "newDownloads", "redownloads",
+	"latOnInstalls", "latOffInstalls", "ttr", "conversionRate",
+	"avgCPA", "avgCPT", "localSpend",
+}
+
+// Metric returns the named SpendRow field as a float. Money fields use their
+// amount. ok is false for an unknown name or a missing money value.
+func Metric(r *types.SpendRow, name string) (v float64, ok bool) {
+	if r == nil {
+		return 0, false
+	}
+	switch name {
+	case "impressions":
+		return float64(r.Impressions), true
+	case "taps":
+		return float64(r.Taps), true
+	case "installs":
+		return float64(r.Installs), true
+	case "newDownloads":
+		return float64(r.NewDownloads), true
+	case "redownloads":
+		return float64(r.Redownloads), true
+	case "latOnInstalls":
+		return float64(r.LatOnInstalls), true
+	case "latOffInstalls":
+		return float64(r.LatOffInstalls), true
+	case "ttr":
+		return r.TTR, true
+	case "conversionRate":
+		return r.ConversionRate, true
+	case "avgCPA":
+		return moneyFloat(r.AvgCPA)
+	case "avgCPT":
+		return moneyFloat(r.AvgCPT)
+	case "localSpend":
+		return moneyFloat(r.LocalSpend)
+	}
+	return 0, false
+}
+
+func moneyFloat(m *types.Money) (float64, bool) {
+	v, _, ok := amount(m)
+	if !ok {
+		return 0, false
+	}
+	f, _ := v.Float64()
+	return f, true
+}
+
+// Sum accumulates SpendRows. Counters add up; rates and averages are
+// recomputed from the sums rather than averaged.
+type Sum struct {
+	Impressions, Taps, Installs, NewDownloads, Redownloads int64
+	LatOnInstalls, LatOffInstalls                          int64
+
+	spend money
+}
+
+// Add adds r to the sum. A nil r is ignored.
+func (s *Sum) Add(r *types.SpendRow) {
+	if r == nil {
+		return
+	}
+	s.Impressions += r.Impressions
+	s.Taps += r.Taps
+	s.Installs += r.Installs
+	s.NewDownloads += r.NewDownloads
+	s.Redownloads += r.Redownloads
+	s.LatOnInstalls += r.LatOnInstalls
+	s.LatOffInstalls += r.LatOffInstalls
+	s.spend.add(r.LocalSpend)
+}
+
+// Row returns the sum as a SpendRow with TTR, conversion rate, average CPT
+// and average CPA derived from the totals.
+func (s *Sum) Row() *types.SpendRow {
+	return &types.SpendRow{
+		Impressions:    s.Impressions,
+		Taps:           s.Taps,
+		Installs:       s.Installs,
+		NewDownloads:   s.NewDownloads,
+		Redownloads:    s.Redownloads,
+		LatOnInstalls:  s.LatOnInstalls,
+		LatOffInstalls: s.LatOffInstalls,
+		TTR:            rate(s.Taps, s.Impressions),
+		ConversionRate: rate(s.Installs, s.Taps),
+		AvgCPT:         s.spend.per(s.Taps),
+		AvgCPA:         s.spend.per(s.Installs),
+		LocalSpend:     s.spend.value(),
+	}
+}
+
+// rate is a/b rounded to four decimals, as Apple reports it.
+func rate(a, b int64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return math.Round(float64(a)/float64(b)*10000) / 10000
+}
+
+// keyFields identify a report row: the entity IDs for each report level plus
+// the groupBy dimensions.
+var keyFields = []string{
+	"campaignId", "adGroupId", "keywordId", "adId", "searchTermText", "searchTermSource",
+	"countryOrRegion", "countryCode", "deviceClass", "ageRange", "gender", "adminArea", "locality",
+}
+
+// RowKey identifies the entity (and dimension values) a row reports on, so
+// rows from different requests can be matched. Rows without any known ID
+// field fall back to their full metadata.
+func RowKey(r types.ReportRow) string {
+	var parts []string
+	for _, f := range keyFields {
+		if v, ok := r.Metadata[f]; ok && v != nil {
+			parts = append(parts, f+"="+fmt.Sprint(v))
+		}
+	}
+	if len(parts) == 0 {
+		b, _ := json.Marshal(r.Metadata)
+		return string(b)
+	}
+	return strings.Join(parts, "|")
+}
+
+// Merge combines responses for consecutive date windows of the same request.
+// Rows are matched with RowKey; totals and same-date granularity buckets are
+// summed, with rates and averages recomputed. Metadata and insights come
+// from the latest window. Grand totals, when any part has them, are
+// recomputed from the merged rows.
+func Merge(parts []*types.ReportingDataResponse) *types.ReportingDataResponse {
+	type bucket struct {
+		date string
+		sum  Sum
+	}
+	type merged struct {
+		row      types.ReportRow
+		total    Sum
+		hasTotal bool
+		buckets  map[string]*bucket
+		order    []string
+	}
+
+	var (
+		rows      []*merged
+		byKey     = map[string]*merged{}
+		wantGrand bool
+	)
+	for _, p := range parts {
+		if p == nil {
+			continue
+		}
+		if p.GrandTotals != nil {
+			wantGrand = true
+		}
+		for _, r := range p.Row {
+			key := RowKey(r)
+			m := byKey[key]
+			if m == nil {
+				m = &merged{buckets: map[string]*bucket{}}
+				byKey[key] = m
+				rows = append(rows, m)
+			}
+			if r.Metadata != nil {
+				m.row.Metadata = r.Metadata
+			}
+			if r.Other {
+				m.row.Other = true
+			}
+			if r.Insights != nil {
+				m.row.Insights = r.Insights
+			}
+			if r.Total != nil {
+				m.hasTotal = true
+				m.total.Add(r.Total)
+			}
+			for _, g := range r.Granularity {
+				b := m.buckets[g.Date]
+				if b == nil {
+					b = &bucket{date: g.Date}
+					m.buckets[g.Date] = b
+					m.order = append(m.order, g.Date)
+				}
+				b.sum.Add(&g.SpendRow)
+			}
+		}
+	}
+
+	out := &types.ReportingDataResponse{Row: make([]types.ReportRow, 0, len(rows))}
+	var grand Sum
+	for _, m := range rows {
+		r := m.row
+		if m.hasTotal {
+			r.Total = m.total.Row()
+			grand.Add(r.Total)
+		}
+		sort.Strings(m.order)
+		for _, d := range m.order {
+			r.Granularity = append(r.Granularity, types.GranularityRow{Date: d, SpendRow: *m.buckets[d].sum.Row()})
+			if !m.hasTotal {
+				grand.Add(&r.Granularity[len(r.Granularity)-1].SpendRow)
+			}
+		}
+		out.Row = append(out.Row, r)
+	}
+	if wantGrand {
+		out.GrandTotals = &types.ReportRow{Total: grand.Row()}
+	}
+	return out
+}
+
+// SortRows orders rows by the selector's orderBy. Fields name a SpendRow
+// metric (compared on row totals) or a metadata key.
+func SortRows(rows []types.ReportRow, orderBy []*types.Sorting) {
+	if len(orderBy) == 0 {
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, o := range orderBy {
+			c := compareField(rows[i], rows[j], o.Field)
+			if c == 0 {
+				continue
+			}
+			if strings.EqualFold(o.SortOrder, "DESCENDING") {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+func compareField(a, b types.ReportRow, field string) int {
+	av, aok := Metric(a.Total, field)
+	bv, bok := Metric(b.Total, field)
+	if !aok && !bok {
+		as, bs := fmt.Sprint(a.Metadata[field]), fmt.Sprint(b.Metadata[field])
+		af, aerr := strconv.ParseFloat(as, 64)
+		bf, berr := strconv.ParseFloat(bs, 64)
+		if aerr != nil || berr != nil {
+			return strings.Compare(as, bs)
+		}
+		av, bv, aok, bok = af, bf, true, true
+	}
+	switch {
+	case aok && !bok:
+		return 1
+	case !aok && bok:
+		return -1
+	case av < bv:
+		return -1
+	case av > bv:
+		return 1
+	}
+	return 0
+}
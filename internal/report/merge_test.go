@@ -0,0 +1,96 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+func spend(impr, taps, installs int64, amount string) types.SpendRow {
+	return types.SpendRow{Impressions: impr, Taps: taps, Installs: installs, LocalSpend: &types.Money{Amount: amount, Currency: "JPY"}}
+}
+
+func TestMergeSumsRowsAndRecomputesTotals(t *testing.T) {
+	row := func(id int64, status string, total types.SpendRow, date string) types.ReportRow {
+		return types.ReportRow{
+			Metadata:    map[string]any{"campaignId": id, "campaignStatus": status},
+			Total:       &total,
+			Granularity: []types.GranularityRow{{Date: date, SpendRow: total}},
+		}
+	}
+	first := &types.ReportingDataResponse{
+		Row:         []types.ReportRow{row(1, "ENABLED", spend(1000, 100, 10, "500"), "2025-01-01")},
+		GrandTotals: &types.ReportRow{},
+	}
+	second := &types.ReportingDataResponse{
+		Row: []types.ReportRow{
+			row(1, "PAUSED", spend(3000, 200, 20, "1001"), "2025-04-01"),
+			row(2, "ENABLED", spend(10, 1, 0, "7"), "2025-04-01"),
+		},
+		GrandTotals: &types.ReportRow{},
+	}
+
+	got := Merge([]*types.ReportingDataResponse{first, second})
+	if len(got.Row) != 2 {
+		t.Fatalf("got %d rows, want 2", len(got.Row))
+	}
+
+	r := got.Row[0]
+	if r.Metadata["campaignStatus"] != "PAUSED" {
+		t.Errorf("metadata not taken from the latest window: %v", r.Metadata)
+	}
+	tot := r.Total
+	if tot.Impressions != 4000 || tot.Taps != 300 || tot.Installs != 30 {
+		t.Errorf("counters = %+v", tot)
+	}
+	if tot.TTR != 0.075 || tot.ConversionRate != 0.1 {
+		t.Errorf("ttr=%v cr=%v, want 0.075 and 0.1", tot.TTR, tot.ConversionRate)
+	}
+	// Yen amounts have no minor unit, so averages round to whole yen.
+	if tot.LocalSpend.Amount != "1501" || tot.AvgCPT.Amount != "5" || tot.AvgCPA.Amount != "50" || tot.AvgCPA.Currency != "JPY" {
+		t.Errorf("money = spend %v cpt %v cpa %v", tot.LocalSpend, tot.AvgCPT, tot.AvgCPA)
+	}
+	if len(r.Granularity) != 2 || r.Granularity[0].Date != "2025-01-01" || r.Granularity[1].Date != "2025-04-01" {
+		t.Errorf("granularity = %+v", r.Granularity)
+	}
+
+	if got.Row[1].Total.AvgCPA.Amount != "0" {
+		t.Errorf("avgCPA without installs = %v, want 0", got.Row[1].Total.AvgCPA)
+	}
+	if g := got.GrandTotals; g == nil || g.Total.Impressions != 4010 || g.Total.LocalSpend.Amount != "1508" {
+		t.Errorf("grand totals = %+v", got.GrandTotals)
+	}
+}
+
+func TestDerive(t *testing.T) {
+	a := spend(1000, 50, 20, "300")
+	a.NewDownloads, a.Redownloads = 15, 5
+	b := spend(500, 0, 0, "100")
+	data := &types.ReportingDataResponse{
+		Row: []types.ReportRow{
+			{Total: &a, Granularity: []types.GranularityRow{{Date: "2025-01-01", SpendRow: a}}},
+			{Total: &b, Granularity: []types.GranularityRow{{Date: "2025-01-01", SpendRow: b}}},
+		},
+		GrandTotals: &types.ReportRow{Total: &types.SpendRow{}},
+	}
+	Derive(data)
+
+	d := data.Row[0].Total.Derived
+	if d.CPT.Amount != "6" || d.CPT.Currency != "JPY" || d.CPI.Amount != "15" || d.CPI.Currency != "JPY" || d.CostPerNewDownload.Amount != "20" {
+		t.Errorf("cpt=%v cpi=%v cpnd=%v", d.CPT, d.CPI, d.CostPerNewDownload)
+	}
+	if *d.TTR != 0.05 || *d.ConversionRate != 0.4 || *d.RedownloadShare != 0.25 || *d.SpendShare != 0.75 {
+		t.Errorf("ttr=%v cr=%v redownloads=%v share=%v", *d.TTR, *d.ConversionRate, *d.RedownloadShare, *d.SpendShare)
+	}
+	if g := data.Row[0].Granularity[0].Derived; g.SpendShare == nil || *g.SpendShare != 0.75 {
+		t.Errorf("bucket share = %+v", g)
+	}
+
+	// Zero denominators leave the KPI out rather than reporting zero.
+	if d := data.Row[1].Total.Derived; d.CPT != nil || d.CPI != nil || d.ConversionRate != nil || *d.SpendShare != 0.25 {
+		t.Errorf("no-install row = %+v", d)
+	}
+	if data.GrandTotals.Total.Derived == nil || data.GrandTotals.Total.Derived.SpendShare != nil {
+		t.Errorf("grand totals = %+v", data.GrandTotals.Total.Derived)
+	}
+}
@@ -0,0 +1,73 @@
+package report
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/SaadBelfqih/apple-ads-cli/internal/types"
+)
+
+// Amounts are summed and divided as exact rationals and printed with the
+// precision Apple used for the inputs, so currencies without minor units
+// (JPY) or with three decimals (KWD) round correctly.
+
+// amount parses m, reporting its decimal places. ok is false for a nil or
+// malformed amount.
+func amount(m *types.Money) (v *big.Rat, scale int, ok bool) {
+	if m == nil || m.Amount == "" {
+		return nil, 0, false
+	}
+	v, ok = new(big.Rat).SetString(m.Amount)
+	if !ok {
+		return nil, 0, false
+	}
+	if i := strings.IndexByte(m.Amount, '.'); i >= 0 {
+		scale = len(m.Amount) - i - 1
+	}
+	return v, scale, true
+}
+
+// money is a running Money total.
+type money struct {
+	sum      *big.Rat
+	scale    int
+	currency string
+	mixed    bool // amounts in more than one currency were added
+}
+
+func (a *money) add(m *types.Money) {
+	v, scale, ok := amount(m)
+	if !ok {
+		return
+	}
+	if a.sum == nil {
+		a.sum = new(big.Rat)
+	}
+	a.sum.Add(a.sum, v)
+	a.scale = max(a.scale, scale)
+	switch {
+	case a.currency == "":
+		a.currency = m.Currency
+	case m.Currency != "" && m.Currency != a.currency:
+		a.mixed = true
+	}
+}
+
+func (a *money) value() *types.Money {
+	if a.sum == nil {
+		return nil
+	}
+	return &types.Money{Amount: a.sum.FloatString(a.scale), Currency: a.currency}
+}
+
+// per divides the total by n. Like Apple, it reports zero when n is zero.
+func (a *money) per(n int64) *types.Money {
+	if a.sum == nil {
+		return nil
+	}
+	q := new(big.Rat)
+	if n != 0 {
+		q.Quo(a.sum, new(big.Rat).SetInt64(n))
+	}
+	return &types.Money{Amount: q.FloatString(a.scale), Currency: a.currency}
+}
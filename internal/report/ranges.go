@@ -0,0 +1,62 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// Org time zones are IANA names; embed the database so they resolve on
+	// systems without one (notably Windows).
+	_ "time/tzdata"
+)
+
+// Ranges lists the relative date presets accepted by Resolve.
+var Ranges = []string{"yesterday", "last-7d", "last-30d", "wtd", "mtd", "last-month"}
+
+// Resolve turns a relative date preset into an inclusive date range. now
+// should already be in the time zone the report is computed in; "today" is
+// the calendar date of now in that zone.
+//
+// The last-N-days presets end yesterday so every day in them is complete.
+// The to-date presets (wtd, mtd) include today. Weeks start on Monday.
+func Resolve(name string, now time.Time) (Window, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "yesterday":
+		return Window{Start: yesterday, End: yesterday}, nil
+	case "last-7d":
+		return LastDays(7, now), nil
+	case "last-30d":
+		return LastDays(30, now), nil
+	case "wtd":
+		offset := (int(today.Weekday()) + 6) % 7 // days since Monday
+		return Window{Start: today.AddDate(0, 0, -offset), End: today}, nil
+	case "mtd":
+		return Window{Start: today.AddDate(0, 0, 1-today.Day()), End: today}, nil
+	case "last-month":
+		first := today.AddDate(0, 0, 1-today.Day())
+		return Window{Start: first.AddDate(0, -1, 0), End: first.AddDate(0, 0, -1)}, nil
+	}
+	return Window{}, fmt.Errorf("unknown range %q (want one of: %s)", name, strings.Join(Ranges, ", "))
+}
+
+// LastDays returns the n complete days before the calendar date of now.
+func LastDays(n int, now time.Time) Window {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return Window{Start: today.AddDate(0, 0, -n), End: today.AddDate(0, 0, -1)}
+}
+
+// Location loads the IANA time zone an org reports in, as returned in
+// UserACL.TimeZone.
+func Location(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, fmt.Errorf("empty time zone")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("load time zone %q: %w", name, err)
+	}
+	return loc, nil
+}
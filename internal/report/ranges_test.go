@@ -0,0 +1,45 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolve(t *testing.T) {
+	// Wednesday 2025-03-05, 01:30 UTC is still Tuesday in Los Angeles.
+	now := time.Date(2025, 3, 5, 1, 30, 0, 0, time.UTC)
+	la, err := Location("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want string
+	}{
+		{"yesterday", now, "2025-03-04..2025-03-04"},
+		{"last-7d", now, "2025-02-26..2025-03-04"},
+		{"last-30d", now, "2025-02-03..2025-03-04"},
+		{"wtd", now, "2025-03-03..2025-03-05"},
+		{"mtd", now, "2025-03-01..2025-03-05"},
+		{"last-month", now, "2025-02-01..2025-02-28"},
+		{"yesterday", now.In(la), "2025-03-03..2025-03-03"},
+		{"mtd", now.In(la), "2025-03-01..2025-03-04"},
+		{"last-month", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), "2024-12-01..2024-12-31"},
+		{"wtd", time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC), "2025-03-03..2025-03-09"},
+	}
+	for _, tt := range tests {
+		w, err := Resolve(tt.name, tt.now)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := w.Start.Format(DateLayout) + ".." + w.End.Format(DateLayout); got != tt.want {
+			t.Errorf("%s at %s = %s, want %s", tt.name, tt.now, got, tt.want)
+		}
+	}
+
+	if _, err := Resolve("last-week", now); err == nil {
+		t.Fatal("expected error for unknown range")
+	}
+}
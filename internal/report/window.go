@@ -0,0 +1,102 @@
+// Package report holds client-side processing for Apple Ads reports:
+// splitting long date ranges into windows the API accepts and merging the
+// results back into one response.
+package report
+
+import (
+	"strings"
+	"time"
+)
+
+// DateLayout is the format of report startTime and endTime.
+const DateLayout = "2006-01-02"
+
+// maxDays is the longest range, in days, Apple accepts per granularity.
+// Requests without a granularity (row totals only) follow the DAILY limit.
+var maxDays = map[string]int{
+	"HOURLY":  30,
+	"DAILY":   90,
+	"WEEKLY":  365,
+	"MONTHLY": 730,
+}
+
+// MaxDays returns the longest range Apple accepts for granularity, or 0 for
+// an unknown granularity.
+func MaxDays(granularity string) int {
+	g := strings.ToUpper(granularity)
+	if g == "" {
+		g = "DAILY"
+	}
+	return maxDays[g]
+}
+
+// impressionShareMaxDays is the longest range, in days, Apple accepts for an
+// impression share (custom) report per granularity.
+var impressionShareMaxDays = map[string]int{
+	"DAILY":  30,
+	"WEEKLY": 84,
+}
+
+// ImpressionShareMaxDays returns the longest range Apple accepts for an
+// impression share report, or 0 for an unsupported granularity.
+func ImpressionShareMaxDays(granularity string) int {
+	return impressionShareMaxDays[strings.ToUpper(granularity)]
+}
+
+// Window is an inclusive date range.
+type Window struct {
+	Start, End time.Time
+}
+
+// Days returns the number of days in w.
+func (w Window) Days() int {
+	return int(w.End.Sub(w.Start).Hours()/24) + 1
+}
+
+// Split divides [start, end] into consecutive windows no longer than the
+// granularity's limit. WEEKLY and MONTHLY windows end on bucket boundaries
+// (Sunday, end of month) where possible, so no bucket is split across two
+// requests. A range within the limit comes back as a single window.
+func Split(start, end time.Time, granularity string) []Window {
+	limit := MaxDays(granularity)
+	if limit <= 0 || end.Before(start) {
+		return []Window{{start, end}}
+	}
+
+	var out []Window
+	for cur := start; !cur.After(end); {
+		last := cur.AddDate(0, 0, limit-1)
+		if !last.Before(end) {
+			out = append(out, Window{cur, end})
+			break
+		}
+		if aligned := lastBucketEnd(cur, last, granularity); !aligned.IsZero() {
+			last = aligned
+		}
+		out = append(out, Window{cur, last})
+		cur = last.AddDate(0, 0, 1)
+	}
+	return out
+}
+
+// lastBucketEnd returns the latest day in [from, to] that closes a bucket
+// for granularity, or the zero time if there is none or buckets are daily.
+func lastBucketEnd(from, to time.Time, granularity string) time.Time {
+	var d time.Time
+	switch strings.ToUpper(granularity) {
+	case "WEEKLY":
+		// Weeks run Monday to Sunday.
+		d = to.AddDate(0, 0, -int(to.Weekday()))
+	case "MONTHLY":
+		d = time.Date(to.Year(), to.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+		if d.After(to) {
+			d = time.Date(to.Year(), to.Month(), 0, 0, 0, 0, 0, time.UTC)
+		}
+	default:
+		return time.Time{}
+	}
+	if d.Before(from) {
+		return time.Time{}
+	}
+	return d
+}
@@ -0,0 +1,50 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name        string
+		start, end  string
+		granularity string
+		want        []string
+	}{
+		{"within limit", "2025-01-01", "2025-03-31", "DAILY", []string{"2025-01-01..2025-03-31"}},
+		{"daily", "2025-01-01", "2025-06-30", "DAILY", []string{"2025-01-01..2025-03-31", "2025-04-01..2025-06-29", "2025-06-30..2025-06-30"}},
+		{"row totals use daily limit", "2025-01-01", "2025-04-01", "", []string{"2025-01-01..2025-03-31", "2025-04-01..2025-04-01"}},
+		// 2025-01-01 + 29 days is Thursday 2025-01-30.
+		{"hourly", "2025-01-01", "2025-02-15", "HOURLY", []string{"2025-01-01..2025-01-30", "2025-01-31..2025-02-15"}},
+		// The first window would end on Thursday 2025-12-31; it is pulled
+		// back to Sunday 2025-12-28 so the week isn't split.
+		{"weekly aligns to Sunday", "2025-01-01", "2026-03-01", "WEEKLY", []string{"2025-01-01..2025-12-28", "2025-12-29..2026-03-01"}},
+		{"monthly aligns to month end", "2024-01-15", "2026-06-30", "MONTHLY", []string{"2024-01-15..2025-12-31", "2026-01-01..2026-06-30"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Split(day(tt.start), day(tt.end), tt.granularity)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d windows %v, want %v", len(got), got, tt.want)
+			}
+			for i, w := range got {
+				s := w.Start.Format(DateLayout) + ".." + w.End.Format(DateLayout)
+				if s != tt.want[i] {
+					t.Errorf("window[%d] = %s, want %s", i, s, tt.want[i])
+				}
+				if w.Days() > MaxDays(tt.granularity) {
+					t.Errorf("window[%d] spans %d days", i, w.Days())
+				}
+			}
+		})
+	}
+}
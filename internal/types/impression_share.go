@@ -36,4 +36,20 @@ type CustomReportResponse struct {
 	CreationTime     string `json:"creationTime,omitempty"`
 	ModificationTime string `json:"modificationTime,omitempty"`
 	ReportRows       []any  `json:"reportRows,omitempty"`
+	DownloadURI      string `json:"downloadUri,omitempty"` // set once the report is COMPLETED
+}
+
+// ImpressionShareRow is one row of a downloaded impression share report.
+// Share values are fractions (0.1 is 10%); fields Apple left empty are nil.
+type ImpressionShareRow struct {
+	Date                string            `json:"date,omitempty"`
+	AppName             string            `json:"appName,omitempty"`
+	AdamID              int64             `json:"adamId,omitempty"`
+	CountryOrRegion     string            `json:"countryOrRegion,omitempty"`
+	SearchTerm          string            `json:"searchTerm,omitempty"`
+	LowImpressionShare  *float64          `json:"lowImpressionShare,omitempty"`
+	HighImpressionShare *float64          `json:"highImpressionShare,omitempty"`
+	Rank                string            `json:"rank,omitempty"`
+	SearchPopularity    *int              `json:"searchPopularity,omitempty"`
+	Other               map[string]string `json:"other,omitempty"` // columns not listed above
 }
@@ -26,7 +26,7 @@ type ReportingDataResponse struct {
 
 // ReportRow represents a single row in a report.
 type ReportRow struct {
-	Other    map[string]any `json:"other,omitempty"`
+	Other    bool           `json:"other"`
 	Total    *SpendRow      `json:"total,omitempty"`
 	Metadata map[string]any `json:"metadata,omitempty"`
 	Insights *Insights      `json:"insights,omitempty"`
@@ -34,9 +34,10 @@ type ReportRow struct {
 }
 
 // GranularityRow represents a time-bucketed row.
+// Apple returns the bucket's metrics inline next to its date.
 type GranularityRow struct {
-	Date  string    `json:"date,omitempty"`
-	Total *SpendRow `json:"total,omitempty"`
+	Date     string `json:"date,omitempty"`
+	SpendRow `yaml:",inline"`
 }
 
 // SpendRow holds metric fields.
@@ -53,6 +54,21 @@ type SpendRow struct {
 	AvgCPA          *Money  `json:"avgCPA,omitempty"`
 	AvgCPT          *Money  `json:"avgCPT,omitempty"`
 	LocalSpend      *Money  `json:"localSpend,omitempty"`
+
+	// Derived is filled in by the CLI (reports --derive), never by Apple.
+	Derived *DerivedMetrics `json:"derived,omitempty"`
+}
+
+// DerivedMetrics are KPIs computed from a SpendRow. A field is nil when its
+// denominator is zero or an input is missing.
+type DerivedMetrics struct {
+	CPT                *Money   `json:"cpt,omitempty"`                // localSpend / taps
+	CPI                *Money   `json:"cpi,omitempty"`                // localSpend / installs
+	CostPerNewDownload *Money   `json:"costPerNewDownload,omitempty"` // localSpend / newDownloads
+	TTR                *float64 `json:"ttr,omitempty"`                // taps / impressions
+	ConversionRate     *float64 `json:"conversionRate,omitempty"`     // installs / taps
+	RedownloadShare    *float64 `json:"redownloadShare,omitempty"`    // redownloads / installs
+	SpendShare         *float64 `json:"spendShare,omitempty"`         // localSpend / spend of all rows
 }
 
 // Insights holds bid recommendation data.